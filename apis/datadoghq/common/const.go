@@ -19,8 +19,14 @@ const (
 	AgentDeploymentComponentLabelKey = "agent.datadoghq.com/component"
 	// MD5AgentDeploymentProviderLabelKey label key is used to identify which provider is being used
 	MD5AgentDeploymentProviderLabelKey = "agent.datadoghq.com/provider"
+	// AgentDeploymentProfileLabelKey label key is used to identify which node Agent profile is being used
+	AgentDeploymentProfileLabelKey = "agent.datadoghq.com/profile"
 	// MD5AgentDeploymentAnnotationKey annotation key used on a Resource in order to identify which AgentDeployment have been used to generate it.
 	MD5AgentDeploymentAnnotationKey = "agent.datadoghq.com/agentspechash"
+	// DefaultsVersionAnnotationKey annotation key used on a DatadogAgent to record the revision of the defaults written to its spec
+	DefaultsVersionAnnotationKey = "agent.datadoghq.com/defaults-version"
+	// DefaultsChangesAnnotationKey annotation key used on a DatadogAgent to describe the defaults changed since the previously recorded revision
+	DefaultsChangesAnnotationKey = "agent.datadoghq.com/defaults-changes"
 	// MD5ChecksumAnnotationKey annotation key is used to identify customConfig configurations
 	MD5ChecksumAnnotationKey = "checksum/%s-custom-config"
 	// MD5ChecksumAnnotationKey is part of the key name to identify custom seccomp configurations
@@ -56,6 +62,12 @@ const (
 	DefaultAdmissionControllerTargetPort = 8000
 	// DefaultAdmissionControllerWebhookName default admission controller webhook name
 	DefaultAdmissionControllerWebhookName string = "datadog-webhook"
+	// DefaultAdmissionControllerCertificateSecretName default name of the Secret of the admission controller certificate, created by the Cluster Agent
+	DefaultAdmissionControllerCertificateSecretName = "webhook-certificate"
+	// AdmissionControllerCertificateSecretKey key of the PEM-encoded admission controller certificate in its Secret
+	AdmissionControllerCertificateSecretKey = "cert.pem"
+	// AdmissionControllerEnabledLabelKey pod label key used to select the pods mutated by the admission controller
+	AdmissionControllerEnabledLabelKey = "admission.datadoghq.com/enabled"
 	// DefaultDogstatsdPort default dogstatsd port
 	DefaultDogstatsdPort = 8125
 	// DefaultDogstatsdPortName default dogstatsd port name
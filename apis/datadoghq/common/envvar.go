@@ -8,6 +8,7 @@ package common
 // Datadog env var names
 const (
 	DatadogHost                                       = "DATADOG_HOST"
+	DDAdmissionControllerAutoInstrumentation          = "DD_ADMISSION_CONTROLLER_AUTO_INSTRUMENTATION_ENABLED"
 	DDAdmissionControllerEnabled                      = "DD_ADMISSION_CONTROLLER_ENABLED"
 	DDAdmissionControllerInjectConfig                 = "DD_ADMISSION_CONTROLLER_INJECT_CONFIG_ENABLED"
 	DDAdmissionControllerInjectConfigMode             = "DD_ADMISSION_CONTROLLER_INJECT_CONFIG_MODE"
@@ -19,6 +20,10 @@ const (
 	DDAdmissionControllerWebhookName                  = "DD_ADMISSION_CONTROLLER_WEBHOOK_NAME"
 	DDAPIKey                                          = "DD_API_KEY"
 	DDAPMEnabled                                      = "DD_APM_ENABLED"
+	DDAPMInstrumentationDisabledNamespaces            = "DD_APM_INSTRUMENTATION_DISABLED_NAMESPACES"
+	DDAPMInstrumentationEnabled                       = "DD_APM_INSTRUMENTATION_ENABLED"
+	DDAPMInstrumentationEnabledNamespaces             = "DD_APM_INSTRUMENTATION_ENABLED_NAMESPACES"
+	DDAPMInstrumentationLibVersions                   = "DD_APM_INSTRUMENTATION_LIB_VERSIONS"
 	DDAPMInstrumentationInstallTime                   = "DD_INSTRUMENTATION_INSTALL_TIME"
 	DDAPMInstrumentationInstallId                     = "DD_INSTRUMENTATION_INSTALL_ID"
 	DDAPMInstrumentationInstallType                   = "DD_INSTRUMENTATION_INSTALL_TYPE"
@@ -100,6 +105,9 @@ const (
 	DDPrometheusScrapeEnabled                         = "DD_PROMETHEUS_SCRAPE_ENABLED"
 	DDPrometheusScrapeServiceEndpoints                = "DD_PROMETHEUS_SCRAPE_SERVICE_ENDPOINTS"
 	DDPrometheusScrapeVersion                         = "DD_PROMETHEUS_SCRAPE_VERSION"
+	DDProxyHTTP                                       = "DD_PROXY_HTTP"
+	DDProxyHTTPS                                      = "DD_PROXY_HTTPS"
+	DDProxyNoProxy                                    = "DD_PROXY_NO_PROXY"
 	DDRemoteConfigurationEnabled                      = "DD_REMOTE_CONFIGURATION_ENABLED"
 	DDRuntimeSecurityConfigEnabled                    = "DD_RUNTIME_SECURITY_CONFIG_ENABLED"
 	DDRuntimeSecurityConfigPoliciesDir                = "DD_RUNTIME_SECURITY_CONFIG_POLICIES_DIR"
@@ -115,7 +123,9 @@ const (
 	DDSBOMContainerImageAnalyzers                     = "DD_SBOM_CONTAINER_IMAGE_ANALYZERS"
 	DDSBOMHostEnabled                                 = "DD_SBOM_HOST_ENABLED"
 	DDSBOMHostAnalyzers                               = "DD_SBOM_HOST_ANALYZERS"
+	DDSecretBackendArguments                          = "DD_SECRET_BACKEND_ARGUMENTS"
 	DDSecretBackendCommand                            = "DD_SECRET_BACKEND_COMMAND"
+	DDSecretBackendTimeout                            = "DD_SECRET_BACKEND_TIMEOUT"
 	DDSite                                            = "DD_SITE"
 	DDSystemProbeAgentEnabled                         = "DD_SYSTEM_PROBE_ENABLED"
 	DDSystemProbeBPFDebugEnabled                      = DDSystemProbeEnvPrefix + "BPF_DEBUG"
@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogDashboardSpec defines the desired state of DatadogDashboard
+// +k8s:openapi-gen=true
+type DatadogDashboardSpec struct {
+	// Definition is the JSON definition of the dashboard, as exported from the Datadog UI or returned by the dashboards API.
+	// Only one of Definition and DefinitionConfigMap can be set.
+	Definition string `json:"definition,omitempty"`
+
+	// DefinitionConfigMap references the ConfigMap key holding the JSON definition of the dashboard.
+	// The ConfigMap must be in the namespace of the DatadogDashboard.
+	DefinitionConfigMap *DatadogDashboardConfigMapKeySelector `json:"definitionConfigMap,omitempty"`
+
+	// Title is the title of the dashboard. It overrides the title of the definition.
+	Title string `json:"title,omitempty"`
+
+	// Description is the description of the dashboard. It overrides the description of the definition.
+	Description string `json:"description,omitempty"`
+
+	// TemplateVariables are the template variables of the dashboard. They override the template variables of the definition with the same name.
+	// +listType=map
+	// +listMapKey=name
+	TemplateVariables []DatadogDashboardTemplateVariable `json:"templateVariables,omitempty"`
+
+	// Tags is the list of tags of the dashboard, for example `team:foo`. It overrides the tags of the definition.
+	// Dashboards only support the tags with the `team:` prefix.
+	// +listType=set
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DatadogDashboardConfigMapKeySelector selects a key of a ConfigMap
+// +k8s:openapi-gen=true
+type DatadogDashboardConfigMapKeySelector struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// Key is the key of the ConfigMap holding the dashboard definition.
+	Key string `json:"key"`
+}
+
+// DatadogDashboardTemplateVariable defines a template variable of a dashboard
+// +k8s:openapi-gen=true
+type DatadogDashboardTemplateVariable struct {
+	// Name is the name of the variable.
+	Name string `json:"name"`
+	// Prefix is the tag prefix associated with the variable. Only tags with this prefix appear in the variable drop-down.
+	Prefix string `json:"prefix,omitempty"`
+	// Defaults are the default values of the variable on dashboard load.
+	// +listType=atomic
+	Defaults []string `json:"defaults,omitempty"`
+	// AvailableValues is the list of values the variable drop-down is limited to.
+	// +listType=atomic
+	AvailableValues []string `json:"availableValues,omitempty"`
+}
+
+// DatadogDashboardStatus defines the observed state of DatadogDashboard
+// +k8s:openapi-gen=true
+type DatadogDashboardStatus struct {
+	// Conditions represents the latest available observations of the state of a DatadogDashboard.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ID is the dashboard ID generated in Datadog
+	ID string `json:"id,omitempty"`
+
+	// URL is the URL of the dashboard in Datadog
+	URL string `json:"url,omitempty"`
+
+	// Creator is the identity of the dashboard creator
+	Creator string `json:"creator,omitempty"`
+
+	// Created is the time the dashboard was created
+	Created *metav1.Time `json:"created,omitempty"`
+
+	// SyncStatus shows the health of syncing the dashboard to Datadog
+	SyncStatus DatadogDashboardSyncStatus `json:"syncStatus,omitempty"`
+
+	// LastForceSyncTime is the last time the API dashboard was last force synced with the DatadogDashboard resource
+	LastForceSyncTime *metav1.Time `json:"lastForceSyncTime,omitempty"`
+
+	// CurrentHash tracks the hash of the current DatadogDashboardSpec and of its definition to know
+	// if the Spec has changed and needs an update
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// DatadogDashboardSyncStatus is the message reflecting the health of dashboard syncs to Datadog
+type DatadogDashboardSyncStatus string
+
+const (
+	// DatadogDashboardSyncStatusOK means syncing is OK
+	DatadogDashboardSyncStatusOK DatadogDashboardSyncStatus = "OK"
+	// DatadogDashboardSyncStatusValidateError means there is a dashboard validation error
+	DatadogDashboardSyncStatusValidateError DatadogDashboardSyncStatus = "error validating dashboard"
+	// DatadogDashboardSyncStatusCreateError means there is a dashboard creation error
+	DatadogDashboardSyncStatusCreateError DatadogDashboardSyncStatus = "error creating dashboard"
+	// DatadogDashboardSyncStatusUpdateError means there is a dashboard update error
+	DatadogDashboardSyncStatusUpdateError DatadogDashboardSyncStatus = "error updating dashboard"
+)
+
+// DatadogDashboard allows to define and manage Datadog dashboards from your Kubernetes Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogdashboards,scope=Namespaced,shortName=dddashboard
+// +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogDashboardSpec   `json:"spec,omitempty"`
+	Status DatadogDashboardStatus `json:"status,omitempty"`
+}
+
+// DatadogDashboardList contains a list of DatadogDashboards
+// +kubebuilder:object:root=true
+type DatadogDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogDashboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogDashboard{}, &DatadogDashboardList{})
+}
@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// dashboardTagPrefix is the prefix of the tags supported by the dashboards
+const dashboardTagPrefix = "team:"
+
+// IsValidDatadogDashboard use to check if a DatadogDashboardSpec is valid by checking
+// that the required fields are defined
+func IsValidDatadogDashboard(spec *DatadogDashboardSpec) error {
+	var errs []error
+	if spec.Definition == "" && spec.DefinitionConfigMap == nil {
+		errs = append(errs, fmt.Errorf("one of spec.Definition and spec.DefinitionConfigMap must be defined"))
+	}
+	if spec.Definition != "" && spec.DefinitionConfigMap != nil {
+		errs = append(errs, fmt.Errorf("only one of spec.Definition and spec.DefinitionConfigMap can be defined"))
+	}
+
+	if spec.Definition != "" {
+		if err := IsValidDatadogDashboardDefinition(spec.Definition); err != nil {
+			errs = append(errs, fmt.Errorf("spec.Definition is invalid: %w", err))
+		}
+	}
+
+	if spec.DefinitionConfigMap != nil && (spec.DefinitionConfigMap.Name == "" || spec.DefinitionConfigMap.Key == "") {
+		errs = append(errs, fmt.Errorf("spec.DefinitionConfigMap.Name and spec.DefinitionConfigMap.Key must be defined"))
+	}
+
+	names := map[string]bool{}
+	for _, variable := range spec.TemplateVariables {
+		if variable.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.TemplateVariables.Name must be defined"))
+		} else if names[variable.Name] {
+			errs = append(errs, fmt.Errorf("spec.TemplateVariables.Name %s is duplicated", variable.Name))
+		}
+		names[variable.Name] = true
+	}
+
+	for _, tag := range spec.Tags {
+		if !strings.HasPrefix(tag, dashboardTagPrefix) {
+			errs = append(errs, fmt.Errorf("spec.Tags %s is invalid, dashboards only support the tags with the %s prefix", tag, dashboardTagPrefix))
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
+
+// IsValidDatadogDashboardDefinition checks that a dashboard definition is a JSON object
+func IsValidDatadogDashboardDefinition(definition string) error {
+	obj := map[string]interface{}{}
+	return json.Unmarshal([]byte(definition), &obj)
+}
@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestIsValidDatadogDashboard(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     *DatadogDashboardSpec
+		expected error
+	}{
+		{
+			name: "Valid inline definition",
+			spec: &DatadogDashboardSpec{
+				Definition: `{"title": "foo", "layout_type": "ordered", "widgets": []}`,
+				TemplateVariables: []DatadogDashboardTemplateVariable{
+					{Name: "env", Prefix: "env", Defaults: []string{"prod"}},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "Valid ConfigMap definition",
+			spec: &DatadogDashboardSpec{
+				DefinitionConfigMap: &DatadogDashboardConfigMapKeySelector{Name: "dashboards", Key: "foo.json"},
+			},
+			expected: nil,
+		},
+		{
+			name:     "Missing definition",
+			spec:     &DatadogDashboardSpec{},
+			expected: errors.New("one of spec.Definition and spec.DefinitionConfigMap must be defined"),
+		},
+		{
+			name: "Both definitions",
+			spec: &DatadogDashboardSpec{
+				Definition:          `{}`,
+				DefinitionConfigMap: &DatadogDashboardConfigMapKeySelector{Name: "dashboards", Key: "foo.json"},
+			},
+			expected: errors.New("only one of spec.Definition and spec.DefinitionConfigMap can be defined"),
+		},
+		{
+			name: "Invalid definition and template variables",
+			spec: &DatadogDashboardSpec{
+				Definition: `[]`,
+				TemplateVariables: []DatadogDashboardTemplateVariable{
+					{Name: "env"},
+					{Name: "env"},
+				},
+				Tags: []string{"team:foo", "env:prod"},
+			},
+			expected: utilserrors.NewAggregate([]error{
+				errors.New("spec.Definition is invalid: json: cannot unmarshal array into Go value of type map[string]interface {}"),
+				errors.New("spec.TemplateVariables.Name env is duplicated"),
+				errors.New("spec.Tags env:prod is invalid, dashboards only support the tags with the team: prefix"),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsValidDatadogDashboard(test.spec)
+			if test.expected == nil {
+				assert.NoError(t, result)
+			} else {
+				assert.EqualError(t, result, test.expected.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogDowntimeSpec defines the desired state of DatadogDowntime
+// +k8s:openapi-gen=true
+type DatadogDowntimeSpec struct {
+	// Scope is the list of scopes to which the downtime applies, for example `env:prod`.
+	// The resulting downtime applies to sources that match ALL provided scopes.
+	// +listType=set
+	Scope []string `json:"scope"`
+
+	// MonitorSelector selects the monitors silenced by the downtime. All the monitors are silenced when it is not set.
+	MonitorSelector *DatadogDowntimeMonitorSelector `json:"monitorSelector,omitempty"`
+
+	// Start is the time the downtime starts. The downtime starts immediately when it is not set.
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End is the time the downtime ends. The downtime never ends when it is not set.
+	End *metav1.Time `json:"end,omitempty"`
+
+	// Timezone is the timezone in which the downtime is displayed and its recurrence is computed, for example `Europe/Paris`.
+	// Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+
+	// Recurrence repeats the downtime.
+	Recurrence *DatadogDowntimeRecurrence `json:"recurrence,omitempty"`
+
+	// Message is a message to include with the notifications of the silenced monitors.
+	Message string `json:"message,omitempty"`
+
+	// MuteFirstRecoveryNotification mutes the first recovery notification of the silenced monitors during the downtime.
+	MuteFirstRecoveryNotification *bool `json:"muteFirstRecoveryNotification,omitempty"`
+}
+
+// DatadogDowntimeMonitorSelector selects the monitors silenced by a downtime.
+// Only one of DatadogMonitorName, ID and Tags can be set.
+// +k8s:openapi-gen=true
+type DatadogDowntimeMonitorSelector struct {
+	// DatadogMonitorName is the name of a DatadogMonitor in the namespace of the DatadogDowntime.
+	DatadogMonitorName string `json:"datadogMonitorName,omitempty"`
+	// ID is the ID of a Datadog monitor.
+	ID *int64 `json:"id,omitempty"`
+	// Tags selects the monitors having all these tags.
+	// +listType=set
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DatadogDowntimeRecurrenceType is the unit of a downtime recurrence
+type DatadogDowntimeRecurrenceType string
+
+const (
+	// DatadogDowntimeRecurrenceTypeDays repeats the downtime every Period days
+	DatadogDowntimeRecurrenceTypeDays DatadogDowntimeRecurrenceType = "days"
+	// DatadogDowntimeRecurrenceTypeWeeks repeats the downtime every Period weeks
+	DatadogDowntimeRecurrenceTypeWeeks DatadogDowntimeRecurrenceType = "weeks"
+	// DatadogDowntimeRecurrenceTypeMonths repeats the downtime every Period months
+	DatadogDowntimeRecurrenceTypeMonths DatadogDowntimeRecurrenceType = "months"
+	// DatadogDowntimeRecurrenceTypeYears repeats the downtime every Period years
+	DatadogDowntimeRecurrenceTypeYears DatadogDowntimeRecurrenceType = "years"
+	// DatadogDowntimeRecurrenceTypeRRule repeats the downtime following RRule
+	DatadogDowntimeRecurrenceTypeRRule DatadogDowntimeRecurrenceType = "rrule"
+)
+
+// IsValid returns true if the recurrence type is supported
+func (t DatadogDowntimeRecurrenceType) IsValid() bool {
+	switch t {
+	case DatadogDowntimeRecurrenceTypeDays, DatadogDowntimeRecurrenceTypeWeeks, DatadogDowntimeRecurrenceTypeMonths, DatadogDowntimeRecurrenceTypeYears, DatadogDowntimeRecurrenceTypeRRule:
+		return true
+	default:
+		return false
+	}
+}
+
+// DatadogDowntimeRecurrence defines how a downtime repeats
+// +k8s:openapi-gen=true
+type DatadogDowntimeRecurrence struct {
+	// Type is the unit of the recurrence: days, weeks, months, years or rrule.
+	Type DatadogDowntimeRecurrenceType `json:"type"`
+	// Period is how often the downtime repeats, in Type units. It is not used with the rrule type.
+	Period int32 `json:"period,omitempty"`
+	// WeekDays are the days of the week the downtime repeats on, for example `Mon`. Only used with the weeks type.
+	// +listType=set
+	WeekDays []string `json:"weekDays,omitempty"`
+	// RRule is the recurrence rule, as defined in RFC 5545, used with the rrule type. For example `FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE`.
+	RRule string `json:"rrule,omitempty"`
+	// UntilDate is the time the recurrence ends.
+	UntilDate *metav1.Time `json:"untilDate,omitempty"`
+	// UntilOccurrences is the number of times the downtime is repeated.
+	UntilOccurrences *int32 `json:"untilOccurrences,omitempty"`
+}
+
+// DatadogDowntimeStatus defines the observed state of DatadogDowntime
+// +k8s:openapi-gen=true
+type DatadogDowntimeStatus struct {
+	// Conditions represents the latest available observations of the state of a DatadogDowntime.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ID is the downtime ID generated in Datadog
+	ID int `json:"id,omitempty"`
+
+	// Active is true when the downtime is currently silencing monitors
+	Active bool `json:"active,omitempty"`
+
+	// SyncStatus shows the health of syncing the downtime to Datadog
+	SyncStatus DatadogDowntimeSyncStatus `json:"syncStatus,omitempty"`
+
+	// LastForceSyncTime is the last time the API downtime was last force synced with the DatadogDowntime resource
+	LastForceSyncTime *metav1.Time `json:"lastForceSyncTime,omitempty"`
+
+	// StateLastUpdateTime is the last time the downtime state was synced from Datadog
+	StateLastUpdateTime *metav1.Time `json:"stateLastUpdateTime,omitempty"`
+
+	// CurrentHash tracks the hash of the current DatadogDowntimeSpec to know
+	// if the Spec has changed and needs an update
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// DatadogDowntimeSyncStatus is the message reflecting the health of downtime syncs to Datadog
+type DatadogDowntimeSyncStatus string
+
+const (
+	// DatadogDowntimeSyncStatusOK means syncing is OK
+	DatadogDowntimeSyncStatusOK DatadogDowntimeSyncStatus = "OK"
+	// DatadogDowntimeSyncStatusValidateError means there is a downtime validation error
+	DatadogDowntimeSyncStatusValidateError DatadogDowntimeSyncStatus = "error validating downtime"
+	// DatadogDowntimeSyncStatusCreateError means there is a downtime creation error
+	DatadogDowntimeSyncStatusCreateError DatadogDowntimeSyncStatus = "error creating downtime"
+	// DatadogDowntimeSyncStatusUpdateError means there is a downtime update error
+	DatadogDowntimeSyncStatusUpdateError DatadogDowntimeSyncStatus = "error updating downtime"
+	// DatadogDowntimeSyncStatusGetError means there is an error getting the downtime
+	DatadogDowntimeSyncStatusGetError DatadogDowntimeSyncStatus = "error getting downtime"
+)
+
+// DatadogDowntime allows to define and manage Datadog downtimes from your Kubernetes Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogdowntimes,scope=Namespaced,shortName=dddowntime
+// +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="active",type="boolean",JSONPath=".status.active"
+// +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogDowntime struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogDowntimeSpec   `json:"spec,omitempty"`
+	Status DatadogDowntimeStatus `json:"status,omitempty"`
+}
+
+// DatadogDowntimeList contains a list of DatadogDowntimes
+// +kubebuilder:object:root=true
+type DatadogDowntimeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogDowntime `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogDowntime{}, &DatadogDowntimeList{})
+}
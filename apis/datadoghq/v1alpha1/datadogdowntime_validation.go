@@ -0,0 +1,61 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// IsValidDatadogDowntime use to check if a DatadogDowntimeSpec is valid by checking
+// that the required fields are defined
+func IsValidDatadogDowntime(spec *DatadogDowntimeSpec) error {
+	var errs []error
+	if len(spec.Scope) == 0 {
+		errs = append(errs, fmt.Errorf("spec.Scope must be defined"))
+	}
+
+	if selector := spec.MonitorSelector; selector != nil {
+		selectors := 0
+		if selector.DatadogMonitorName != "" {
+			selectors++
+		}
+		if selector.ID != nil {
+			selectors++
+		}
+		if len(selector.Tags) > 0 {
+			selectors++
+		}
+		if selectors != 1 {
+			errs = append(errs, fmt.Errorf("spec.MonitorSelector must define one of datadogMonitorName, id or tags"))
+		}
+	}
+
+	if spec.Start != nil && spec.End != nil && !spec.Start.Before(spec.End) {
+		errs = append(errs, fmt.Errorf("spec.End must be after spec.Start"))
+	}
+
+	if recurrence := spec.Recurrence; recurrence != nil {
+		if !recurrence.Type.IsValid() {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.Type must be one of the values: days, weeks, months, years or rrule"))
+		}
+		if recurrence.Type == DatadogDowntimeRecurrenceTypeRRule && recurrence.RRule == "" {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.RRule must be defined when spec.Recurrence.Type is rrule"))
+		}
+		if recurrence.Type != DatadogDowntimeRecurrenceTypeRRule && recurrence.Period <= 0 {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.Period must be greater than 0"))
+		}
+		if recurrence.UntilDate != nil && recurrence.UntilOccurrences != nil {
+			errs = append(errs, fmt.Errorf("only one of spec.Recurrence.UntilDate and spec.Recurrence.UntilOccurrences can be defined"))
+		}
+		if spec.Start == nil || spec.End == nil {
+			errs = append(errs, fmt.Errorf("spec.Start and spec.End must be defined when spec.Recurrence is defined"))
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
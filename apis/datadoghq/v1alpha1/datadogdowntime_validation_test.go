@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestIsValidDatadogDowntime(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 1, 1, 22, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(2 * time.Hour))
+	monitorID := int64(12345)
+
+	tests := []struct {
+		name     string
+		spec     *DatadogDowntimeSpec
+		expected error
+	}{
+		{
+			name: "Valid spec",
+			spec: &DatadogDowntimeSpec{
+				Scope:           []string{"env:prod"},
+				MonitorSelector: &DatadogDowntimeMonitorSelector{DatadogMonitorName: "foo"},
+				Start:           &start,
+				End:             &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type:     DatadogDowntimeRecurrenceTypeWeeks,
+					Period:   1,
+					WeekDays: []string{"Sat", "Sun"},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:     "Missing scope",
+			spec:     &DatadogDowntimeSpec{},
+			expected: errors.New("spec.Scope must be defined"),
+		},
+		{
+			name: "Several monitor selectors",
+			spec: &DatadogDowntimeSpec{
+				Scope:           []string{"*"},
+				MonitorSelector: &DatadogDowntimeMonitorSelector{ID: &monitorID, Tags: []string{"team:foo"}},
+			},
+			expected: errors.New("spec.MonitorSelector must define one of datadogMonitorName, id or tags"),
+		},
+		{
+			name: "End before start",
+			spec: &DatadogDowntimeSpec{
+				Scope: []string{"*"},
+				Start: &end,
+				End:   &start,
+			},
+			expected: errors.New("spec.End must be after spec.Start"),
+		},
+		{
+			name: "Invalid recurrence",
+			spec: &DatadogDowntimeSpec{
+				Scope: []string{"*"},
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type: DatadogDowntimeRecurrenceTypeRRule,
+				},
+			},
+			expected: utilserrors.NewAggregate([]error{
+				errors.New("spec.Recurrence.RRule must be defined when spec.Recurrence.Type is rrule"),
+				errors.New("spec.Start and spec.End must be defined when spec.Recurrence is defined"),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsValidDatadogDowntime(test.spec)
+			if test.expected == nil {
+				assert.NoError(t, result)
+			} else {
+				assert.EqualError(t, result, test.expected.Error())
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ type DatadogMonitorSpec struct {
 	Message string `json:"message,omitempty"`
 	// Priority is an integer from 1 (high) to 5 (low) indicating alert severity
 	Priority int64 `json:"priority,omitempty"`
-	// Query is the Datadog monitor query
+	// Query is the Datadog monitor query.
+	// The query of a composite monitor can reference other DatadogMonitors of the same namespace with `${name}`
+	// instead of their monitor IDs, for example `${cpu} && ${disk}`.
 	Query string `json:"query,omitempty"`
 	// RestrictedRoles is a list of unique role identifiers to define which roles are allowed to edit the monitor.
 	// `restricted_roles` is the successor of `locked`. For more information about `locked` and `restricted_roles`,
@@ -151,6 +153,49 @@ type DatadogMonitorOptionsThresholdWindows struct {
 type DatadogMonitorControllerOptions struct {
 	// DisableRequiredTags disables the automatic addition of required tags to monitors.
 	DisableRequiredTags *bool `json:"disableRequiredTags,omitempty"`
+	// DriftPolicy defines what the controller does when the monitor is edited outside Kubernetes, for example in the Datadog UI:
+	// overwrite the edits, report them in the Drifted condition, or ignore them. Defaults to overwrite.
+	// +kubebuilder:validation:Enum=overwrite;report;ignore
+	DriftPolicy DatadogDriftPolicy `json:"driftPolicy,omitempty"`
+	// CredentialsSecretRef references a Secret, in the namespace of the DatadogMonitor, holding the Datadog credentials
+	// used to manage the monitor. Defaults to the credentials of the operator. It can't be changed once the monitor is created.
+	CredentialsSecretRef *DatadogCredentialsSecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// DatadogCredentialsSecretReference references a Secret holding Datadog API and application keys, and optionally the Datadog site
+// +k8s:openapi-gen=true
+type DatadogCredentialsSecretReference struct {
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+	// APIKeyKey is the key of the API key in the Secret. Defaults to api_key.
+	APIKeyKey string `json:"apiKeyKey,omitempty"`
+	// AppKeyKey is the key of the application key in the Secret. Defaults to app_key.
+	AppKeyKey string `json:"appKeyKey,omitempty"`
+	// SiteKey is the key of the Datadog site, for example datadoghq.eu, in the Secret. Defaults to site.
+	// The site of the operator is used when the Secret doesn't contain it.
+	SiteKey string `json:"siteKey,omitempty"`
+}
+
+// DatadogDriftPolicy defines how the controllers handle the Datadog objects edited outside Kubernetes
+type DatadogDriftPolicy string
+
+const (
+	// DatadogDriftPolicyOverwrite periodically overwrites the Datadog object with the spec
+	DatadogDriftPolicyOverwrite DatadogDriftPolicy = "overwrite"
+	// DatadogDriftPolicyReport reports the fields of the Datadog object that differ from the spec, without updating it
+	DatadogDriftPolicyReport DatadogDriftPolicy = "report"
+	// DatadogDriftPolicyIgnore neither overwrites nor reports the edits of the Datadog object
+	DatadogDriftPolicyIgnore DatadogDriftPolicy = "ignore"
+)
+
+// IsValid returns true if the drift policy is supported, the empty policy defaults to overwrite
+func (p DatadogDriftPolicy) IsValid() bool {
+	switch p {
+	case "", DatadogDriftPolicyOverwrite, DatadogDriftPolicyReport, DatadogDriftPolicyIgnore:
+		return true
+	default:
+		return false
+	}
 }
 
 // DatadogMonitorStatus defines the observed state of DatadogMonitor
@@ -191,6 +236,10 @@ type DatadogMonitorStatus struct {
 	// CurrentHash tracks the hash of the current DatadogMonitorSpec to know
 	// if the Spec has changed and needs an update
 	CurrentHash string `json:"currentHash,omitempty"`
+
+	// CredentialsSecretRef is the credentials Secret used to create or adopt the monitor. It can't be changed afterwards,
+	// as the monitor would be left in the Datadog organization of the previous credentials.
+	CredentialsSecretRef *DatadogCredentialsSecretReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // DatadogMonitorCondition describes the current state of a DatadogMonitor
@@ -226,6 +275,8 @@ const (
 	DatadogMonitorConditionTypeUpdated DatadogMonitorConditionType = "Updated"
 	// DatadogMonitorConditionTypeError means the DatadogMonitor has an error
 	DatadogMonitorConditionTypeError DatadogMonitorConditionType = "Error"
+	// DatadogMonitorConditionTypeDrifted means the monitor was edited outside Kubernetes and differs from the DatadogMonitor
+	DatadogMonitorConditionTypeDrifted DatadogMonitorConditionType = "Drifted"
 )
 
 // DatadogMonitorState represents the overall DatadogMonitor state
@@ -279,6 +330,14 @@ type DatadogMonitorDowntimeStatus struct {
 	DowntimeID  int  `json:"downtimeId,omitempty"`
 }
 
+// DatadogMonitorIDAnnotationKey is the annotation containing the ID of an existing Datadog monitor to adopt.
+// The monitor is managed by the DatadogMonitor, and updated to match its spec, instead of creating a new monitor.
+const DatadogMonitorIDAnnotationKey = "monitor.datadoghq.com/id"
+
+// DatadogMonitorPrimaryAnnotationKey is the annotation making an adopted monitor primary when set to "true":
+// the monitor is deleted in Datadog when the DatadogMonitor is deleted. Adopted monitors are kept in Datadog by default.
+const DatadogMonitorPrimaryAnnotationKey = "monitor.datadoghq.com/primary"
+
 // DatadogMonitor allows to define and manage Monitors from your Kubernetes Cluster
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
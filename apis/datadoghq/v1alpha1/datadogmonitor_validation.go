@@ -7,10 +7,17 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/types"
 	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// datadogMonitorReferenceRegexp matches the references to other DatadogMonitors in the query of a composite monitor
+var datadogMonitorReferenceRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
 // IsValidDatadogMonitor use to check if a DatadogMonitorSpec is valid by checking
 // that the required fields are defined
 func IsValidDatadogMonitor(spec *DatadogMonitorSpec) error {
@@ -31,5 +38,73 @@ func IsValidDatadogMonitor(spec *DatadogMonitorSpec) error {
 		errs = append(errs, fmt.Errorf("spec.Message must be defined"))
 	}
 
+	if spec.Type == DatadogMonitorTypeComposite {
+		if _, err := GetDatadogMonitorReferences(spec, ""); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !spec.ControllerOptions.DriftPolicy.IsValid() {
+		errs = append(errs, fmt.Errorf("spec.ControllerOptions.DriftPolicy must be one of the values: %s, %s or %s", DatadogDriftPolicyOverwrite, DatadogDriftPolicyReport, DatadogDriftPolicyIgnore))
+	}
+
+	if ref := spec.ControllerOptions.CredentialsSecretRef; ref != nil && ref.Name == "" {
+		errs = append(errs, fmt.Errorf("spec.ControllerOptions.CredentialsSecretRef.Name must be defined"))
+	}
+
 	return utilserrors.NewAggregate(errs)
 }
+
+// GetDatadogMonitorReferences returns the DatadogMonitors referenced in the query of a composite monitor,
+// they are in the given namespace.
+func GetDatadogMonitorReferences(spec *DatadogMonitorSpec, namespace string) ([]types.NamespacedName, error) {
+	if spec.Type != DatadogMonitorTypeComposite {
+		return nil, nil
+	}
+
+	var refs []types.NamespacedName
+	for _, match := range datadogMonitorReferenceRegexp.FindAllStringSubmatch(spec.Query, -1) {
+		ref, err := parseDatadogMonitorReference(match[1], namespace)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// ReplaceDatadogMonitorReferences replaces the references to other DatadogMonitors in the query of a composite monitor
+// with the result of replace.
+func ReplaceDatadogMonitorReferences(spec *DatadogMonitorSpec, namespace string, replace func(ref types.NamespacedName) (string, error)) (string, error) {
+	if spec.Type != DatadogMonitorTypeComposite {
+		return spec.Query, nil
+	}
+
+	var errs []error
+	query := datadogMonitorReferenceRegexp.ReplaceAllStringFunc(spec.Query, func(match string) string {
+		ref, err := parseDatadogMonitorReference(datadogMonitorReferenceRegexp.FindStringSubmatch(match)[1], namespace)
+		if err == nil {
+			var value string
+			if value, err = replace(ref); err == nil {
+				return value
+			}
+		}
+		errs = append(errs, err)
+		return match
+	})
+	return query, utilserrors.NewAggregate(errs)
+}
+
+// parseDatadogMonitorReference parses a reference to a DatadogMonitor. A composite monitor can only reference the
+// DatadogMonitors of its own namespace, so that it cannot read the monitor IDs of the other namespaces.
+func parseDatadogMonitorReference(ref, namespace string) (types.NamespacedName, error) {
+	name := types.NamespacedName{Namespace: namespace, Name: ref}
+	if strings.Contains(ref, "/") {
+		return name, fmt.Errorf("spec.Query: invalid DatadogMonitor reference ${%s}, it must be ${name} of a DatadogMonitor in the same namespace", ref)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(name.Name); len(errs) > 0 {
+		return name, fmt.Errorf("spec.Query: invalid DatadogMonitor name in ${%s}: %s", ref, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
@@ -6,9 +6,11 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestIsValidDatadogMonitor(t *testing.T) {
@@ -39,6 +41,28 @@ func TestIsValidDatadogMonitor(t *testing.T) {
 		Type:  "metric alert",
 		Name:  "Test Monitor",
 	}
+	composite := &DatadogMonitorSpec{
+		Query:   "${cpu} && (${disk} || 12345)",
+		Type:    "composite",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+	}
+	compositeInvalidReference := &DatadogMonitorSpec{
+		Query:   "${cpu} && ${monitoring/disk}",
+		Type:    "composite",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+	}
+	compositeInvalidName := &DatadogMonitorSpec{
+		Query:   "${CPU} && ${disk}",
+		Type:    "composite",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+	}
+	invalidDriftPolicy := minimumValid.DeepCopy()
+	invalidDriftPolicy.ControllerOptions.DriftPolicy = "foo"
+	missingCredentialsSecretName := minimumValid.DeepCopy()
+	missingCredentialsSecretName.ControllerOptions.CredentialsSecretRef = &DatadogCredentialsSecretReference{APIKeyKey: "key"}
 
 	testCases := []struct {
 		name    string
@@ -69,6 +93,30 @@ func TestIsValidDatadogMonitor(t *testing.T) {
 			spec:    missingMessage,
 			wantErr: "spec.Message must be defined",
 		},
+		{
+			name: "composite monitor with references",
+			spec: composite,
+		},
+		{
+			name:    "composite monitor referencing another namespace",
+			spec:    compositeInvalidReference,
+			wantErr: "spec.Query: invalid DatadogMonitor reference ${monitoring/disk}, it must be ${name} of a DatadogMonitor in the same namespace",
+		},
+		{
+			name:    "composite monitor with invalid names",
+			spec:    compositeInvalidName,
+			wantErr: "spec.Query: invalid DatadogMonitor name in ${CPU}: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name:    "monitor with an invalid drift policy",
+			spec:    invalidDriftPolicy,
+			wantErr: "spec.ControllerOptions.DriftPolicy must be one of the values: overwrite, report or ignore",
+		},
+		{
+			name:    "monitor with a credentials Secret without name",
+			spec:    missingCredentialsSecretName,
+			wantErr: "spec.ControllerOptions.CredentialsSecretRef.Name must be defined",
+		},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -82,3 +130,51 @@ func TestIsValidDatadogMonitor(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceDatadogMonitorReferences(t *testing.T) {
+	ids := map[types.NamespacedName]string{
+		{Namespace: "foo", Name: "cpu"}:  "1",
+		{Namespace: "foo", Name: "disk"}: "2",
+	}
+	replace := func(ref types.NamespacedName) (string, error) {
+		if id, found := ids[ref]; found {
+			return id, nil
+		}
+		return "", fmt.Errorf("DatadogMonitor %s not found", ref)
+	}
+
+	testCases := []struct {
+		name      string
+		spec      *DatadogMonitorSpec
+		wantQuery string
+		wantErr   string
+	}{
+		{
+			name:      "composite monitor",
+			spec:      &DatadogMonitorSpec{Type: DatadogMonitorTypeComposite, Query: "${cpu} && (${disk} || 3)"},
+			wantQuery: "1 && (2 || 3)",
+		},
+		{
+			name:      "composite monitor referencing a missing monitor",
+			spec:      &DatadogMonitorSpec{Type: DatadogMonitorTypeComposite, Query: "${cpu} && ${memory}"},
+			wantQuery: "1 && ${memory}",
+			wantErr:   "DatadogMonitor foo/memory not found",
+		},
+		{
+			name:      "not a composite monitor",
+			spec:      &DatadogMonitorSpec{Type: DatadogMonitorTypeMetric, Query: "avg(last_5m):avg:system.cpu.user{env:${cpu}} > 1"},
+			wantQuery: "avg(last_5m):avg:system.cpu.user{env:${cpu}} > 1",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := ReplaceDatadogMonitorReferences(test.spec, "foo", replace)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.wantQuery, query)
+		})
+	}
+}
@@ -25,10 +25,16 @@ type DatadogSLOSpec struct {
 	// +listType=set
 	Groups []string `json:"groups,omitempty"`
 
-	// MonitorIDs is a list of monitor IDs that defines the scope of a monitor service level objective. Required if type is monitor.
+	// MonitorIDs is a list of monitor IDs that defines the scope of a monitor service level objective.
+	// Required if type is monitor and MonitorRefs is not set.
 	// +listType=set
 	MonitorIDs []int64 `json:"monitorIDs,omitempty"`
 
+	// MonitorRefs is a list of DatadogMonitors that defines the scope of a monitor service level objective, in addition to MonitorIDs.
+	// The DatadogMonitors are resolved to their monitor IDs, and the SLO is updated when they are recreated with new IDs.
+	// +listType=atomic
+	MonitorRefs []DatadogSLOMonitorReference `json:"monitorRefs,omitempty"`
+
 	// Tags is a list of tags to associate with your service level objective.
 	// This can help you categorize and filter service level objectives in the service level objectives page of the UI.
 	// Note: it's not currently possible to filter by these tags when querying via the API.
@@ -63,6 +69,15 @@ type DatadogSLOQuery struct {
 	Denominator string `json:"denominator"`
 }
 
+// DatadogSLOMonitorReference references a DatadogMonitor.
+// +k8s:openapi-gen=true
+type DatadogSLOMonitorReference struct {
+	// Name is the name of the DatadogMonitor.
+	Name string `json:"name"`
+	// Namespace is the namespace of the DatadogMonitor. Defaults to the namespace of the DatadogSLO.
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type DatadogSLOType string
 
 const (
@@ -92,6 +107,13 @@ const (
 type DatadogSLOControllerOptions struct {
 	// DisableRequiredTags disables the automatic addition of required tags to SLOs.
 	DisableRequiredTags *bool `json:"disableRequiredTags,omitempty"`
+	// DriftPolicy defines what the controller does when the SLO is edited outside Kubernetes, for example in the Datadog UI:
+	// overwrite the edits, report them in the Drifted condition, or ignore them. Defaults to overwrite.
+	// +kubebuilder:validation:Enum=overwrite;report;ignore
+	DriftPolicy DatadogDriftPolicy `json:"driftPolicy,omitempty"`
+	// CredentialsSecretRef references a Secret, in the namespace of the DatadogSLO, holding the Datadog credentials
+	// used to manage the SLO. Defaults to the credentials of the operator. It can't be changed once the SLO is created.
+	CredentialsSecretRef *DatadogCredentialsSecretReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // DatadogSLOStatus defines the observed state of a DatadogSLO.
@@ -120,8 +142,53 @@ type DatadogSLOStatus struct {
 	// CurrentHash tracks the hash of the current DatadogSLOSpec to know
 	// if the Spec has changed and needs an update.
 	CurrentHash string `json:"currentHash,omitempty"`
+
+	// ErrorBudgetState is the state of the error budget over the SLO timeframe.
+	ErrorBudgetState DatadogSLOErrorBudgetState `json:"errorBudgetState,omitempty"`
+
+	// History reports the SLI value, the remaining error budget and the burn rate of the SLO
+	// over each timeframe up to the SLO timeframe.
+	// +listType=map
+	// +listMapKey=timeframe
+	History []DatadogSLOHistory `json:"history,omitempty"`
+
+	// HistoryLastUpdateTime is the last time the SLO history was fetched from Datadog.
+	HistoryLastUpdateTime *metav1.Time `json:"historyLastUpdateTime,omitempty"`
+
+	// CredentialsSecretRef is the credentials Secret used to create the SLO. It can't be changed afterwards,
+	// as the SLO would be left in the Datadog organization of the previous credentials.
+	CredentialsSecretRef *DatadogCredentialsSecretReference `json:"credentialsSecretRef,omitempty"`
 }
 
+// DatadogSLOHistory reports the state of a SLO over a timeframe.
+// +k8s:openapi-gen=true
+type DatadogSLOHistory struct {
+	// Timeframe is the timeframe, ending now, the values are computed over.
+	Timeframe DatadogSLOTimeFrame `json:"timeframe"`
+
+	// SLIValue is the service level indicator over the timeframe, in percent.
+	SLIValue string `json:"sliValue,omitempty"`
+
+	// ErrorBudgetRemaining is the remaining error budget over the timeframe, in percent of the error budget.
+	// It is negative when the error budget is exhausted.
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining,omitempty"`
+
+	// BurnRate is the rate the error budget is consumed at over the timeframe, 1 consumes exactly the error budget.
+	BurnRate string `json:"burnRate,omitempty"`
+}
+
+// DatadogSLOErrorBudgetState is the state of the error budget of a SLO.
+type DatadogSLOErrorBudgetState string
+
+const (
+	// DatadogSLOErrorBudgetStateOK means the SLI value is above the warning threshold.
+	DatadogSLOErrorBudgetStateOK DatadogSLOErrorBudgetState = "OK"
+	// DatadogSLOErrorBudgetStateWarning means the SLI value is below the warning threshold.
+	DatadogSLOErrorBudgetStateWarning DatadogSLOErrorBudgetState = "Warning"
+	// DatadogSLOErrorBudgetStateExhausted means the SLI value is below the target threshold.
+	DatadogSLOErrorBudgetStateExhausted DatadogSLOErrorBudgetState = "Exhausted"
+)
+
 // DatadogSLOSyncStatus is the message reflecting the health of SLO state syncs to Datadog.
 type DatadogSLOSyncStatus string
 
@@ -134,6 +201,10 @@ const (
 	DatadogSLOSyncStatusUpdateError DatadogSLOSyncStatus = "error updating SLO"
 	// DatadogSLOSyncStatusCreateError means there is an error getting the SLO.
 	DatadogSLOSyncStatusCreateError DatadogSLOSyncStatus = "error creating SLO"
+	// DatadogSLOSyncStatusMonitorRefsError means a referenced DatadogMonitor cannot be resolved to a monitor ID.
+	DatadogSLOSyncStatusMonitorRefsError DatadogSLOSyncStatus = "error resolving monitor references"
+	// DatadogSLOSyncStatusCredentialsError means the credentials Secret referenced by the DatadogSLO cannot be read.
+	DatadogSLOSyncStatusCredentialsError DatadogSLOSyncStatus = "error getting credentials"
 )
 
 // DatadogSLO allows a user to define and manage datadog SLOs from Kubernetes cluster.
@@ -142,6 +213,7 @@ const (
 // +kubebuilder:resource:path=datadogslos,scope=Namespaced,shortName=ddslo
 // +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="error budget",type="string",JSONPath=".status.errorBudgetState"
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
 // +genclient
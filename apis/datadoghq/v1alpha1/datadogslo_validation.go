@@ -31,8 +31,14 @@ func IsValidDatadogSLO(spec *DatadogSLOSpec) error {
 		errs = append(errs, fmt.Errorf("spec.Query must be defined when spec.Type is metric"))
 	}
 
-	if spec.Type == DatadogSLOTypeMonitor && len(spec.MonitorIDs) == 0 {
-		errs = append(errs, fmt.Errorf("spec.MonitorIDs must be defined when spec.Type is monitor"))
+	if spec.Type == DatadogSLOTypeMonitor && len(spec.MonitorIDs) == 0 && len(spec.MonitorRefs) == 0 {
+		errs = append(errs, fmt.Errorf("spec.MonitorIDs or spec.MonitorRefs must be defined when spec.Type is monitor"))
+	}
+
+	for i, ref := range spec.MonitorRefs {
+		if ref.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.MonitorRefs[%d].Name must be defined", i))
+		}
 	}
 
 	if spec.TargetThreshold.AsApproximateFloat64() <= 0 || spec.TargetThreshold.AsApproximateFloat64() >= 100 {
@@ -43,6 +49,14 @@ func IsValidDatadogSLO(spec *DatadogSLOSpec) error {
 		errs = append(errs, fmt.Errorf("spec.WarningThreshold must be greater than 0 and less than 100"))
 	}
 
+	if spec.ControllerOptions != nil && !spec.ControllerOptions.DriftPolicy.IsValid() {
+		errs = append(errs, fmt.Errorf("spec.ControllerOptions.DriftPolicy must be one of the values: %s, %s or %s", DatadogDriftPolicyOverwrite, DatadogDriftPolicyReport, DatadogDriftPolicyIgnore))
+	}
+
+	if spec.ControllerOptions != nil && spec.ControllerOptions.CredentialsSecretRef != nil && spec.ControllerOptions.CredentialsSecretRef.Name == "" {
+		errs = append(errs, fmt.Errorf("spec.ControllerOptions.CredentialsSecretRef.Name must be defined"))
+	}
+
 	switch spec.Timeframe {
 	case DatadogSLOTimeFrame7d, DatadogSLOTimeFrame30d, DatadogSLOTimeFrame90d:
 		break
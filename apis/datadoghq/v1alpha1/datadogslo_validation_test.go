@@ -112,7 +112,29 @@ func TestIsValidDatadogSLO(t *testing.T) {
 				Timeframe:       DatadogSLOTimeFrame30d,
 				MonitorIDs:      []int64{},
 			},
-			expected: errors.New("spec.MonitorIDs must be defined when spec.Type is monitor"),
+			expected: errors.New("spec.MonitorIDs or spec.MonitorRefs must be defined when spec.Type is monitor"),
+		},
+		{
+			name: "Valid MonitorRefs",
+			spec: &DatadogSLOSpec{
+				Name:            "MySLO",
+				Type:            DatadogSLOTypeMonitor,
+				TargetThreshold: resource.MustParse("99.99"),
+				Timeframe:       DatadogSLOTimeFrame30d,
+				MonitorRefs:     []DatadogSLOMonitorReference{{Name: "my-monitor"}, {Name: "other-monitor", Namespace: "monitoring"}},
+			},
+			expected: nil,
+		},
+		{
+			name: "MonitorRefs without name",
+			spec: &DatadogSLOSpec{
+				Name:            "MySLO",
+				Type:            DatadogSLOTypeMonitor,
+				TargetThreshold: resource.MustParse("99.99"),
+				Timeframe:       DatadogSLOTimeFrame30d,
+				MonitorRefs:     []DatadogSLOMonitorReference{{Name: "my-monitor"}, {Namespace: "monitoring"}},
+			},
+			expected: errors.New("spec.MonitorRefs[1].Name must be defined"),
 		},
 		{
 			name: "Invalid Thresholds",
@@ -157,6 +179,36 @@ func TestIsValidDatadogSLO(t *testing.T) {
 			},
 			expected: errors.New("spec.Timeframe must be defined as one of the values: 7d, 30d, or 90d"),
 		},
+		{
+			name: "Invalid DriftPolicy",
+			spec: &DatadogSLOSpec{
+				Name: "MySLO",
+				Query: &DatadogSLOQuery{
+					Numerator:   "good",
+					Denominator: "total",
+				},
+				Type:              DatadogSLOTypeMetric,
+				TargetThreshold:   resource.MustParse("98.00"),
+				Timeframe:         DatadogSLOTimeFrame30d,
+				ControllerOptions: &DatadogSLOControllerOptions{DriftPolicy: "foo"},
+			},
+			expected: errors.New("spec.ControllerOptions.DriftPolicy must be one of the values: overwrite, report or ignore"),
+		},
+		{
+			name: "Missing CredentialsSecretRef name",
+			spec: &DatadogSLOSpec{
+				Name: "MySLO",
+				Query: &DatadogSLOQuery{
+					Numerator:   "good",
+					Denominator: "total",
+				},
+				Type:              DatadogSLOTypeMetric,
+				TargetThreshold:   resource.MustParse("98.00"),
+				Timeframe:         DatadogSLOTimeFrame30d,
+				ControllerOptions: &DatadogSLOControllerOptions{CredentialsSecretRef: &DatadogCredentialsSecretReference{APIKeyKey: "key"}},
+			},
+			expected: errors.New("spec.ControllerOptions.CredentialsSecretRef.Name must be defined"),
+		},
 	}
 
 	for _, tt := range tests {
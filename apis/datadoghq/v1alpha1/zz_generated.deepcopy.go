@@ -1004,6 +1004,368 @@ func (in *DatadogCredentials) DeepCopy() *DatadogCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogCredentialsSecretReference) DeepCopyInto(out *DatadogCredentialsSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogCredentialsSecretReference.
+func (in *DatadogCredentialsSecretReference) DeepCopy() *DatadogCredentialsSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogCredentialsSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboard) DeepCopyInto(out *DatadogDashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboard.
+func (in *DatadogDashboard) DeepCopy() *DatadogDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardConfigMapKeySelector) DeepCopyInto(out *DatadogDashboardConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardConfigMapKeySelector.
+func (in *DatadogDashboardConfigMapKeySelector) DeepCopy() *DatadogDashboardConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardList) DeepCopyInto(out *DatadogDashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogDashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardList.
+func (in *DatadogDashboardList) DeepCopy() *DatadogDashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardSpec) DeepCopyInto(out *DatadogDashboardSpec) {
+	*out = *in
+	if in.DefinitionConfigMap != nil {
+		in, out := &in.DefinitionConfigMap, &out.DefinitionConfigMap
+		*out = new(DatadogDashboardConfigMapKeySelector)
+		**out = **in
+	}
+	if in.TemplateVariables != nil {
+		in, out := &in.TemplateVariables, &out.TemplateVariables
+		*out = make([]DatadogDashboardTemplateVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardSpec.
+func (in *DatadogDashboardSpec) DeepCopy() *DatadogDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardStatus) DeepCopyInto(out *DatadogDashboardStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	if in.LastForceSyncTime != nil {
+		in, out := &in.LastForceSyncTime, &out.LastForceSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardStatus.
+func (in *DatadogDashboardStatus) DeepCopy() *DatadogDashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardTemplateVariable) DeepCopyInto(out *DatadogDashboardTemplateVariable) {
+	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailableValues != nil {
+		in, out := &in.AvailableValues, &out.AvailableValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardTemplateVariable.
+func (in *DatadogDashboardTemplateVariable) DeepCopy() *DatadogDashboardTemplateVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardTemplateVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntime) DeepCopyInto(out *DatadogDowntime) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntime.
+func (in *DatadogDowntime) DeepCopy() *DatadogDowntime {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDowntime) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeList) DeepCopyInto(out *DatadogDowntimeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogDowntime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeList.
+func (in *DatadogDowntimeList) DeepCopy() *DatadogDowntimeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDowntimeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeMonitorSelector) DeepCopyInto(out *DatadogDowntimeMonitorSelector) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeMonitorSelector.
+func (in *DatadogDowntimeMonitorSelector) DeepCopy() *DatadogDowntimeMonitorSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeMonitorSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeRecurrence) DeepCopyInto(out *DatadogDowntimeRecurrence) {
+	*out = *in
+	if in.WeekDays != nil {
+		in, out := &in.WeekDays, &out.WeekDays
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UntilDate != nil {
+		in, out := &in.UntilDate, &out.UntilDate
+		*out = (*in).DeepCopy()
+	}
+	if in.UntilOccurrences != nil {
+		in, out := &in.UntilOccurrences, &out.UntilOccurrences
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeRecurrence.
+func (in *DatadogDowntimeRecurrence) DeepCopy() *DatadogDowntimeRecurrence {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeRecurrence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeSpec) DeepCopyInto(out *DatadogDowntimeSpec) {
+	*out = *in
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitorSelector != nil {
+		in, out := &in.MonitorSelector, &out.MonitorSelector
+		*out = new(DatadogDowntimeMonitorSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Recurrence != nil {
+		in, out := &in.Recurrence, &out.Recurrence
+		*out = new(DatadogDowntimeRecurrence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MuteFirstRecoveryNotification != nil {
+		in, out := &in.MuteFirstRecoveryNotification, &out.MuteFirstRecoveryNotification
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeSpec.
+func (in *DatadogDowntimeSpec) DeepCopy() *DatadogDowntimeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeStatus) DeepCopyInto(out *DatadogDowntimeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastForceSyncTime != nil {
+		in, out := &in.LastForceSyncTime, &out.LastForceSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StateLastUpdateTime != nil {
+		in, out := &in.StateLastUpdateTime, &out.StateLastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeStatus.
+func (in *DatadogDowntimeStatus) DeepCopy() *DatadogDowntimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 	*out = *in
@@ -1211,6 +1573,11 @@ func (in *DatadogMonitorControllerOptions) DeepCopyInto(out *DatadogMonitorContr
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(DatadogCredentialsSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorControllerOptions.
@@ -1486,6 +1853,11 @@ func (in *DatadogMonitorStatus) DeepCopyInto(out *DatadogMonitorStatus) {
 		}
 	}
 	out.DowntimeStatus = in.DowntimeStatus
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(DatadogCredentialsSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorStatus.
@@ -1549,6 +1921,11 @@ func (in *DatadogSLOControllerOptions) DeepCopyInto(out *DatadogSLOControllerOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(DatadogCredentialsSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOControllerOptions.
@@ -1561,6 +1938,21 @@ func (in *DatadogSLOControllerOptions) DeepCopy() *DatadogSLOControllerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogSLOHistory) DeepCopyInto(out *DatadogSLOHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOHistory.
+func (in *DatadogSLOHistory) DeepCopy() *DatadogSLOHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogSLOHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogSLOList) DeepCopyInto(out *DatadogSLOList) {
 	*out = *in
@@ -1593,6 +1985,21 @@ func (in *DatadogSLOList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogSLOMonitorReference) DeepCopyInto(out *DatadogSLOMonitorReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOMonitorReference.
+func (in *DatadogSLOMonitorReference) DeepCopy() *DatadogSLOMonitorReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogSLOMonitorReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogSLOQuery) DeepCopyInto(out *DatadogSLOQuery) {
 	*out = *in
@@ -1626,6 +2033,11 @@ func (in *DatadogSLOSpec) DeepCopyInto(out *DatadogSLOSpec) {
 		*out = make([]int64, len(*in))
 		copy(*out, *in)
 	}
+	if in.MonitorRefs != nil {
+		in, out := &in.MonitorRefs, &out.MonitorRefs
+		*out = make([]DatadogSLOMonitorReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -1677,6 +2089,20 @@ func (in *DatadogSLOStatus) DeepCopyInto(out *DatadogSLOStatus) {
 		in, out := &in.LastForceSyncTime, &out.LastForceSyncTime
 		*out = (*in).DeepCopy()
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DatadogSLOHistory, len(*in))
+		copy(*out, *in)
+	}
+	if in.HistoryLastUpdateTime != nil {
+		in, out := &in.HistoryLastUpdateTime, &out.HistoryLastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(DatadogCredentialsSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOStatus.
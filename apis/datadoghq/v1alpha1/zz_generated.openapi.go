@@ -41,6 +41,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"./apis/datadoghq/v1alpha1.DatadogAgentSpecClusterChecksRunnerSpec": schema__apis_datadoghq_v1alpha1_DatadogAgentSpecClusterChecksRunnerSpec(ref),
 		"./apis/datadoghq/v1alpha1.DatadogAgentStatus":                      schema__apis_datadoghq_v1alpha1_DatadogAgentStatus(ref),
 		"./apis/datadoghq/v1alpha1.DatadogCredentials":                      schema__apis_datadoghq_v1alpha1_DatadogCredentials(ref),
+		"./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference":       schema__apis_datadoghq_v1alpha1_DatadogCredentialsSecretReference(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDashboard":                        schema__apis_datadoghq_v1alpha1_DatadogDashboard(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDashboardConfigMapKeySelector":    schema__apis_datadoghq_v1alpha1_DatadogDashboardConfigMapKeySelector(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDashboardSpec":                    schema__apis_datadoghq_v1alpha1_DatadogDashboardSpec(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDashboardStatus":                  schema__apis_datadoghq_v1alpha1_DatadogDashboardStatus(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDashboardTemplateVariable":        schema__apis_datadoghq_v1alpha1_DatadogDashboardTemplateVariable(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDowntime":                         schema__apis_datadoghq_v1alpha1_DatadogDowntime(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDowntimeMonitorSelector":          schema__apis_datadoghq_v1alpha1_DatadogDowntimeMonitorSelector(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDowntimeRecurrence":               schema__apis_datadoghq_v1alpha1_DatadogDowntimeRecurrence(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDowntimeSpec":                     schema__apis_datadoghq_v1alpha1_DatadogDowntimeSpec(ref),
+		"./apis/datadoghq/v1alpha1.DatadogDowntimeStatus":                   schema__apis_datadoghq_v1alpha1_DatadogDowntimeStatus(ref),
 		"./apis/datadoghq/v1alpha1.DatadogFeatures":                         schema__apis_datadoghq_v1alpha1_DatadogFeatures(ref),
 		"./apis/datadoghq/v1alpha1.DatadogMetric":                           schema__apis_datadoghq_v1alpha1_DatadogMetric(ref),
 		"./apis/datadoghq/v1alpha1.DatadogMetricCondition":                  schema__apis_datadoghq_v1alpha1_DatadogMetricCondition(ref),
@@ -56,6 +67,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"./apis/datadoghq/v1alpha1.DatadogMonitorTriggeredState":            schema__apis_datadoghq_v1alpha1_DatadogMonitorTriggeredState(ref),
 		"./apis/datadoghq/v1alpha1.DatadogSLO":                              schema__apis_datadoghq_v1alpha1_DatadogSLO(ref),
 		"./apis/datadoghq/v1alpha1.DatadogSLOControllerOptions":             schema__apis_datadoghq_v1alpha1_DatadogSLOControllerOptions(ref),
+		"./apis/datadoghq/v1alpha1.DatadogSLOHistory":                       schema__apis_datadoghq_v1alpha1_DatadogSLOHistory(ref),
+		"./apis/datadoghq/v1alpha1.DatadogSLOMonitorReference":              schema__apis_datadoghq_v1alpha1_DatadogSLOMonitorReference(ref),
 		"./apis/datadoghq/v1alpha1.DatadogSLOQuery":                         schema__apis_datadoghq_v1alpha1_DatadogSLOQuery(ref),
 		"./apis/datadoghq/v1alpha1.DatadogSLOSpec":                          schema__apis_datadoghq_v1alpha1_DatadogSLOSpec(ref),
 		"./apis/datadoghq/v1alpha1.DatadogSLOStatus":                        schema__apis_datadoghq_v1alpha1_DatadogSLOStatus(ref),
@@ -1785,6 +1798,686 @@ func schema__apis_datadoghq_v1alpha1_DatadogCredentials(ref common.ReferenceCall
 	}
 }
 
+func schema__apis_datadoghq_v1alpha1_DatadogCredentialsSecretReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogCredentialsSecretReference references a Secret holding Datadog API and application keys, and optionally the Datadog site",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the Secret.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiKeyKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIKeyKey is the key of the API key in the Secret. Defaults to api_key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"appKeyKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppKeyKey is the key of the application key in the Secret. Defaults to app_key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"siteKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SiteKey is the key of the Datadog site, for example datadoghq.eu, in the Secret. Defaults to site. The site of the operator is used when the Secret doesn't contain it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDashboard(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDashboard allows to define and manage Datadog dashboards from your Kubernetes Cluster",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("./apis/datadoghq/v1alpha1.DatadogDashboardSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("./apis/datadoghq/v1alpha1.DatadogDashboardStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogDashboardSpec", "./apis/datadoghq/v1alpha1.DatadogDashboardStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDashboardConfigMapKeySelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDashboardConfigMapKeySelector selects a key of a ConfigMap",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the ConfigMap.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the key of the ConfigMap holding the dashboard definition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+		},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDashboardSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDashboardSpec defines the desired state of DatadogDashboard",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"definition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Definition is the JSON definition of the dashboard, as exported from the Datadog UI or returned by the dashboards API. Only one of Definition and DefinitionConfigMap can be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"definitionConfigMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefinitionConfigMap references the ConfigMap key holding the JSON definition of the dashboard. The ConfigMap must be in the namespace of the DatadogDashboard.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogDashboardConfigMapKeySelector"),
+						},
+					},
+					"title": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Title is the title of the dashboard. It overrides the title of the definition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Description is the description of the dashboard. It overrides the description of the definition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"templateVariables": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TemplateVariables are the template variables of the dashboard. They override the template variables of the definition with the same name.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("./apis/datadoghq/v1alpha1.DatadogDashboardTemplateVariable"),
+									},
+								},
+							},
+						},
+					},
+					"tags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags is the list of tags of the dashboard, for example `team:foo`. It overrides the tags of the definition. Dashboards only support the tags with the `team:` prefix.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogDashboardConfigMapKeySelector", "./apis/datadoghq/v1alpha1.DatadogDashboardTemplateVariable"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDashboardStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDashboardStatus defines the observed state of DatadogDashboard",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions represents the latest available observations of the state of a DatadogDashboard.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the dashboard ID generated in Datadog",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL is the URL of the dashboard in Datadog",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"creator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Creator is the identity of the dashboard creator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"created": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Created is the time the dashboard was created",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"syncStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncStatus shows the health of syncing the dashboard to Datadog",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastForceSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastForceSyncTime is the last time the API dashboard was last force synced with the DatadogDashboard resource",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"currentHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentHash tracks the hash of the current DatadogDashboardSpec and of its definition to know if the Spec has changed and needs an update",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDashboardTemplateVariable(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDashboardTemplateVariable defines a template variable of a dashboard",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the variable.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prefix is the tag prefix associated with the variable. Only tags with this prefix appear in the variable drop-down.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"defaults": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Defaults are the default values of the variable on dashboard load.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"availableValues": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AvailableValues is the list of values the variable drop-down is limited to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDowntime(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDowntime allows to define and manage Datadog downtimes from your Kubernetes Cluster",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("./apis/datadoghq/v1alpha1.DatadogDowntimeSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("./apis/datadoghq/v1alpha1.DatadogDowntimeStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogDowntimeSpec", "./apis/datadoghq/v1alpha1.DatadogDowntimeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDowntimeMonitorSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDowntimeMonitorSelector selects the monitors silenced by a downtime. Only one of DatadogMonitorName, ID and Tags can be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"datadogMonitorName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DatadogMonitorName is the name of a DatadogMonitor in the namespace of the DatadogDowntime.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the ID of a Datadog monitor.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags selects the monitors having all these tags.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDowntimeRecurrence(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDowntimeRecurrence defines how a downtime repeats",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the unit of the recurrence: days, weeks, months, years or rrule.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"period": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Period is how often the downtime repeats, in Type units. It is not used with the rrule type.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"weekDays": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "WeekDays are the days of the week the downtime repeats on, for example `Mon`. Only used with the weeks type.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"rrule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RRule is the recurrence rule, as defined in RFC 5545, used with the rrule type. For example `FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"untilDate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UntilDate is the time the recurrence ends.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"untilOccurrences": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UntilOccurrences is the number of times the downtime is repeated.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"type"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDowntimeSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDowntimeSpec defines the desired state of DatadogDowntime",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scope": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Scope is the list of scopes to which the downtime applies, for example `env:prod`. The resulting downtime applies to sources that match ALL provided scopes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"monitorSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MonitorSelector selects the monitors silenced by the downtime. All the monitors are silenced when it is not set.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogDowntimeMonitorSelector"),
+						},
+					},
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the time the downtime starts. The downtime starts immediately when it is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the time the downtime ends. The downtime never ends when it is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"timezone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timezone is the timezone in which the downtime is displayed and its recurrence is computed, for example `Europe/Paris`. Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"recurrence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Recurrence repeats the downtime.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogDowntimeRecurrence"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a message to include with the notifications of the silenced monitors.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"muteFirstRecoveryNotification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MuteFirstRecoveryNotification mutes the first recovery notification of the silenced monitors during the downtime.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"scope"},
+			},
+		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogDowntimeMonitorSelector", "./apis/datadoghq/v1alpha1.DatadogDowntimeRecurrence", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogDowntimeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogDowntimeStatus defines the observed state of DatadogDowntime",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions represents the latest available observations of the state of a DatadogDowntime.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the downtime ID generated in Datadog",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"active": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Active is true when the downtime is currently silencing monitors",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"syncStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncStatus shows the health of syncing the downtime to Datadog",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastForceSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastForceSyncTime is the last time the API downtime was last force synced with the DatadogDowntime resource",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"stateLastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StateLastUpdateTime is the last time the downtime state was synced from Datadog",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"currentHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentHash tracks the hash of the current DatadogDowntimeSpec to know if the Spec has changed and needs an update",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema__apis_datadoghq_v1alpha1_DatadogFeatures(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2058,9 +2751,24 @@ func schema__apis_datadoghq_v1alpha1_DatadogMonitorControllerOptions(ref common.
 							Format:      "",
 						},
 					},
+					"driftPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftPolicy defines what the controller does when the monitor is edited outside Kubernetes, for example in the Datadog UI: overwrite the edits, report them in the Drifted condition, or ignore them. Defaults to overwrite.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef references a Secret, in the namespace of the DatadogMonitor, holding the Datadog credentials used to manage the monitor. Defaults to the credentials of the operator. It can't be changed once the monitor is created.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"},
 	}
 }
 
@@ -2319,7 +3027,7 @@ func schema__apis_datadoghq_v1alpha1_DatadogMonitorSpec(ref common.ReferenceCall
 					},
 					"query": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Query is the Datadog monitor query",
+							Description: "Query is the Datadog monitor query. The query of a composite monitor can reference other DatadogMonitors of the same namespace with `${name}` instead of their monitor IDs, for example `${cpu} && ${disk}`.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -2517,11 +3225,17 @@ func schema__apis_datadoghq_v1alpha1_DatadogMonitorStatus(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef is the credentials Secret used to create or adopt the monitor. It can't be changed afterwards, as the monitor would be left in the Datadog organization of the previous credentials.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"./apis/datadoghq/v1alpha1.DatadogMonitorCondition", "./apis/datadoghq/v1alpha1.DatadogMonitorDowntimeStatus", "./apis/datadoghq/v1alpha1.DatadogMonitorTriggeredState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference", "./apis/datadoghq/v1alpha1.DatadogMonitorCondition", "./apis/datadoghq/v1alpha1.DatadogMonitorDowntimeStatus", "./apis/datadoghq/v1alpha1.DatadogMonitorTriggeredState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2622,7 +3336,94 @@ func schema__apis_datadoghq_v1alpha1_DatadogSLOControllerOptions(ref common.Refe
 							Format:      "",
 						},
 					},
+					"driftPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftPolicy defines what the controller does when the SLO is edited outside Kubernetes, for example in the Datadog UI: overwrite the edits, report them in the Drifted condition, or ignore them. Defaults to overwrite.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef references a Secret, in the namespace of the DatadogSLO, holding the Datadog credentials used to manage the SLO. Defaults to the credentials of the operator. It can't be changed once the SLO is created.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogSLOHistory(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogSLOHistory reports the state of a SLO over a timeframe.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeframe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeframe is the timeframe, ending now, the values are computed over.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sliValue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SLIValue is the service level indicator over the timeframe, in percent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"errorBudgetRemaining": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorBudgetRemaining is the remaining error budget over the timeframe, in percent of the error budget. It is negative when the error budget is exhausted.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"burnRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BurnRate is the rate the error budget is consumed at over the timeframe, 1 consumes exactly the error budget.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"timeframe"},
+			},
+		},
+	}
+}
+
+func schema__apis_datadoghq_v1alpha1_DatadogSLOMonitorReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogSLOMonitorReference references a DatadogMonitor.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the DatadogMonitor.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the DatadogMonitor. Defaults to the namespace of the DatadogSLO.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name"},
 			},
 		},
 	}
@@ -2705,7 +3506,7 @@ func schema__apis_datadoghq_v1alpha1_DatadogSLOSpec(ref common.ReferenceCallback
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "MonitorIDs is a list of monitor IDs that defines the scope of a monitor service level objective. Required if type is monitor.",
+							Description: "MonitorIDs is a list of monitor IDs that defines the scope of a monitor service level objective. Required if type is monitor and MonitorRefs is not set.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -2718,6 +3519,25 @@ func schema__apis_datadoghq_v1alpha1_DatadogSLOSpec(ref common.ReferenceCallback
 							},
 						},
 					},
+					"monitorRefs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "MonitorRefs is a list of DatadogMonitors that defines the scope of a monitor service level objective, in addition to MonitorIDs. The DatadogMonitors are resolved to their monitor IDs, and the SLO is updated when they are recreated with new IDs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("./apis/datadoghq/v1alpha1.DatadogSLOMonitorReference"),
+									},
+								},
+							},
+						},
+					},
 					"tags": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -2784,7 +3604,7 @@ func schema__apis_datadoghq_v1alpha1_DatadogSLOSpec(ref common.ReferenceCallback
 			},
 		},
 		Dependencies: []string{
-			"./apis/datadoghq/v1alpha1.DatadogSLOControllerOptions", "./apis/datadoghq/v1alpha1.DatadogSLOQuery", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"./apis/datadoghq/v1alpha1.DatadogSLOControllerOptions", "./apis/datadoghq/v1alpha1.DatadogSLOMonitorReference", "./apis/datadoghq/v1alpha1.DatadogSLOQuery", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -2857,11 +3677,52 @@ func schema__apis_datadoghq_v1alpha1_DatadogSLOStatus(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"errorBudgetState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorBudgetState is the state of the error budget over the SLO timeframe.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"history": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"timeframe",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "History reports the SLI value, the remaining error budget and the burn rate of the SLO over each timeframe up to the SLO timeframe.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("./apis/datadoghq/v1alpha1.DatadogSLOHistory"),
+									},
+								},
+							},
+						},
+					},
+					"historyLastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HistoryLastUpdateTime is the last time the SLO history was fetched from Datadog.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef is the credentials Secret used to create the SLO. It can't be changed afterwards, as the SLO would be left in the Datadog organization of the previous credentials.",
+							Ref:         ref("./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"./apis/datadoghq/v1alpha1.DatadogCredentialsSecretReference", "./apis/datadoghq/v1alpha1.DatadogSLOHistory", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -71,6 +71,25 @@ func SetDatadogAgentStatusCondition(status *DatadogAgentStatus, condition *metav
 	}
 }
 
+// DeleteDatadogAgentStatusCondition is used to remove a condition
+func DeleteDatadogAgentStatusCondition(status *DatadogAgentStatus, conditionType string) {
+	idConditionComplete := getIndexForConditionType(status, conditionType)
+	if idConditionComplete >= 0 {
+		status.Conditions = append(status.Conditions[:idConditionComplete], status.Conditions[idConditionComplete+1:]...)
+	}
+}
+
+// GetFeatureReconcileConditionType returns the ReconcileConditionType of a feature
+func GetFeatureReconcileConditionType(featureID string) string {
+	return FeatureReconcileConditionTypePrefix + featureID
+}
+
+// GetImagePolicyUnpinnedConditionType returns the ConditionType reporting the images of a component that aren't pinned
+// by the image policy manifests
+func GetImagePolicyUnpinnedConditionType(componentName ComponentName) string {
+	return ImagePolicyUnpinnedConditionTypePrefix + string(componentName)
+}
+
 // NewDatadogAgentStatusCondition returns new metav1.Condition instance
 func NewDatadogAgentStatusCondition(conditionType string, conditionStatus metav1.ConditionStatus, now metav1.Time, reason, message string) metav1.Condition {
 	return metav1.Condition{
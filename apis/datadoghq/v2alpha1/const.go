@@ -12,6 +12,15 @@ const (
 	OverrideReconcileConflictConditionType = "OverrideReconcileConflict"
 	// DatadogAgentReconcileErrorConditionType ReconcileConditionType for DatadogAgent reconcile error
 	DatadogAgentReconcileErrorConditionType = "DatadogAgentReconcileError"
+	// DatadogAgentInvalidSpecConditionType ReconcileConditionType for a DatadogAgent spec that doesn't pass the validation
+	DatadogAgentInvalidSpecConditionType = "DatadogAgentInvalidSpec"
+	// DatadogAgentSpecWarningConditionType ReconcileConditionType for a DatadogAgent spec with settings that can harm the cluster
+	DatadogAgentSpecWarningConditionType = "DatadogAgentSpecWarning"
+	// FeatureReconcileConditionTypePrefix prefix of the ReconcileConditionType of a feature, suffixed by the feature ID
+	FeatureReconcileConditionTypePrefix = "FeatureReconcile-"
+	// ImagePolicyUnpinnedConditionTypePrefix prefix of the ConditionType reporting the images of a component that
+	// aren't pinned by the image policy manifests, suffixed by the component name
+	ImagePolicyUnpinnedConditionTypePrefix = "ImagePolicyUnpinned-"
 
 	// ExtraConfdConfigMapName is the name of the ConfigMap storing Custom Confd data
 	ExtraConfdConfigMapName = "%s-extra-confd"
@@ -6,6 +6,10 @@
 package v2alpha1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 )
@@ -95,6 +99,16 @@ const (
 	defaultContainerStrategy = OptimizedContainerStrategy
 )
 
+// DefaultsVersion is the revision of the default values set by DefaultDatadogAgent.
+// Defaults are only applied to unset fields, so once persisted in a DatadogAgent they don't change across
+// Operator upgrades. Any change of a default value must increment DefaultsVersion and be described in defaultsChangelog.
+const DefaultsVersion = 1
+
+// defaultsChangelog describes the changes introduced by each revision of the defaults.
+var defaultsChangelog = map[int]string{
+	1: "initial revision",
+}
+
 // DefaultDatadogAgent defaults the DatadogAgentSpec GlobalConfig and Features.
 func DefaultDatadogAgent(dda *DatadogAgent) {
 	defaultGlobalConfig(&dda.Spec)
@@ -102,6 +116,37 @@ func DefaultDatadogAgent(dda *DatadogAgent) {
 	defaultFeaturesConfig(&dda.Spec)
 }
 
+// DefaultDatadogAgentWithAnnotations defaults the DatadogAgentSpec like DefaultDatadogAgent, and records the revision
+// of the defaults in the DatadogAgent annotations. It is used when the defaults are persisted in the DatadogAgent
+// (defaulting webhook or persisted defaults mode), so that the effective configuration is visible on the object.
+// If the DatadogAgent was defaulted by a previous revision, the changes since this revision are described in an annotation.
+func DefaultDatadogAgentWithAnnotations(dda *DatadogAgent) {
+	DefaultDatadogAgent(dda)
+
+	annotations := dda.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if previous, err := strconv.Atoi(annotations[apicommon.DefaultsVersionAnnotationKey]); err == nil && previous < DefaultsVersion {
+		annotations[apicommon.DefaultsChangesAnnotationKey] = defaultsChangesSince(previous)
+	}
+	annotations[apicommon.DefaultsVersionAnnotationKey] = strconv.Itoa(DefaultsVersion)
+
+	dda.SetAnnotations(annotations)
+}
+
+// defaultsChangesSince returns the description of the defaults changes introduced after the `previous` revision.
+func defaultsChangesSince(previous int) string {
+	var changes []string
+	for version := previous + 1; version <= DefaultsVersion; version++ {
+		if desc, found := defaultsChangelog[version]; found {
+			changes = append(changes, fmt.Sprintf("v%d: %s", version, desc))
+		}
+	}
+	return strings.Join(changes, "; ")
+}
+
 // defaultGlobalConfig sets default values in DatadogAgentSpec.Global.
 func defaultGlobalConfig(ddaSpec *DatadogAgentSpec) {
 	if ddaSpec.Global == nil {
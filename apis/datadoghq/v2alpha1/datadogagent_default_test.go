@@ -1450,3 +1450,55 @@ func Test_defaultFeatures(t *testing.T) {
 		})
 	}
 }
+
+func Test_DefaultDatadogAgentWithAnnotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:        "never defaulted",
+			annotations: nil,
+			wantAnnotations: map[string]string{
+				apicommon.DefaultsVersionAnnotationKey: "1",
+			},
+		},
+		{
+			name: "already defaulted with the current revision",
+			annotations: map[string]string{
+				"foo":                                  "bar",
+				apicommon.DefaultsVersionAnnotationKey: "1",
+			},
+			wantAnnotations: map[string]string{
+				"foo":                                  "bar",
+				apicommon.DefaultsVersionAnnotationKey: "1",
+			},
+		},
+		{
+			name: "defaulted with a previous revision",
+			annotations: map[string]string{
+				apicommon.DefaultsVersionAnnotationKey: "0",
+			},
+			wantAnnotations: map[string]string{
+				apicommon.DefaultsVersionAnnotationKey: "1",
+				apicommon.DefaultsChangesAnnotationKey: "v1: initial revision",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := &DatadogAgent{}
+			dda.Annotations = tt.annotations
+			DefaultDatadogAgentWithAnnotations(dda)
+
+			assert.Equal(t, tt.wantAnnotations, dda.Annotations)
+
+			// Defaulting is idempotent.
+			defaulted := dda.DeepCopy()
+			DefaultDatadogAgentWithAnnotations(defaulted)
+			assert.Equal(t, dda.Spec, defaulted.Spec)
+		})
+	}
+}
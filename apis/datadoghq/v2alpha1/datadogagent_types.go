@@ -7,7 +7,11 @@ package v2alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	edsdatadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 
 	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 )
@@ -37,6 +41,37 @@ type DatadogAgentSpec struct {
 	// Override the default configurations of the agents
 	// +optional
 	Override map[ComponentName]*DatadogAgentComponentOverride `json:"override,omitempty"`
+
+	// Profiles define groups of nodes running a node Agent with a specific configuration.
+	// Each profile creates its own node Agent DaemonSet, on the nodes matching the profile and no previous profile.
+	// The node Agent DaemonSet that isn't part of any profile runs on the remaining nodes.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Profiles []NodeAgentProfile `json:"profiles,omitempty"`
+}
+
+// NodeAgentProfile defines a group of nodes running a node Agent with a specific configuration.
+// +k8s:openapi-gen=true
+type NodeAgentProfile struct {
+	// Name of the profile, used as a suffix of the profile node Agent DaemonSet name.
+	Name string `json:"name"`
+
+	// NodeSelectorRequirements select the nodes of the profile. The requirements are ANDed.
+	// Only the `In`, `NotIn`, `Exists` and `DoesNotExist` operators are supported.
+	// +listType=atomic
+	NodeSelectorRequirements []corev1.NodeSelectorRequirement `json:"nodeSelectorRequirements"`
+
+	// DisabledFeatures lists the IDs of the features that aren't configured on the node Agents of the profile, for example `npm` or `apm`.
+	// The `default` feature can't be disabled.
+	// +optional
+	// +listType=set
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+
+	// Override the node Agent configuration of the profile.
+	// It is applied on top of the `nodeAgent` override.
+	// +optional
+	Override *DatadogAgentComponentOverride `json:"override,omitempty"`
 }
 
 // DatadogFeatures are features running on the Agent and Cluster Agent.
@@ -118,6 +153,39 @@ type APMFeatureConfig struct {
 	// Path Default: `/var/run/datadog/apm.socket`
 	// +optional
 	UnixDomainSocketConfig *UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
+
+	// SingleStepInstrumentation injects the APM tracing libraries into the application pods through the Admission Controller.
+	// It requires the Admission Controller to be enabled.
+	// +optional
+	SingleStepInstrumentation *SingleStepInstrumentation `json:"instrumentation,omitempty"`
+}
+
+// SingleStepInstrumentation contains the configuration of the APM library injection.
+// The libraries are injected in all the namespaces when `enabled` is set, or in the `enabledNamespaces` only.
+type SingleStepInstrumentation struct {
+	// Enabled enables the injection of the APM tracing libraries into the pods of all the namespaces,
+	// except the ones listed in `disabledNamespaces`.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EnabledNamespaces enables the injection of the APM tracing libraries into the pods of these namespaces only.
+	// It can't be set when `enabled` is true.
+	// +optional
+	// +listType=set
+	EnabledNamespaces []string `json:"enabledNamespaces,omitempty"`
+
+	// DisabledNamespaces lists the namespaces where the libraries are not injected.
+	// It can only be set when `enabled` is true.
+	// +optional
+	// +listType=set
+	DisabledNamespaces []string `json:"disabledNamespaces,omitempty"`
+
+	// LibVersions sets the version of the tracing library injected for each language.
+	// The supported languages are 'java', 'js', 'python', 'dotnet' and 'ruby', for example `java: v1.31.0`.
+	// The latest version of the libraries of all the languages is injected when it's not set.
+	// +optional
+	LibVersions map[string]string `json:"libVersions,omitempty"`
 }
 
 // LogCollectionFeatureConfig contains Logs configuration.
@@ -530,6 +598,7 @@ type AdmissionControllerFeatureConfig struct {
 	Enabled *bool `json:"enabled,omitempty"`
 
 	// MutateUnlabelled enables config injection without the need of pod label 'admission.datadoghq.com/enabled="true"'.
+	// It's ignored when `objectSelector` is set.
 	// Default: false
 	// +optional
 	MutateUnlabelled *bool `json:"mutateUnlabelled,omitempty"`
@@ -544,13 +613,52 @@ type AdmissionControllerFeatureConfig struct {
 	AgentCommunicationMode *string `json:"agentCommunicationMode,omitempty"`
 
 	// FailurePolicy determines how unrecognized and timeout errors are handled.
+	// 'Fail' with `mutateUnlabelled` or an empty `objectSelector` is reported in the `DatadogAgentSpecWarning` status condition,
+	// a failing webhook would block the creation of all the pods.
+	// Default: "Ignore"
 	// +optional
 	FailurePolicy *string `json:"failurePolicy,omitempty"`
 
+	// TimeoutSeconds is the time the API server waits for the webhook to respond, between 1 and 30 seconds.
+	// The failure policy is applied when it's reached.
+	// Default: 10
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the pods mutated by the webhook.
+	// kube-system and the namespace of the Operator are always excluded.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector selects the pods mutated by the webhook. It replaces the selection with the
+	// `admission.datadoghq.com/enabled` pod label and `mutateUnlabelled`.
+	// The pods of the Datadog components are always excluded.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
 	// WebhookName is a custom name for the MutatingWebhookConfiguration.
 	// Default: "datadog-webhook"
 	// +optional
 	WebhookName *string `json:"webhookName,omitempty"`
+
+	// InjectTags enables the injection of the `DD_ENV`, `DD_SERVICE` and `DD_VERSION` environment variables
+	// from the `tags.datadoghq.com/env`, `tags.datadoghq.com/service` and `tags.datadoghq.com/version` labels
+	// of the pods and of the workloads owning them.
+	// Default: true
+	// +optional
+	InjectTags *bool `json:"injectTags,omitempty"`
+
+	// InjectConfig enables the injection of the environment variables used by the APM libraries and DogStatsD clients
+	// to reach the Agent, configured with `agentCommunicationMode`.
+	// Default: true
+	// +optional
+	InjectConfig *bool `json:"injectConfig,omitempty"`
+
+	// InjectLibraries enables the injection of the APM tracing libraries, into the pods annotated with
+	// `admission.datadoghq.com/<language>-lib.version` and the ones selected by `features.apm.instrumentation`.
+	// Default: true
+	// +optional
+	InjectLibraries *bool `json:"injectLibraries,omitempty"`
 }
 
 // ExternalMetricsServerFeatureConfig contains the External Metrics Server feature configuration.
@@ -723,7 +831,19 @@ type GlobalConfig struct {
 	// +optional
 	Endpoint *Endpoint `json:"endpoint,omitempty"`
 
-	// Registry is the image registry to use for all Agent images.
+	// Proxy configures the proxy used by the Agents, and by the Operator, to reach Datadog.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// ImagePolicy configures the images of all the components: pull secrets, repository mirrors, variants and digests.
+	// +optional
+	ImagePolicy *ImagePolicyConfig `json:"imagePolicy,omitempty"`
+
+	// SecretBackend configures the secret backend used by the Agents to resolve the `ENC[]` handles of their configuration.
+	// +optional
+	SecretBackend *SecretBackendConfig `json:"secretBackend,omitempty"`
+
+	// Registry is the image registry to use for all Agent images. The image names and tags are kept.
 	// Use 'public.ecr.aws/datadog' for AWS ECR.
 	// Use 'docker.io/datadog' for DockerHub.
 	// Default: 'gcr.io/datadoghq'
@@ -811,13 +931,111 @@ type DatadogCredentials struct {
 	AppSecret *commonv1.SecretConfig `json:"appSecret,omitempty"`
 }
 
+// ProxyConfig configures an outbound HTTP(S) proxy.
+// +k8s:openapi-gen=true
+type ProxyConfig struct {
+	// HTTP is the URL of the proxy used for HTTP requests, for example 'http://proxy.example.com:3128'.
+	// +optional
+	HTTP *string `json:"http,omitempty"`
+
+	// HTTPSecret references an existing Secret which stores the HTTP proxy URL, for instance when it contains credentials.
+	// If set, this parameter takes precedence over "HTTP".
+	// +optional
+	HTTPSecret *commonv1.SecretConfig `json:"httpSecret,omitempty"`
+
+	// HTTPS is the URL of the proxy used for HTTPS requests, for example 'http://proxy.example.com:3128'.
+	// +optional
+	HTTPS *string `json:"https,omitempty"`
+
+	// HTTPSSecret references an existing Secret which stores the HTTPS proxy URL, for instance when it contains credentials.
+	// If set, this parameter takes precedence over "HTTPS".
+	// +optional
+	HTTPSSecret *commonv1.SecretConfig `json:"httpsSecret,omitempty"`
+
+	// NoProxy lists the hosts that are reached without the proxy.
+	// +optional
+	// +listType=set
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// ImagePolicyConfig provides the configuration of the images of the Agent, Cluster Agent and Cluster Checks Runner.
+// +k8s:openapi-gen=true
+type ImagePolicyConfig struct {
+	// PullSecrets lists the Secrets used to pull the images of all the components.
+	// They are replaced by the `image.pullSecrets` of a component override.
+	// +optional
+	// +listType=atomic
+	PullSecrets []corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+
+	// Repositories maps a component to the repository its Agent or Cluster Agent image is pulled from,
+	// for example 'registry.example.com/datadog/agent'. The image tag is kept.
+	// +optional
+	Repositories map[ComponentName]string `json:"repositories,omitempty"`
+
+	// JMXEnabled selects the JMX variant of the Agent image, for the Node Agent and the Cluster Checks Runner.
+	// Default: false
+	// +optional
+	JMXEnabled *bool `json:"jmxEnabled,omitempty"`
+
+	// FIPSEnabled selects the FIPS variant of the Agent and Cluster Agent images.
+	// Default: false
+	// +optional
+	FIPSEnabled *bool `json:"fipsEnabled,omitempty"`
+
+	// Manifests lists the digests of the images. An image listed here is pinned to its digest.
+	// +optional
+	// +listType=map
+	// +listMapKey=image
+	Manifests []ImageManifest `json:"manifests,omitempty"`
+}
+
+// ImageManifest provides the digest of an image.
+// +k8s:openapi-gen=true
+type ImageManifest struct {
+	// Image is the image reference, in the form '<repository>:<tag>', for example 'gcr.io/datadoghq/agent:7.50.3'.
+	Image string `json:"image"`
+
+	// Digest is the digest of the image, for example 'sha256:<hash>'.
+	Digest string `json:"digest"`
+}
+
 // SecretBackendConfig provides configuration for the secret backend.
+// +k8s:openapi-gen=true
 type SecretBackendConfig struct {
 	// Command defines the secret backend command to use
+	// +optional
 	Command *string `json:"command,omitempty"`
 
-	// Args defines the list of arguments to pass to the command
+	// Args defines the list of arguments to pass to the command.
+	// The arguments are passed space-separated to the Agent, so they can't contain whitespace.
+	// +optional
+	// +listType=atomic
 	Args []string `json:"args,omitempty"`
+
+	// Timeout defines the command timeout in seconds.
+	// +optional
+	Timeout *int32 `json:"timeout,omitempty"`
+
+	// EnableGlobalPermissions grants the Agents read access to all the Secrets of the cluster.
+	// Default: false
+	// +optional
+	EnableGlobalPermissions *bool `json:"enableGlobalPermissions,omitempty"`
+
+	// Roles grants the Agents read access to a list of Secrets in a namespace.
+	// +optional
+	// +listType=atomic
+	Roles []*SecretBackendRolesConfig `json:"roles,omitempty"`
+}
+
+// SecretBackendRolesConfig provides the configuration of the Secrets read by the secret backend.
+// +k8s:openapi-gen=true
+type SecretBackendRolesConfig struct {
+	// Namespace defines the namespace of the Secrets.
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Secrets defines the names of the Secrets the Agents can read.
+	// +listType=set
+	Secrets []string `json:"secrets,omitempty"`
 }
 
 // NetworkPolicyFlavor specifies which flavor of Network Policy to use.
@@ -902,6 +1120,26 @@ type DatadogAgentComponentOverride struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// The deployment strategy to use to replace existing pods with new ones.
+	// Valid types are `RollingUpdate` and `OnDelete` for the node Agent DaemonSet,
+	// `RollingUpdate` and `Recreate` for the Cluster Agent and Cluster Checks Runner Deployments.
+	// The rolling update, canary and reconcile frequency settings of an ExtendedDaemonSet
+	// take precedence over the values set in the operator flags.
+	// +optional
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Configure the PodDisruptionBudget of the Cluster Agent or Cluster Checks Runner Deployment.
+	// Not applicable to the node Agent.
+	// By default, a PodDisruptionBudget with `minAvailable: 1` is created when the Deployment has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+
+	// Configure a HorizontalPodAutoscaler for the Cluster Checks Runner Deployment.
+	// Not applicable to the node Agent and the Cluster Agent.
+	// When enabled, the number of replicas is managed by the HorizontalPodAutoscaler and `replicas` is ignored.
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
 	// Set CreateRbac to false to prevent automatic creation of Role/ClusterRole for this component
 	// +optional
 	CreateRbac *bool `json:"createRbac,omitempty"`
@@ -997,6 +1235,110 @@ type DatadogAgentComponentOverride struct {
 	Disabled *bool `json:"disabled,omitempty"`
 }
 
+// UpdateStrategy contains the deployment strategy configuration of a component.
+// The configuration is shared between DaemonSet, ExtendedDaemonSet and Deployment.
+type UpdateStrategy struct {
+	// Type of the update strategy. Not applicable to an ExtendedDaemonSet.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Configure the rolling update strategy.
+	// +optional
+	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+	// Configure the canary deployment configuration using ExtendedDaemonSet.
+	// +optional
+	Canary *edsdatadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary `json:"canary,omitempty"`
+	// The reconcile frequency of the ExtendedDaemonSet.
+	// +optional
+	ReconcileFrequency *metav1.Duration `json:"reconcileFrequency,omitempty"`
+}
+
+// RollingUpdate contains configuration fields of the rolling update strategy.
+// MaxPodSchedulerFailure, MaxParallelPodCreation, SlowStartIntervalDuration and SlowStartAdditiveIncrease
+// are only used by an ExtendedDaemonSet.
+type RollingUpdate struct {
+	// The maximum number of pods that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// The maximum number of pods that can be scheduled above the desired number of pods.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Not applicable to an ExtendedDaemonSet.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaxPodSchedulerFailure the maximum number of pods not scheduled on their Node due to a
+	// scheduler failure: resource constraints. Value can be an absolute number (ex: 5) or a percentage of total
+	// number of DaemonSet pods at the start of the update (ex: 10%).
+	// +optional
+	MaxPodSchedulerFailure *intstr.IntOrString `json:"maxPodSchedulerFailure,omitempty"`
+	// The maximum number of pods created in parallel.
+	// +optional
+	MaxParallelPodCreation *int32 `json:"maxParallelPodCreation,omitempty"`
+	// SlowStartIntervalDuration the duration between two steps of the slow start.
+	// +optional
+	SlowStartIntervalDuration *metav1.Duration `json:"slowStartIntervalDuration,omitempty"`
+	// SlowStartAdditiveIncrease the number of pods added at each step of the slow start.
+	// Value can be an absolute number (ex: 5) or a percentage of total
+	// number of DaemonSet pods at the start of the update (ex: 10%).
+	// +optional
+	SlowStartAdditiveIncrease *intstr.IntOrString `json:"slowStartAdditiveIncrease,omitempty"`
+}
+
+// PodDisruptionBudgetConfig contains the PodDisruptionBudget configuration of a component.
+// Only one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetConfig struct {
+	// The minimum number of pods that must be available after an eviction.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// The maximum number of pods that can be unavailable after an eviction.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoscalingConfig contains the HorizontalPodAutoscaler configuration of a component.
+// If no target is set, the average CPU utilization is targeted at 80%.
+type AutoscalingConfig struct {
+	// Enabled enables the HorizontalPodAutoscaler.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The lower limit for the number of replicas.
+	// Default: 1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// The upper limit for the number of replicas. Required when the HorizontalPodAutoscaler is enabled.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// The target average CPU utilization, represented as a percentage of the requested CPU.
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// The target average memory utilization, represented as a percentage of the requested memory.
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+	// Scale on a metric served by the Cluster Agent External Metrics Server.
+	// The `externalMetricsServer` feature must be enabled.
+	// +optional
+	ExternalMetric *AutoscalingExternalMetricConfig `json:"externalMetric,omitempty"`
+}
+
+// AutoscalingExternalMetricConfig contains the configuration of an external metric used by a HorizontalPodAutoscaler.
+// Only one of TargetValue and TargetAverageValue can be set.
+type AutoscalingExternalMetricConfig struct {
+	// Name of the metric, for example `datadogmetric@<namespace>:<name>` to use a DatadogMetric.
+	Name string `json:"name"`
+	// Labels used to select the metric.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// The target value of the metric.
+	// +optional
+	TargetValue *resource.Quantity `json:"targetValue,omitempty"`
+	// The target value of the metric divided by the number of replicas.
+	// +optional
+	TargetAverageValue *resource.Quantity `json:"targetAverageValue,omitempty"`
+}
+
 // DatadogAgentGenericContainer is the generic structure describing any container's common configuration.
 // +k8s:openapi-gen=true
 type DatadogAgentGenericContainer struct {
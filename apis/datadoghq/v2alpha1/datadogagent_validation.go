@@ -0,0 +1,721 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+)
+
+const (
+	admissionControllerHostIPCommunicationMode  = "hostip"
+	admissionControllerServiceCommunicationMode = "service"
+
+	admissionControllerFailurePolicyIgnore = "Ignore"
+	admissionControllerFailurePolicyFail   = "Fail"
+)
+
+// imageDigestRegexp matches the digests accepted in `global.imagePolicy.manifests`.
+var imageDigestRegexp = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
+// supportedInstrumentationLanguages lists the languages accepted in `features.apm.instrumentation.libVersions`.
+var supportedInstrumentationLanguages = []string{"java", "js", "python", "dotnet", "ruby"}
+
+// supportedOverrideContainers lists, for each component, the container names accepted in `override.<component>.containers`.
+var supportedOverrideContainers = map[ComponentName][]commonv1.AgentContainerName{
+	NodeAgentComponentName: {
+		commonv1.AllContainers,
+		commonv1.InitVolumeContainerName,
+		commonv1.InitConfigContainerName,
+		commonv1.SeccompSetupContainerName,
+		commonv1.UnprivilegedSingleAgentContainerName,
+		commonv1.CoreAgentContainerName,
+		commonv1.TraceAgentContainerName,
+		commonv1.ProcessAgentContainerName,
+		commonv1.SecurityAgentContainerName,
+		commonv1.SystemProbeContainerName,
+	},
+	ClusterAgentComponentName: {
+		commonv1.AllContainers,
+		commonv1.ClusterAgentContainerName,
+	},
+	ClusterChecksRunnerComponentName: {
+		commonv1.AllContainers,
+		commonv1.InitConfigContainerName,
+		commonv1.ClusterChecksRunnersContainerName,
+	},
+}
+
+// supportedUpdateStrategyTypes lists, for each component, the values accepted in `override.<component>.updateStrategy.type`.
+var supportedUpdateStrategyTypes = map[ComponentName][]string{
+	NodeAgentComponentName:           {string(appsv1.RollingUpdateDaemonSetStrategyType), string(appsv1.OnDeleteDaemonSetStrategyType)},
+	ClusterAgentComponentName:        {string(appsv1.RollingUpdateDeploymentStrategyType), string(appsv1.RecreateDeploymentStrategyType)},
+	ClusterChecksRunnerComponentName: {string(appsv1.RollingUpdateDeploymentStrategyType), string(appsv1.RecreateDeploymentStrategyType)},
+}
+
+// supportedProfileNodeSelectorOperators lists the operators accepted in `profiles[].nodeSelectorRequirements`.
+// They are the operators that can be negated to keep the profiles from overlapping.
+var supportedProfileNodeSelectorOperators = []string{
+	string(corev1.NodeSelectorOpIn),
+	string(corev1.NodeSelectorOpNotIn),
+	string(corev1.NodeSelectorOpExists),
+	string(corev1.NodeSelectorOpDoesNotExist),
+}
+
+// supportedProfileDisabledFeatures lists the feature IDs accepted in `profiles[].disabledFeatures`.
+// It must be kept in sync with the IDs of controllers/datadogagent/feature, except the default feature that can't be disabled;
+// the controllers/datadogagent tests fail when they differ.
+var supportedProfileDisabledFeatures = []string{
+	"admission_controller",
+	"apm",
+	"cluster_checks",
+	"cspm",
+	"cws",
+	"dogstatsd",
+	"ebpf_check",
+	"event_collection",
+	"external_metrics",
+	"ksm",
+	"live_container",
+	"live_process",
+	"log_collection",
+	"npm",
+	"oom_kill",
+	"orchestrator_explorer",
+	"otlp",
+	"process_discovery",
+	"prometheus_scrape",
+	"remote_config",
+	"sbom",
+	"tcp_queue_length",
+	"usm",
+}
+
+// SupportedProfileDisabledFeatures returns the feature IDs accepted in `profiles[].disabledFeatures`.
+func SupportedProfileDisabledFeatures() []string {
+	return append([]string(nil), supportedProfileDisabledFeatures...)
+}
+
+// ValidateDatadogAgent checks that a DatadogAgent doesn't contain contradictory or incomplete settings.
+// It is used by the validating webhook and by the reconciler, and accepts defaulted as well as non-defaulted specs.
+func ValidateDatadogAgent(dda *DatadogAgent) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	var errs field.ErrorList
+	errs = append(errs, validateGlobalConfig(dda.Spec.Global, specPath.Child("global"))...)
+	errs = append(errs, validateFeatures(&dda.Spec, specPath.Child("features"))...)
+	errs = append(errs, validateOverrides(&dda.Spec, specPath.Child("override"))...)
+	errs = append(errs, validateProfiles(&dda.Spec, specPath.Child("profiles"))...)
+
+	return errs
+}
+
+// GetDatadogAgentSpecWarnings returns the settings of a DatadogAgent that are accepted, but can harm the cluster.
+// They were accepted before the validation was added, so they are reported instead of rejected.
+func GetDatadogAgentSpecWarnings(dda *DatadogAgent) []string {
+	var warnings []string
+	if dda.Spec.Features == nil {
+		return warnings
+	}
+
+	if ac := dda.Spec.Features.AdmissionController; ac != nil && (ac.Enabled == nil || *ac.Enabled) && apiutils.StringValue(ac.FailurePolicy) == admissionControllerFailurePolicyFail {
+		failurePolicyPath := field.NewPath("spec", "features", "admissionController", "failurePolicy")
+		if ac.ObjectSelector == nil && apiutils.BoolValue(ac.MutateUnlabelled) {
+			warnings = append(warnings, fmt.Sprintf("%s: 'Fail' with 'mutateUnlabelled' blocks the creation of all the pods when the webhook fails, use 'Ignore'", failurePolicyPath))
+		}
+		if ac.ObjectSelector != nil && len(ac.ObjectSelector.MatchLabels) == 0 && len(ac.ObjectSelector.MatchExpressions) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: 'Fail' with an empty 'objectSelector' blocks the creation of all the pods when the webhook fails, use 'Ignore'", failurePolicyPath))
+		}
+	}
+
+	return warnings
+}
+
+// IsValidDatadogAgent is used to check if a DatadogAgent is valid.
+// It returns an aggregated error containing every violation, or nil.
+func IsValidDatadogAgent(dda *DatadogAgent) error {
+	return ValidateDatadogAgent(dda).ToAggregate()
+}
+
+func validateGlobalConfig(global *GlobalConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if global == nil || global.Credentials == nil {
+		return append(errs, field.Required(fldPath.Child("credentials"), "Datadog credentials must be configured"))
+	}
+
+	credsPath := fldPath.Child("credentials")
+	creds := global.Credentials
+	if creds.APISecret == nil && apiutils.StringValue(creds.APIKey) == "" {
+		errs = append(errs, field.Required(credsPath.Child("apiKey"), "either 'apiKey' or 'apiSecret' must be set"))
+	}
+	errs = append(errs, validateSecretConfig(creds.APISecret, credsPath.Child("apiSecret"))...)
+	errs = append(errs, validateSecretConfig(creds.AppSecret, credsPath.Child("appSecret"))...)
+	errs = append(errs, validateSecretConfig(global.ClusterAgentTokenSecret, fldPath.Child("clusterAgentTokenSecret"))...)
+	errs = append(errs, validateProxy(global.Proxy, fldPath.Child("proxy"))...)
+	errs = append(errs, validateSecretBackend(global.SecretBackend, fldPath.Child("secretBackend"))...)
+	errs = append(errs, validateImagePolicy(global.ImagePolicy, fldPath.Child("imagePolicy"))...)
+
+	return errs
+}
+
+func validateProxy(proxy *ProxyConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if proxy == nil {
+		return errs
+	}
+
+	errs = append(errs, validateProxyURL(proxy.HTTP, fldPath.Child("http"))...)
+	errs = append(errs, validateProxyURL(proxy.HTTPS, fldPath.Child("https"))...)
+	errs = append(errs, validateProxySecret(proxy.HTTPSecret, fldPath.Child("httpSecret"))...)
+	errs = append(errs, validateProxySecret(proxy.HTTPSSecret, fldPath.Child("httpsSecret"))...)
+
+	return errs
+}
+
+// validateProxySecret checks a proxy Secret reference, which has no default key.
+func validateProxySecret(secret *commonv1.SecretConfig, fldPath *field.Path) field.ErrorList {
+	errs := validateSecretConfig(secret, fldPath)
+	if secret != nil && secret.KeyName == "" {
+		errs = append(errs, field.Required(fldPath.Child("keyName"), "secret key name must be set"))
+	}
+	return errs
+}
+
+func validateProxyURL(proxyURL *string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if proxyURL == nil || *proxyURL == "" {
+		return errs
+	}
+
+	u, err := url.Parse(*proxyURL)
+	if err != nil || u.Scheme == "" || u.Hostname() == "" {
+		return append(errs, field.Invalid(fldPath, *proxyURL, "must be a URL in the form 'scheme://host[:port]'"))
+	}
+	if port := u.Port(); port != "" {
+		if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+			errs = append(errs, field.Invalid(fldPath, *proxyURL, "port must be a number between 1 and 65535"))
+		}
+	}
+
+	return errs
+}
+
+func validateSecretBackend(secretBackend *SecretBackendConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if secretBackend == nil {
+		return errs
+	}
+
+	// The arguments are passed space-separated to the Agent, which splits them on spaces
+	for i, arg := range secretBackend.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n") {
+			errs = append(errs, field.Invalid(fldPath.Child("args").Index(i), arg, "must be non-empty and can't contain whitespace"))
+		}
+	}
+
+	if secretBackend.Timeout != nil && *secretBackend.Timeout <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("timeout"), *secretBackend.Timeout, "must be greater than 0"))
+	}
+
+	for i, role := range secretBackend.Roles {
+		rolePath := fldPath.Child("roles").Index(i)
+		if role == nil {
+			continue
+		}
+		if namespace := apiutils.StringValue(role.Namespace); namespace == "" {
+			errs = append(errs, field.Required(rolePath.Child("namespace"), "the namespace of the secrets must be set"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(namespace) {
+				errs = append(errs, field.Invalid(rolePath.Child("namespace"), namespace, msg))
+			}
+		}
+		if len(role.Secrets) == 0 {
+			errs = append(errs, field.Required(rolePath.Child("secrets"), "at least one secret must be set"))
+		}
+		for j, secret := range role.Secrets {
+			for _, msg := range validation.IsDNS1123Subdomain(secret) {
+				errs = append(errs, field.Invalid(rolePath.Child("secrets").Index(j), secret, msg))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateImagePolicy(policy *ImagePolicyConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if policy == nil {
+		return errs
+	}
+
+	// Iterate in a stable order so that the reported errors are deterministic.
+	components := make([]string, 0, len(policy.Repositories))
+	for component := range policy.Repositories {
+		components = append(components, string(component))
+	}
+	sort.Strings(components)
+
+	for _, name := range components {
+		component := ComponentName(name)
+		repository := policy.Repositories[component]
+		repositoryPath := fldPath.Child("repositories").Key(name)
+		if _, found := supportedOverrideContainers[component]; !found {
+			errs = append(errs, field.NotSupported(repositoryPath, component, []string{
+				string(NodeAgentComponentName),
+				string(ClusterAgentComponentName),
+				string(ClusterChecksRunnerComponentName),
+			}))
+			continue
+		}
+		if repository == "" || strings.Contains(repository, "@") || defaulting.IsImageNameContainsTag(repository) {
+			errs = append(errs, field.Invalid(repositoryPath, repository, "must be a repository in the form '<registry>/<name>', without tag nor digest"))
+		}
+	}
+
+	images := make(map[string]bool, len(policy.Manifests))
+	for i, manifest := range policy.Manifests {
+		manifestPath := fldPath.Child("manifests").Index(i)
+		if strings.Contains(manifest.Image, "@") || !defaulting.IsImageNameContainsTag(manifest.Image) {
+			errs = append(errs, field.Invalid(manifestPath.Child("image"), manifest.Image, "must be an image in the form '<repository>:<tag>'"))
+		} else if images[manifest.Image] {
+			errs = append(errs, field.Duplicate(manifestPath.Child("image"), manifest.Image))
+		}
+		images[manifest.Image] = true
+		if !imageDigestRegexp.MatchString(manifest.Digest) {
+			errs = append(errs, field.Invalid(manifestPath.Child("digest"), manifest.Digest, "must be a digest in the form 'sha256:<hash>'"))
+		}
+	}
+
+	return errs
+}
+
+func validateFeatures(spec *DatadogAgentSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	features := spec.Features
+	if features == nil {
+		return errs
+	}
+
+	if features.OTLP != nil {
+		protocolsPath := fldPath.Child("otlp", "receiver", "protocols")
+		if grpc := features.OTLP.Receiver.Protocols.GRPC; grpc != nil {
+			errs = append(errs, validateHostPortEndpoint(grpc.Endpoint, protocolsPath.Child("grpc", "endpoint"))...)
+		}
+		if http := features.OTLP.Receiver.Protocols.HTTP; http != nil {
+			errs = append(errs, validateHostPortEndpoint(http.Endpoint, protocolsPath.Child("http", "endpoint"))...)
+		}
+	}
+
+	if ac := features.AdmissionController; ac != nil {
+		errs = append(errs, validateAdmissionController(ac, features, fldPath.Child("admissionController"))...)
+	}
+
+	if apm := features.APM; apm != nil && apm.SingleStepInstrumentation != nil {
+		errs = append(errs, validateSingleStepInstrumentation(apm, features.AdmissionController, fldPath.Child("apm", "instrumentation"))...)
+	}
+
+	if ems := features.ExternalMetricsServer; ems != nil && apiutils.BoolValue(ems.Enabled) {
+		if !hasAppKey(spec.Global) && (ems.Endpoint == nil || !hasAppKeyInCredentials(ems.Endpoint.Credentials)) {
+			errs = append(errs, field.Required(field.NewPath("spec", "global", "credentials", "appKey"), "an application key is required when the External Metrics Server is enabled"))
+		}
+	}
+
+	if features.KubeStateMetricsCore != nil {
+		errs = append(errs, validateCustomConfig(features.KubeStateMetricsCore.Conf, fldPath.Child("kubeStateMetricsCore", "conf"))...)
+	}
+	if features.OrchestratorExplorer != nil {
+		errs = append(errs, validateCustomConfig(features.OrchestratorExplorer.Conf, fldPath.Child("orchestratorExplorer", "conf"))...)
+	}
+	if features.CSPM != nil {
+		errs = append(errs, validateCustomConfig(features.CSPM.CustomBenchmarks, fldPath.Child("cspm", "customBenchmarks"))...)
+	}
+	if features.CWS != nil {
+		errs = append(errs, validateCustomConfig(features.CWS.CustomPolicies, fldPath.Child("cws", "customPolicies"))...)
+	}
+	if features.Dogstatsd != nil {
+		errs = append(errs, validateCustomConfig(features.Dogstatsd.MapperProfiles, fldPath.Child("dogstatsd", "mapperProfiles"))...)
+	}
+
+	return errs
+}
+
+func validateAdmissionController(ac *AdmissionControllerFeatureConfig, features *DatadogFeatures, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if mode := apiutils.StringValue(ac.AgentCommunicationMode); mode != "" {
+		modePath := fldPath.Child("agentCommunicationMode")
+		switch mode {
+		case admissionControllerHostIPCommunicationMode, admissionControllerServiceCommunicationMode:
+		case apicommon.AdmissionControllerSocketCommunicationMode:
+			if isUDSDisabled(features.APM, features.Dogstatsd) {
+				errs = append(errs, field.Invalid(modePath, mode, "socket mode requires Unix Domain Socket to be enabled for APM or DogStatsD"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(modePath, mode, []string{
+				admissionControllerHostIPCommunicationMode,
+				admissionControllerServiceCommunicationMode,
+				apicommon.AdmissionControllerSocketCommunicationMode,
+			}))
+		}
+	}
+
+	if policy := apiutils.StringValue(ac.FailurePolicy); policy != "" && policy != admissionControllerFailurePolicyIgnore && policy != admissionControllerFailurePolicyFail {
+		errs = append(errs, field.NotSupported(fldPath.Child("failurePolicy"), policy, []string{admissionControllerFailurePolicyIgnore, admissionControllerFailurePolicyFail}))
+	}
+
+	if ac.NamespaceSelector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(ac.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+	if ac.ObjectSelector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(ac.ObjectSelector, fldPath.Child("objectSelector"))...)
+	}
+
+	if ac.InjectLibraries != nil && !*ac.InjectLibraries && IsSingleStepInstrumentationEnabled(features.APM) {
+		errs = append(errs, field.Forbidden(fldPath.Child("injectLibraries"), "can't be disabled when 'features.apm.instrumentation' is enabled"))
+	}
+
+	return errs
+}
+
+func validateSingleStepInstrumentation(apm *APMFeatureConfig, ac *AdmissionControllerFeatureConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	ssi := apm.SingleStepInstrumentation
+
+	if apiutils.BoolValue(ssi.Enabled) {
+		if len(ssi.EnabledNamespaces) > 0 {
+			errs = append(errs, field.Forbidden(fldPath.Child("enabledNamespaces"), "can't be set when 'enabled' is true"))
+		}
+	} else if len(ssi.DisabledNamespaces) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("disabledNamespaces"), "can only be set when 'enabled' is true"))
+	}
+	errs = append(errs, validateNamespaceNames(ssi.EnabledNamespaces, fldPath.Child("enabledNamespaces"))...)
+	errs = append(errs, validateNamespaceNames(ssi.DisabledNamespaces, fldPath.Child("disabledNamespaces"))...)
+
+	languages := make([]string, 0, len(ssi.LibVersions))
+	for language := range ssi.LibVersions {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		versionPath := fldPath.Child("libVersions").Key(language)
+		if !isSupportedString(language, supportedInstrumentationLanguages) {
+			errs = append(errs, field.NotSupported(versionPath, language, supportedInstrumentationLanguages))
+		} else if ssi.LibVersions[language] == "" {
+			errs = append(errs, field.Required(versionPath, "the library version must be set"))
+		}
+	}
+
+	if IsSingleStepInstrumentationEnabled(apm) {
+		if apm.Enabled != nil && !*apm.Enabled {
+			errs = append(errs, field.Forbidden(fldPath, "library injection requires APM to be enabled"))
+		}
+		if ac != nil && ac.Enabled != nil && !*ac.Enabled {
+			errs = append(errs, field.Forbidden(fldPath, "library injection requires the Admission Controller to be enabled"))
+		}
+	}
+
+	return errs
+}
+
+func validateNamespaceNames(namespaces []string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, namespace := range namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			errs = append(errs, field.Invalid(fldPath.Index(i), namespace, msg))
+		}
+	}
+	return errs
+}
+
+// isUDSDisabled returns true if Unix Domain Socket is explicitly disabled for both APM and DogStatsD.
+// UDS is enabled by default, so unset values are considered enabled.
+func isUDSDisabled(apm *APMFeatureConfig, dsd *DogstatsdFeatureConfig) bool {
+	apmUDSDisabled := apm != nil && (apm.Enabled != nil && !*apm.Enabled ||
+		apm.UnixDomainSocketConfig != nil && apm.UnixDomainSocketConfig.Enabled != nil && !*apm.UnixDomainSocketConfig.Enabled)
+	dsdUDSDisabled := dsd != nil && dsd.UnixDomainSocketConfig != nil && dsd.UnixDomainSocketConfig.Enabled != nil && !*dsd.UnixDomainSocketConfig.Enabled
+
+	return apmUDSDisabled && dsdUDSDisabled
+}
+
+func validateOverrides(spec *DatadogAgentSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	overrides := spec.Override
+
+	// Iterate in a stable order so that the reported errors are deterministic.
+	components := make([]string, 0, len(overrides))
+	for component := range overrides {
+		components = append(components, string(component))
+	}
+	sort.Strings(components)
+
+	for _, name := range components {
+		component := ComponentName(name)
+		override := overrides[component]
+		componentPath := fldPath.Key(string(component))
+		if _, found := supportedOverrideContainers[component]; !found {
+			errs = append(errs, field.NotSupported(componentPath, component, []string{
+				string(NodeAgentComponentName),
+				string(ClusterAgentComponentName),
+				string(ClusterChecksRunnerComponentName),
+			}))
+			continue
+		}
+		errs = append(errs, validateOverride(component, override, spec, componentPath)...)
+	}
+
+	return errs
+}
+
+// validateOverride validates the override of a known component.
+func validateOverride(component ComponentName, override *DatadogAgentComponentOverride, spec *DatadogAgentSpec, componentPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if override == nil {
+		return errs
+	}
+	supportedContainers := supportedOverrideContainers[component]
+
+	for container := range override.Containers {
+		if !isSupportedContainer(container, supportedContainers) {
+			errs = append(errs, field.NotSupported(componentPath.Child("containers").Key(string(container)), container, containerNamesToStrings(supportedContainers)))
+		}
+	}
+
+	for fileName, conf := range override.CustomConfigurations {
+		conf := conf
+		errs = append(errs, validateCustomConfig(&conf, componentPath.Child("customConfigurations").Key(string(fileName)))...)
+	}
+	errs = append(errs, validateMultiCustomConfig(override.ExtraConfd, componentPath.Child("extraConfd"))...)
+	errs = append(errs, validateMultiCustomConfig(override.ExtraChecksd, componentPath.Child("extraChecksd"))...)
+
+	if override.Replicas != nil && *override.Replicas < 0 {
+		errs = append(errs, field.Invalid(componentPath.Child("replicas"), *override.Replicas, "must be greater than or equal to 0"))
+	}
+
+	if pdb := override.PodDisruptionBudget; pdb != nil {
+		pdbPath := componentPath.Child("podDisruptionBudget")
+		if component == NodeAgentComponentName {
+			errs = append(errs, field.Forbidden(pdbPath, "a PodDisruptionBudget can't be configured for the node Agent"))
+		} else if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+			errs = append(errs, field.Forbidden(pdbPath, "'minAvailable' and 'maxUnavailable' should not be set at the same time"))
+		}
+	}
+
+	if override.Autoscaling != nil {
+		autoscalingPath := componentPath.Child("autoscaling")
+		if component != ClusterChecksRunnerComponentName {
+			errs = append(errs, field.Forbidden(autoscalingPath, "autoscaling can only be configured for the Cluster Checks Runner"))
+		} else {
+			errs = append(errs, validateAutoscaling(override.Autoscaling, spec.Features, autoscalingPath)...)
+		}
+	}
+
+	if override.UpdateStrategy != nil && override.UpdateStrategy.Type != "" {
+		supportedTypes := supportedUpdateStrategyTypes[component]
+		if !isSupportedString(override.UpdateStrategy.Type, supportedTypes) {
+			errs = append(errs, field.NotSupported(componentPath.Child("updateStrategy", "type"), override.UpdateStrategy.Type, supportedTypes))
+		}
+	}
+
+	return errs
+}
+
+func validateProfiles(spec *DatadogAgentSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]struct{}{}
+	for i, profile := range spec.Profiles {
+		profilePath := fldPath.Index(i)
+
+		if profile.Name == "" {
+			errs = append(errs, field.Required(profilePath.Child("name"), "a profile name must be set"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(profile.Name) {
+				errs = append(errs, field.Invalid(profilePath.Child("name"), profile.Name, msg))
+			}
+			if _, found := names[profile.Name]; found {
+				errs = append(errs, field.Duplicate(profilePath.Child("name"), profile.Name))
+			}
+			names[profile.Name] = struct{}{}
+		}
+
+		requirementsPath := profilePath.Child("nodeSelectorRequirements")
+		if len(profile.NodeSelectorRequirements) == 0 {
+			errs = append(errs, field.Required(requirementsPath, "at least one node selector requirement must be set"))
+		}
+		for j, requirement := range profile.NodeSelectorRequirements {
+			errs = append(errs, validateProfileNodeSelectorRequirement(requirement, requirementsPath.Index(j))...)
+		}
+
+		for j, id := range profile.DisabledFeatures {
+			if !isSupportedString(id, supportedProfileDisabledFeatures) {
+				errs = append(errs, field.NotSupported(profilePath.Child("disabledFeatures").Index(j), id, supportedProfileDisabledFeatures))
+			}
+		}
+
+		if profile.Override != nil {
+			overridePath := profilePath.Child("override")
+			if profile.Override.Name != nil {
+				errs = append(errs, field.Forbidden(overridePath.Child("name"), "the DaemonSet name of a profile is derived from the profile name"))
+			}
+			errs = append(errs, validateOverride(NodeAgentComponentName, profile.Override, spec, overridePath)...)
+		}
+	}
+
+	return errs
+}
+
+func validateProfileNodeSelectorRequirement(requirement corev1.NodeSelectorRequirement, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if requirement.Key == "" {
+		errs = append(errs, field.Required(fldPath.Child("key"), "a node label key must be set"))
+	}
+
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+		if len(requirement.Values) == 0 {
+			errs = append(errs, field.Required(fldPath.Child("values"), "values must be set with the In and NotIn operators"))
+		}
+	case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+		if len(requirement.Values) > 0 {
+			errs = append(errs, field.Forbidden(fldPath.Child("values"), "values can't be set with the Exists and DoesNotExist operators"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("operator"), requirement.Operator, supportedProfileNodeSelectorOperators))
+	}
+
+	return errs
+}
+
+func validateAutoscaling(autoscaling *AutoscalingConfig, features *DatadogFeatures, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if !apiutils.BoolValue(autoscaling.Enabled) {
+		return errs
+	}
+
+	if autoscaling.MaxReplicas == nil {
+		errs = append(errs, field.Required(fldPath.Child("maxReplicas"), "maxReplicas must be set when autoscaling is enabled"))
+	} else if *autoscaling.MaxReplicas < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxReplicas"), *autoscaling.MaxReplicas, "must be greater than or equal to 1"))
+	}
+	if autoscaling.MinReplicas != nil {
+		if *autoscaling.MinReplicas < 1 {
+			errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *autoscaling.MinReplicas, "must be greater than or equal to 1"))
+		} else if autoscaling.MaxReplicas != nil && *autoscaling.MinReplicas > *autoscaling.MaxReplicas {
+			errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *autoscaling.MinReplicas, "must be less than or equal to maxReplicas"))
+		}
+	}
+	if autoscaling.TargetCPUUtilizationPercentage != nil && *autoscaling.TargetCPUUtilizationPercentage < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("targetCPUUtilizationPercentage"), *autoscaling.TargetCPUUtilizationPercentage, "must be greater than 0"))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil && *autoscaling.TargetMemoryUtilizationPercentage < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("targetMemoryUtilizationPercentage"), *autoscaling.TargetMemoryUtilizationPercentage, "must be greater than 0"))
+	}
+
+	if metric := autoscaling.ExternalMetric; metric != nil {
+		metricPath := fldPath.Child("externalMetric")
+		if metric.Name == "" {
+			errs = append(errs, field.Required(metricPath.Child("name"), "the metric name must be set"))
+		}
+		if (metric.TargetValue == nil) == (metric.TargetAverageValue == nil) {
+			errs = append(errs, field.Invalid(metricPath, metric.Name, "exactly one of 'targetValue' and 'targetAverageValue' must be set"))
+		}
+		if features == nil || features.ExternalMetricsServer == nil || !apiutils.BoolValue(features.ExternalMetricsServer.Enabled) {
+			errs = append(errs, field.Invalid(metricPath, metric.Name, "the externalMetricsServer feature must be enabled to use an external metric"))
+		}
+	}
+
+	return errs
+}
+
+func validateHostPortEndpoint(endpoint *string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if endpoint == nil {
+		return errs
+	}
+
+	_, port, err := net.SplitHostPort(*endpoint)
+	if err != nil {
+		return append(errs, field.Invalid(fldPath, *endpoint, "must be in the form 'host:port'"))
+	}
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		errs = append(errs, field.Invalid(fldPath, *endpoint, "port must be a number between 1 and 65535"))
+	}
+
+	return errs
+}
+
+func validateCustomConfig(conf *CustomConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if conf != nil && conf.ConfigData != nil && conf.ConfigMap != nil {
+		errs = append(errs, field.Forbidden(fldPath, "'configData' and 'configMap' should not be set at the same time"))
+	}
+	return errs
+}
+
+func validateMultiCustomConfig(conf *MultiCustomConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if conf != nil && len(conf.ConfigDataMap) > 0 && conf.ConfigMap != nil {
+		errs = append(errs, field.Forbidden(fldPath, "'configDataMap' and 'configMap' should not be set at the same time"))
+	}
+	return errs
+}
+
+func validateSecretConfig(secret *commonv1.SecretConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if secret != nil && secret.SecretName == "" {
+		errs = append(errs, field.Required(fldPath.Child("secretName"), "secret name must be set"))
+	}
+	return errs
+}
+
+func hasAppKey(global *GlobalConfig) bool {
+	return global != nil && hasAppKeyInCredentials(global.Credentials)
+}
+
+func hasAppKeyInCredentials(creds *DatadogCredentials) bool {
+	return creds != nil && (creds.AppSecret != nil || apiutils.StringValue(creds.AppKey) != "")
+}
+
+func isSupportedContainer(name commonv1.AgentContainerName, supported []commonv1.AgentContainerName) bool {
+	for _, s := range supported {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSupportedString(value string, supported []string) bool {
+	for _, s := range supported {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containerNamesToStrings(names []commonv1.AgentContainerName) []string {
+	output := make([]string, 0, len(names))
+	for _, name := range names {
+		output = append(output, string(name))
+	}
+	return output
+}
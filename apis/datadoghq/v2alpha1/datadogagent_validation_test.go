@@ -0,0 +1,624 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func newValidDatadogAgent() *DatadogAgent {
+	return &DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "datadog",
+		},
+		Spec: DatadogAgentSpec{
+			Global: &GlobalConfig{
+				Credentials: &DatadogCredentials{
+					APIKey: apiutils.NewStringPointer("0000000000000000000000"),
+				},
+			},
+		},
+	}
+}
+
+func TestValidateDatadogAgent(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     func(dda *DatadogAgent)
+		wantFields []string
+	}{
+		{
+			name:   "valid minimal spec",
+			update: func(dda *DatadogAgent) {},
+		},
+		{
+			name: "missing credentials",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.Credentials = nil
+			},
+			wantFields: []string{"spec.global.credentials"},
+		},
+		{
+			name: "missing api key",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.Credentials = &DatadogCredentials{
+					AppKey: apiutils.NewStringPointer("0000000000000000000000"),
+				}
+			},
+			wantFields: []string{"spec.global.credentials.apiKey"},
+		},
+		{
+			name: "api secret without name",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.Credentials = &DatadogCredentials{
+					APISecret: &commonv1.SecretConfig{KeyName: "api_key"},
+				}
+			},
+			wantFields: []string{"spec.global.credentials.apiSecret.secretName"},
+		},
+		{
+			name: "proxy",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.Proxy = &ProxyConfig{
+					HTTP:        apiutils.NewStringPointer("http://proxy.example.com:3128"),
+					HTTPSSecret: &commonv1.SecretConfig{SecretName: "proxy", KeyName: "https"},
+					NoProxy:     []string{"localhost", "169.254.169.254"},
+				}
+			},
+		},
+		{
+			name: "invalid proxy",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.Proxy = &ProxyConfig{
+					HTTP:        apiutils.NewStringPointer("proxy.example.com:3128"),
+					HTTPS:       apiutils.NewStringPointer("http://proxy.example.com:99999"),
+					HTTPSecret:  &commonv1.SecretConfig{SecretName: "proxy"},
+					HTTPSSecret: &commonv1.SecretConfig{KeyName: "https"},
+				}
+			},
+			wantFields: []string{
+				"spec.global.proxy.http",
+				"spec.global.proxy.https",
+				"spec.global.proxy.httpSecret.keyName",
+				"spec.global.proxy.httpsSecret.secretName",
+			},
+		},
+		{
+			name: "secret backend",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.SecretBackend = &SecretBackendConfig{
+					Command: apiutils.NewStringPointer("/readsecret_multiple_providers.sh"),
+					Timeout: apiutils.NewInt32Pointer(60),
+					Roles: []*SecretBackendRolesConfig{
+						{Namespace: apiutils.NewStringPointer("secrets"), Secrets: []string{"db-password", "redis"}},
+					},
+				}
+			},
+		},
+		{
+			name: "invalid secret backend",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.SecretBackend = &SecretBackendConfig{
+					Command: apiutils.NewStringPointer("/readsecret_multiple_providers.sh"),
+					Args:    []string{"--timeout", "--log-level debug"},
+					Timeout: apiutils.NewInt32Pointer(0),
+					Roles: []*SecretBackendRolesConfig{
+						{Secrets: []string{"db-password"}},
+						{Namespace: apiutils.NewStringPointer("Secrets"), Secrets: []string{"redis", "Bad_Name"}},
+						{Namespace: apiutils.NewStringPointer("secrets")},
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.global.secretBackend.args[1]",
+				"spec.global.secretBackend.timeout",
+				"spec.global.secretBackend.roles[0].namespace",
+				"spec.global.secretBackend.roles[1].namespace",
+				"spec.global.secretBackend.roles[1].secrets[1]",
+				"spec.global.secretBackend.roles[2].secrets",
+			},
+		},
+		{
+			name: "image policy",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.ImagePolicy = &ImagePolicyConfig{
+					PullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+					Repositories: map[ComponentName]string{
+						NodeAgentComponentName:    "localhost:5000/datadog/agent",
+						ClusterAgentComponentName: "localhost:5000/datadog/cluster-agent",
+					},
+					Manifests: []ImageManifest{
+						{Image: "localhost:5000/datadog/agent:7.50.3", Digest: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+					},
+				}
+			},
+		},
+		{
+			name: "invalid image policy",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Global.ImagePolicy = &ImagePolicyConfig{
+					Repositories: map[ComponentName]string{
+						NodeAgentComponentName:           "localhost:5000/datadog/agent:7.50.3",
+						ClusterChecksRunnerComponentName: "",
+						"otherAgent":                     "localhost:5000/datadog/agent",
+					},
+					Manifests: []ImageManifest{
+						{Image: "localhost:5000/datadog/agent", Digest: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+						{Image: "localhost:5000/datadog/agent:7.50.3", Digest: "sha256:1234"},
+						{Image: "localhost:5000/datadog/agent:7.50.3", Digest: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.global.imagePolicy.repositories[clusterChecksRunner]",
+				"spec.global.imagePolicy.repositories[nodeAgent]",
+				"spec.global.imagePolicy.repositories[otherAgent]",
+				"spec.global.imagePolicy.manifests[0].image",
+				"spec.global.imagePolicy.manifests[1].digest",
+				"spec.global.imagePolicy.manifests[2].image",
+			},
+		},
+		{
+			name: "external metrics server without app key",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					ExternalMetricsServer: &ExternalMetricsServerFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+					},
+				}
+			},
+			wantFields: []string{"spec.global.credentials.appKey"},
+		},
+		{
+			name: "otlp endpoints without port",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					OTLP: &OTLPFeatureConfig{Receiver: OTLPReceiverConfig{Protocols: OTLPProtocolsConfig{
+						GRPC: &OTLPGRPCConfig{Enabled: apiutils.NewBoolPointer(true), Endpoint: apiutils.NewStringPointer("0.0.0.0")},
+						HTTP: &OTLPHTTPConfig{Enabled: apiutils.NewBoolPointer(true), Endpoint: apiutils.NewStringPointer("0.0.0.0:http")},
+					}}},
+				}
+			},
+			wantFields: []string{
+				"spec.features.otlp.receiver.protocols.grpc.endpoint",
+				"spec.features.otlp.receiver.protocols.http.endpoint",
+			},
+		},
+		{
+			name: "admission controller socket mode with uds disabled",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						Enabled:                apiutils.NewBoolPointer(true),
+						AgentCommunicationMode: apiutils.NewStringPointer(apicommon.AdmissionControllerSocketCommunicationMode),
+					},
+					APM: &APMFeatureConfig{
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{Enabled: apiutils.NewBoolPointer(false)},
+					},
+					Dogstatsd: &DogstatsdFeatureConfig{
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{Enabled: apiutils.NewBoolPointer(false)},
+					},
+				}
+			},
+			wantFields: []string{"spec.features.admissionController.agentCommunicationMode"},
+		},
+		{
+			name: "admission controller socket mode with dsd uds enabled",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						AgentCommunicationMode: apiutils.NewStringPointer(apicommon.AdmissionControllerSocketCommunicationMode),
+					},
+					APM: &APMFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(false),
+					},
+				}
+			},
+		},
+		{
+			name: "admission controller unknown mode and failure policy",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						AgentCommunicationMode: apiutils.NewStringPointer("pigeon"),
+						FailurePolicy:          apiutils.NewStringPointer("Maybe"),
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.features.admissionController.agentCommunicationMode",
+				"spec.features.admissionController.failurePolicy",
+			},
+		},
+		{
+			name: "apm library injection",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					APM: &APMFeatureConfig{
+						SingleStepInstrumentation: &SingleStepInstrumentation{
+							Enabled:            apiutils.NewBoolPointer(true),
+							DisabledNamespaces: []string{"kube-system"},
+							LibVersions:        map[string]string{"java": "v1.31.0", "python": "v2"},
+						},
+					},
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						InjectTags: apiutils.NewBoolPointer(true),
+					},
+				}
+			},
+		},
+		{
+			name: "invalid apm library injection",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					APM: &APMFeatureConfig{
+						SingleStepInstrumentation: &SingleStepInstrumentation{
+							EnabledNamespaces:  []string{"default", "Bad_Namespace"},
+							DisabledNamespaces: []string{"kube-system"},
+							LibVersions:        map[string]string{"cobol": "v1", "java": ""},
+						},
+					},
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(false),
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.features.apm.instrumentation",
+				"spec.features.apm.instrumentation.enabledNamespaces[1]",
+				"spec.features.apm.instrumentation.disabledNamespaces",
+				"spec.features.apm.instrumentation.libVersions[cobol]",
+				"spec.features.apm.instrumentation.libVersions[java]",
+			},
+		},
+		{
+			name: "apm library injection with apm disabled",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					APM: &APMFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(false),
+						SingleStepInstrumentation: &SingleStepInstrumentation{
+							Enabled:           apiutils.NewBoolPointer(true),
+							EnabledNamespaces: []string{"default"},
+						},
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.features.apm.instrumentation",
+				"spec.features.apm.instrumentation.enabledNamespaces",
+			},
+		},
+		{
+			name: "admission controller selectors and mutations",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						MutateUnlabelled: apiutils.NewBoolPointer(true),
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "web"},
+						},
+						ObjectSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "app", Operator: metav1.LabelSelectorOpExists},
+							},
+						},
+						FailurePolicy:   apiutils.NewStringPointer("Ignore"),
+						TimeoutSeconds:  apiutils.NewInt32Pointer(5),
+						InjectConfig:    apiutils.NewBoolPointer(false),
+						InjectLibraries: apiutils.NewBoolPointer(false),
+					},
+				}
+			},
+		},
+		{
+			name: "invalid admission controller selectors and mutations",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Features = &DatadogFeatures{
+					AdmissionController: &AdmissionControllerFeatureConfig{
+						FailurePolicy:   apiutils.NewStringPointer("Never"),
+						InjectLibraries: apiutils.NewBoolPointer(false),
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "-web"},
+						},
+						ObjectSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "app", Operator: metav1.LabelSelectorOpIn},
+							},
+						},
+					},
+					APM: &APMFeatureConfig{
+						SingleStepInstrumentation: &SingleStepInstrumentation{
+							EnabledNamespaces: []string{"default"},
+						},
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.features.admissionController.failurePolicy",
+				"spec.features.admissionController.injectLibraries",
+				"spec.features.admissionController.namespaceSelector.matchLabels",
+				"spec.features.admissionController.objectSelector.matchExpressions[0].values",
+			},
+		},
+		{
+			name: "override unknown component and container",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					"foo": {},
+					ClusterAgentComponentName: {
+						Containers: map[commonv1.AgentContainerName]*DatadogAgentGenericContainer{
+							commonv1.ClusterAgentContainerName: {},
+							commonv1.TraceAgentContainerName:   {},
+						},
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.override[clusterAgent].containers[trace-agent]",
+				"spec.override[foo]",
+			},
+		},
+		{
+			name: "custom config with both data and configmap",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					NodeAgentComponentName: {
+						CustomConfigurations: map[AgentConfigFileName]CustomConfig{
+							AgentGeneralConfigFile: {
+								ConfigData: apiutils.NewStringPointer("foo: bar"),
+								ConfigMap:  &commonv1.ConfigMapConfig{Name: "foo"},
+							},
+						},
+					},
+				}
+			},
+			wantFields: []string{"spec.override[nodeAgent].customConfigurations[datadog.yaml]"},
+		},
+		{
+			name: "update strategy types",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					NodeAgentComponentName:           {UpdateStrategy: &UpdateStrategy{Type: "Recreate"}},
+					ClusterAgentComponentName:        {UpdateStrategy: &UpdateStrategy{Type: "OnDelete"}},
+					ClusterChecksRunnerComponentName: {UpdateStrategy: &UpdateStrategy{Type: "Recreate"}},
+				}
+			},
+			wantFields: []string{
+				"spec.override[clusterAgent].updateStrategy.type",
+				"spec.override[nodeAgent].updateStrategy.type",
+			},
+		},
+		{
+			name: "pod disruption budget",
+			update: func(dda *DatadogAgent) {
+				minAvailable := intstr.FromInt(1)
+				maxUnavailable := intstr.FromInt(1)
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					NodeAgentComponentName: {PodDisruptionBudget: &PodDisruptionBudgetConfig{MinAvailable: &minAvailable}},
+					ClusterAgentComponentName: {PodDisruptionBudget: &PodDisruptionBudgetConfig{
+						MinAvailable:   &minAvailable,
+						MaxUnavailable: &maxUnavailable,
+					}},
+					ClusterChecksRunnerComponentName: {PodDisruptionBudget: &PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable}},
+				}
+			},
+			wantFields: []string{
+				"spec.override[clusterAgent].podDisruptionBudget",
+				"spec.override[nodeAgent].podDisruptionBudget",
+			},
+		},
+		{
+			name: "autoscaling on another component than the cluster checks runner",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					ClusterAgentComponentName: {Autoscaling: &AutoscalingConfig{Enabled: apiutils.NewBoolPointer(true)}},
+				}
+			},
+			wantFields: []string{"spec.override[clusterAgent].autoscaling"},
+		},
+		{
+			name: "autoscaling valid",
+			update: func(dda *DatadogAgent) {
+				target := resource.MustParse("10")
+				dda.Spec.Global.Credentials.AppKey = apiutils.NewStringPointer("0000000000000000000000")
+				dda.Spec.Features = &DatadogFeatures{
+					ExternalMetricsServer: &ExternalMetricsServerFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				}
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					ClusterChecksRunnerComponentName: {Autoscaling: &AutoscalingConfig{
+						Enabled:                        apiutils.NewBoolPointer(true),
+						MinReplicas:                    apiutils.NewInt32Pointer(2),
+						MaxReplicas:                    apiutils.NewInt32Pointer(5),
+						TargetCPUUtilizationPercentage: apiutils.NewInt32Pointer(70),
+						ExternalMetric: &AutoscalingExternalMetricConfig{
+							Name:               "datadogmetric@default:checks",
+							TargetAverageValue: &target,
+						},
+					}},
+				}
+			},
+		},
+		{
+			name: "autoscaling invalid",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{
+					ClusterChecksRunnerComponentName: {Autoscaling: &AutoscalingConfig{
+						Enabled:     apiutils.NewBoolPointer(true),
+						MinReplicas: apiutils.NewInt32Pointer(2),
+						ExternalMetric: &AutoscalingExternalMetricConfig{
+							Name: "datadogmetric@default:checks",
+						},
+					}},
+				}
+			},
+			wantFields: []string{
+				"spec.override[clusterChecksRunner].autoscaling.maxReplicas",
+				"spec.override[clusterChecksRunner].autoscaling.externalMetric",
+				"spec.override[clusterChecksRunner].autoscaling.externalMetric",
+			},
+		},
+		{
+			name: "profiles valid",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Profiles = []NodeAgentProfile{
+					{
+						Name: "gpu",
+						NodeSelectorRequirements: []corev1.NodeSelectorRequirement{
+							{Key: "nvidia.com/gpu.present", Operator: corev1.NodeSelectorOpExists},
+						},
+						DisabledFeatures: []string{"npm"},
+						Override: &DatadogAgentComponentOverride{
+							PriorityClassName: apiutils.NewStringPointer("gpu"),
+						},
+					},
+					{
+						Name: "big-memory",
+						NodeSelectorRequirements: []corev1.NodeSelectorRequirement{
+							{Key: "node.kubernetes.io/instance-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"r5.8xlarge"}},
+						},
+					},
+				}
+			},
+		},
+		{
+			name: "profiles invalid",
+			update: func(dda *DatadogAgent) {
+				dda.Spec.Profiles = []NodeAgentProfile{
+					{
+						Name: "gpu",
+						NodeSelectorRequirements: []corev1.NodeSelectorRequirement{
+							{Key: "nvidia.com/gpu.present", Operator: corev1.NodeSelectorOpExists, Values: []string{"true"}},
+							{Key: "nvidia.com/gpu.count", Operator: corev1.NodeSelectorOpGt, Values: []string{"1"}},
+						},
+						Override: &DatadogAgentComponentOverride{
+							Name:     apiutils.NewStringPointer("gpu-agent"),
+							Replicas: apiutils.NewInt32Pointer(-1),
+						},
+					},
+					{
+						Name: "gpu",
+						NodeSelectorRequirements: []corev1.NodeSelectorRequirement{
+							{Operator: corev1.NodeSelectorOpIn},
+						},
+						DisabledFeatures: []string{"apm", "network"},
+					},
+					{
+						Name: "Big_Memory",
+					},
+				}
+			},
+			wantFields: []string{
+				"spec.profiles[0].nodeSelectorRequirements[0].values",
+				"spec.profiles[0].nodeSelectorRequirements[1].operator",
+				"spec.profiles[0].override.name",
+				"spec.profiles[0].override.replicas",
+				"spec.profiles[1].name",
+				"spec.profiles[1].nodeSelectorRequirements[0].key",
+				"spec.profiles[1].nodeSelectorRequirements[0].values",
+				"spec.profiles[1].disabledFeatures[1]",
+				"spec.profiles[2].name",
+				"spec.profiles[2].nodeSelectorRequirements",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := newValidDatadogAgent()
+			tt.update(dda)
+
+			errs := ValidateDatadogAgent(dda)
+			gotFields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				gotFields = append(gotFields, err.Field)
+			}
+			assert.ElementsMatch(t, tt.wantFields, gotFields)
+		})
+	}
+}
+
+func TestGetDatadogAgentSpecWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		ac           *AdmissionControllerFeatureConfig
+		wantWarnings int
+	}{
+		{
+			name: "default admission controller",
+		},
+		{
+			name: "ignore failures to mutate unlabelled pods",
+			ac: &AdmissionControllerFeatureConfig{
+				MutateUnlabelled: apiutils.NewBoolPointer(true),
+				FailurePolicy:    apiutils.NewStringPointer("Ignore"),
+			},
+		},
+		{
+			name: "fail to mutate unlabelled pods",
+			ac: &AdmissionControllerFeatureConfig{
+				MutateUnlabelled: apiutils.NewBoolPointer(true),
+				FailurePolicy:    apiutils.NewStringPointer("Fail"),
+			},
+			wantWarnings: 1,
+		},
+		{
+			name: "fail to mutate the pods of an object selector",
+			ac: &AdmissionControllerFeatureConfig{
+				MutateUnlabelled: apiutils.NewBoolPointer(true),
+				ObjectSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				FailurePolicy:    apiutils.NewStringPointer("Fail"),
+			},
+		},
+		{
+			name: "fail to mutate all the pods with an empty object selector",
+			ac: &AdmissionControllerFeatureConfig{
+				ObjectSelector: &metav1.LabelSelector{},
+				FailurePolicy:  apiutils.NewStringPointer("Fail"),
+			},
+			wantWarnings: 1,
+		},
+		{
+			name: "admission controller disabled",
+			ac: &AdmissionControllerFeatureConfig{
+				Enabled:          apiutils.NewBoolPointer(false),
+				MutateUnlabelled: apiutils.NewBoolPointer(true),
+				FailurePolicy:    apiutils.NewStringPointer("Fail"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := newValidDatadogAgent()
+			dda.Spec.Features = &DatadogFeatures{AdmissionController: tt.ac}
+
+			// The warnings don't make the spec invalid.
+			assert.Empty(t, ValidateDatadogAgent(dda))
+			assert.Len(t, GetDatadogAgentSpecWarnings(dda), tt.wantWarnings)
+		})
+	}
+}
+
+func TestDatadogAgentValidateUpdate(t *testing.T) {
+	dda := newValidDatadogAgent()
+	dda.Spec.Global.Credentials = nil
+	assert.Error(t, dda.ValidateUpdate(nil))
+
+	// A DatadogAgent being deleted must not be blocked.
+	now := metav1.Now()
+	dda.DeletionTimestamp = &now
+	assert.NoError(t, dda.ValidateUpdate(nil))
+}
@@ -6,12 +6,59 @@
 package v2alpha1
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
-// SetupWebhookWithManager starts the conversion webhook
+// SetupWebhookWithManager starts the conversion, defaulting and validating webhooks
 func (r *DatadogAgent) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
+
+//+kubebuilder:webhook:path=/mutate-datadoghq-com-v2alpha1-datadogagent,mutating=true,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogagents,verbs=create;update,versions=v2alpha1,name=mdatadogagent.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &DatadogAgent{}
+
+// Default implements webhook.Defaulter, it persists the default values in the DatadogAgent spec.
+// It uses the same defaulting code as the reconciler, so the spec shows the effective configuration.
+func (r *DatadogAgent) Default() {
+	if r.DeletionTimestamp != nil {
+		return
+	}
+	DefaultDatadogAgentWithAnnotations(r)
+}
+
+//+kubebuilder:webhook:path=/validate-datadoghq-com-v2alpha1-datadogagent,mutating=false,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogagents,verbs=create;update,versions=v2alpha1,name=vdatadogagent.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DatadogAgent{}
+
+// ValidateCreate implements webhook.Validator, it rejects a DatadogAgent with an invalid spec.
+func (r *DatadogAgent) ValidateCreate() error {
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator, it rejects a DatadogAgent with an invalid spec.
+func (r *DatadogAgent) ValidateUpdate(old runtime.Object) error {
+	// Don't block the finalizer removal of a DatadogAgent that is being deleted.
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator, a DatadogAgent can always be deleted.
+func (r *DatadogAgent) ValidateDelete() error {
+	return nil
+}
+
+func (r *DatadogAgent) validate() error {
+	errs := ValidateDatadogAgent(r)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("DatadogAgent").GroupKind(), r.Name, errs)
+}
@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func newInvalidDatadogAgent() *DatadogAgent {
+	dda := newValidDatadogAgent()
+	dda.Spec.Global.Credentials = nil
+	return dda
+}
+
+func TestDatadogAgent_Default(t *testing.T) {
+	dda := newValidDatadogAgent()
+	dda.Default()
+
+	assert.Equal(t, defaultSite, apiutils.StringValue(dda.Spec.Global.Site))
+	assert.Equal(t, "1", dda.Annotations[apicommon.DefaultsVersionAnnotationKey])
+
+	// A DatadogAgent being deleted is left unchanged.
+	deleted := newValidDatadogAgent()
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	deleted.Default()
+
+	assert.Nil(t, deleted.Spec.Global.Site)
+	assert.Empty(t, deleted.Annotations)
+}
+
+func TestDatadogAgent_ValidateCreate(t *testing.T) {
+	assert.NoError(t, newValidDatadogAgent().ValidateCreate())
+
+	err := newInvalidDatadogAgent().ValidateCreate()
+	assert.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+}
+
+func TestDatadogAgent_ValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dda     *DatadogAgent
+		wantErr bool
+	}{
+		{
+			name: "valid spec",
+			dda:  newValidDatadogAgent(),
+		},
+		{
+			name:    "invalid spec",
+			dda:     newInvalidDatadogAgent(),
+			wantErr: true,
+		},
+		{
+			name: "invalid spec being deleted",
+			dda: func() *DatadogAgent {
+				dda := newInvalidDatadogAgent()
+				now := metav1.Now()
+				dda.DeletionTimestamp = &now
+				return dda
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dda.ValidateUpdate(newValidDatadogAgent())
+			if tt.wantErr {
+				assert.True(t, apierrors.IsInvalid(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDatadogAgent_ValidateDelete(t *testing.T) {
+	assert.NoError(t, newInvalidDatadogAgent().ValidateDelete())
+}
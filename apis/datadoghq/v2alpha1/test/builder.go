@@ -364,6 +364,26 @@ func (builder *DatadogAgentBuilder) WithAPMUDSEnabled(enabled bool, apmSocketHos
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithAPMSingleStepInstrumentation(ssi *v2alpha1.SingleStepInstrumentation) *DatadogAgentBuilder {
+	builder.initAPM()
+	builder.datadogAgent.Spec.Features.APM.SingleStepInstrumentation = ssi
+	return builder
+}
+
+// Admission Controller
+
+func (builder *DatadogAgentBuilder) initAdmissionController() {
+	if builder.datadogAgent.Spec.Features.AdmissionController == nil {
+		builder.datadogAgent.Spec.Features.AdmissionController = &v2alpha1.AdmissionControllerFeatureConfig{}
+	}
+}
+
+func (builder *DatadogAgentBuilder) WithAdmissionControllerEnabled(enabled bool) *DatadogAgentBuilder {
+	builder.initAdmissionController()
+	builder.datadogAgent.Spec.Features.AdmissionController.Enabled = apiutils.NewBoolPointer(enabled)
+	return builder
+}
+
 // OTLP
 
 func (builder *DatadogAgentBuilder) initOTLP() {
@@ -470,6 +490,34 @@ func (builder *DatadogAgentBuilder) WithGlobalCriSocketPath(criSocketPath string
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithGlobalProxy(http string, httpsSecret *common.SecretConfig, noProxy []string) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.Proxy = &v2alpha1.ProxyConfig{
+		HTTP:        apiutils.NewStringPointer(http),
+		HTTPSSecret: httpsSecret,
+		NoProxy:     noProxy,
+	}
+	return builder
+}
+
+// Global Registry and ImagePolicy
+
+func (builder *DatadogAgentBuilder) WithGlobalRegistry(registry string) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.Registry = apiutils.NewStringPointer(registry)
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithGlobalImagePolicy(imagePolicy *v2alpha1.ImagePolicyConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.ImagePolicy = imagePolicy
+	return builder
+}
+
+// Global SecretBackend
+
+func (builder *DatadogAgentBuilder) WithGlobalSecretBackend(secretBackend *v2alpha1.SecretBackendConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.SecretBackend = secretBackend
+	return builder
+}
+
 // Global ContainerStrategy
 
 func (builder *DatadogAgentBuilder) WithSingleContainerStrategy(enabled bool) *DatadogAgentBuilder {
@@ -503,3 +551,10 @@ func (builder *DatadogAgentBuilder) WithComponentOverride(componentName v2alpha1
 	builder.datadogAgent.Spec.Override[componentName] = &v2alpha1.DatadogAgentComponentOverride{}
 	return builder
 }
+
+// Profiles
+
+func (builder *DatadogAgentBuilder) WithProfiles(profiles ...v2alpha1.NodeAgentProfile) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Profiles = append(builder.datadogAgent.Spec.Profiles, profiles...)
+	return builder
+}
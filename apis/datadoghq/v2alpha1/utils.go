@@ -89,6 +89,15 @@ func IsCCREnabled(dda *DatadogAgent) bool {
 	return dda.Spec.Features.ClusterChecks != nil && apiutils.BoolValue(dda.Spec.Features.ClusterChecks.UseClusterChecksRunners)
 }
 
+// IsSingleStepInstrumentationEnabled returns whether the APM libraries are injected, in all or in some namespaces
+func IsSingleStepInstrumentationEnabled(apm *APMFeatureConfig) bool {
+	if apm == nil || apm.SingleStepInstrumentation == nil {
+		return false
+	}
+	ssi := apm.SingleStepInstrumentation
+	return apiutils.BoolValue(ssi.Enabled) || len(ssi.EnabledNamespaces) > 0
+}
+
 // GetLocalAgentServiceName returns the name used for the local agent service
 func GetLocalAgentServiceName(dda *DatadogAgent) string {
 	if dda.Spec.Global.LocalService != nil && dda.Spec.Global.LocalService.NameOverride != nil {
@@ -12,9 +12,11 @@ package v2alpha1
 
 import (
 	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	apiv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -35,6 +37,11 @@ func (in *APMFeatureConfig) DeepCopyInto(out *APMFeatureConfig) {
 		*out = new(UnixDomainSocketConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SingleStepInstrumentation != nil {
+		in, out := &in.SingleStepInstrumentation, &out.SingleStepInstrumentation
+		*out = new(SingleStepInstrumentation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APMFeatureConfig.
@@ -75,11 +82,41 @@ func (in *AdmissionControllerFeatureConfig) DeepCopyInto(out *AdmissionControlle
 		*out = new(string)
 		**out = **in
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.WebhookName != nil {
 		in, out := &in.WebhookName, &out.WebhookName
 		*out = new(string)
 		**out = **in
 	}
+	if in.InjectTags != nil {
+		in, out := &in.InjectTags, &out.InjectTags
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InjectConfig != nil {
+		in, out := &in.InjectConfig, &out.InjectConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InjectLibraries != nil {
+		in, out := &in.InjectLibraries, &out.InjectLibraries
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllerFeatureConfig.
@@ -92,6 +129,83 @@ func (in *AdmissionControllerFeatureConfig) DeepCopy() *AdmissionControllerFeatu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingConfig) DeepCopyInto(out *AutoscalingConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExternalMetric != nil {
+		in, out := &in.ExternalMetric, &out.ExternalMetric
+		*out = new(AutoscalingExternalMetricConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingConfig.
+func (in *AutoscalingConfig) DeepCopy() *AutoscalingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingExternalMetricConfig) DeepCopyInto(out *AutoscalingExternalMetricConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TargetValue != nil {
+		in, out := &in.TargetValue, &out.TargetValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TargetAverageValue != nil {
+		in, out := &in.TargetAverageValue, &out.TargetAverageValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingExternalMetricConfig.
+func (in *AutoscalingExternalMetricConfig) DeepCopy() *AutoscalingExternalMetricConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingExternalMetricConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CSPMFeatureConfig) DeepCopyInto(out *CSPMFeatureConfig) {
 	*out = *in
@@ -342,6 +456,21 @@ func (in *DatadogAgentComponentOverride) DeepCopyInto(out *DatadogAgentComponent
 		*out = new(int32)
 		**out = **in
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CreateRbac != nil {
 		in, out := &in.CreateRbac, &out.CreateRbac
 		*out = new(bool)
@@ -617,6 +746,13 @@ func (in *DatadogAgentSpec) DeepCopyInto(out *DatadogAgentSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]NodeAgentProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentSpec.
@@ -1025,6 +1161,21 @@ func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 		*out = new(Endpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretBackend != nil {
+		in, out := &in.SecretBackend, &out.SecretBackend
+		*out = new(SecretBackendConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(string)
@@ -1135,6 +1286,63 @@ func (in *HostPortConfig) DeepCopy() *HostPortConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageManifest) DeepCopyInto(out *ImageManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageManifest.
+func (in *ImageManifest) DeepCopy() *ImageManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyConfig) DeepCopyInto(out *ImagePolicyConfig) {
+	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make(map[ComponentName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.JMXEnabled != nil {
+		in, out := &in.JMXEnabled, &out.JMXEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FIPSEnabled != nil {
+		in, out := &in.FIPSEnabled, &out.FIPSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]ImageManifest, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyConfig.
+func (in *ImagePolicyConfig) DeepCopy() *ImagePolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeStateMetricsCoreFeatureConfig) DeepCopyInto(out *KubeStateMetricsCoreFeatureConfig) {
 	*out = *in
@@ -1374,6 +1582,38 @@ func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAgentProfile) DeepCopyInto(out *NodeAgentProfile) {
+	*out = *in
+	if in.NodeSelectorRequirements != nil {
+		in, out := &in.NodeSelectorRequirements, &out.NodeSelectorRequirements
+		*out = make([]corev1.NodeSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DisabledFeatures != nil {
+		in, out := &in.DisabledFeatures, &out.DisabledFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(DatadogAgentComponentOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAgentProfile.
+func (in *NodeAgentProfile) DeepCopy() *NodeAgentProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAgentProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OOMKillFeatureConfig) DeepCopyInto(out *OOMKillFeatureConfig) {
 	*out = *in
@@ -1546,6 +1786,31 @@ func (in *OrchestratorExplorerFeatureConfig) DeepCopy() *OrchestratorExplorerFea
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessDiscoveryFeatureConfig) DeepCopyInto(out *ProcessDiscoveryFeatureConfig) {
 	*out = *in
@@ -1601,6 +1866,46 @@ func (in *PrometheusScrapeFeatureConfig) DeepCopy() *PrometheusScrapeFeatureConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSecret != nil {
+		in, out := &in.HTTPSecret, &out.HTTPSecret
+		*out = new(commonv1.SecretConfig)
+		**out = **in
+	}
+	if in.HTTPS != nil {
+		in, out := &in.HTTPS, &out.HTTPS
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSSecret != nil {
+		in, out := &in.HTTPSSecret, &out.HTTPSSecret
+		*out = new(commonv1.SecretConfig)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteConfigurationFeatureConfig) DeepCopyInto(out *RemoteConfigurationFeatureConfig) {
 	*out = *in
@@ -1621,6 +1926,51 @@ func (in *RemoteConfigurationFeatureConfig) DeepCopy() *RemoteConfigurationFeatu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxPodSchedulerFailure != nil {
+		in, out := &in.MaxPodSchedulerFailure, &out.MaxPodSchedulerFailure
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxParallelPodCreation != nil {
+		in, out := &in.MaxParallelPodCreation, &out.MaxParallelPodCreation
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SlowStartIntervalDuration != nil {
+		in, out := &in.SlowStartIntervalDuration, &out.SlowStartIntervalDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SlowStartAdditiveIncrease != nil {
+		in, out := &in.SlowStartAdditiveIncrease, &out.SlowStartAdditiveIncrease
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdate.
+func (in *RollingUpdate) DeepCopy() *RollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SBOMFeatureConfig) DeepCopyInto(out *SBOMFeatureConfig) {
 	*out = *in
@@ -1714,6 +2064,27 @@ func (in *SecretBackendConfig) DeepCopyInto(out *SecretBackendConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableGlobalPermissions != nil {
+		in, out := &in.EnableGlobalPermissions, &out.EnableGlobalPermissions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]*SecretBackendRolesConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SecretBackendRolesConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretBackendConfig.
@@ -1726,6 +2097,68 @@ func (in *SecretBackendConfig) DeepCopy() *SecretBackendConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretBackendRolesConfig) DeepCopyInto(out *SecretBackendRolesConfig) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretBackendRolesConfig.
+func (in *SecretBackendRolesConfig) DeepCopy() *SecretBackendRolesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretBackendRolesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleStepInstrumentation) DeepCopyInto(out *SingleStepInstrumentation) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnabledNamespaces != nil {
+		in, out := &in.EnabledNamespaces, &out.EnabledNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisabledNamespaces != nil {
+		in, out := &in.DisabledNamespaces, &out.DisabledNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LibVersions != nil {
+		in, out := &in.LibVersions, &out.LibVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleStepInstrumentation.
+func (in *SingleStepInstrumentation) DeepCopy() *SingleStepInstrumentation {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleStepInstrumentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPQueueLengthFeatureConfig) DeepCopyInto(out *TCPQueueLengthFeatureConfig) {
 	*out = *in
@@ -1790,3 +2223,33 @@ func (in *UnixDomainSocketConfig) DeepCopy() *UnixDomainSocketConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(apiv1alpha1.ExtendedDaemonSetSpecStrategyCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileFrequency != nil {
+		in, out := &in.ReconcileFrequency, &out.ReconcileFrequency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategy.
+func (in *UpdateStrategy) DeepCopy() *UpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
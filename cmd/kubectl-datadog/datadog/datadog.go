@@ -8,9 +8,12 @@ package datadog
 import (
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/agent"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/clusteragent/clusteragent"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/diff"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/flare"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/get"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/metrics"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/monitor"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/render"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/validate/validate"
 
 	"github.com/spf13/cobra"
@@ -41,6 +44,8 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.AddCommand(get.New(streams))
 	cmd.AddCommand(flare.New(streams))
 	cmd.AddCommand(validate.New(streams))
+	cmd.AddCommand(render.New(streams))
+	cmd.AddCommand(diff.New(streams))
 
 	// Agent commands
 	cmd.AddCommand(agent.New(streams))
@@ -51,6 +56,9 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	// DatadogMetric commands
 	cmd.AddCommand(metrics.New(streams))
 
+	// DatadogMonitor commands
+	cmd.AddCommand(monitor.New(streams))
+
 	o := newOptions(streams)
 	o.configFlags.AddFlags(cmd.Flags())
 
@@ -0,0 +1,194 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/render"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var diffExample = `
+  # preview the changes the operator would apply for the DatadogAgent foo
+  %[1]s diff foo
+
+  # preview the changes of a locally edited DatadogAgent
+  %[1]s diff -f datadog-agent.yaml
+`
+
+// options provides information required by the diff command
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                 []string
+	userDatadogAgentName string
+	file                 string
+	extendedDaemonSet    bool
+	supportCilium        bool
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "diff" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "diff [DatadogAgent name] [-f DatadogAgent manifest]",
+		Short:        "Preview the changes the operator would apply to the cluster for a DatadogAgent",
+		Example:      fmt.Sprintf(diffExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", "Path of the DatadogAgent manifest, - to read it from stdin")
+	cmd.Flags().BoolVar(&o.extendedDaemonSet, "extended-daemonset", false, "The operator deploys the node Agent with an ExtendedDaemonSet")
+	cmd.Flags().BoolVar(&o.supportCilium, "cilium", false, "The operator manages the Cilium network policies")
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.userDatadogAgentName = args[0]
+	}
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if len(o.args) > 1 {
+		return errors.New("either one or no arguments are allowed")
+	}
+	if o.userDatadogAgentName == "" && o.file == "" {
+		return errors.New("the DatadogAgent name or manifest is required")
+	}
+	return nil
+}
+
+// run runs the diff command
+func (o *options) run() error {
+	ctx := context.TODO()
+	dda, err := o.getDatadogAgent(ctx)
+	if err != nil {
+		return err
+	}
+
+	platformInfo, err := o.getPlatformInfo()
+	if err != nil {
+		return err
+	}
+	nodeList := &corev1.NodeList{}
+	if err = o.Client.List(ctx, nodeList); err != nil {
+		return fmt.Errorf("unable to list nodes: %w", err)
+	}
+
+	renderOptions := datadogagent.RenderOptions{
+		PlatformInfo: platformInfo,
+		Nodes:        nodeList.Items,
+		Logger:       logr.Discard(),
+	}
+	renderOptions.ReconcilerOptions.ExtendedDaemonsetOptions.Enabled = o.extendedDaemonSet
+	renderOptions.ReconcilerOptions.SupportCilium = o.supportCilium
+
+	diffs, err := datadogagent.Diff(ctx, o.Client, dda, renderOptions)
+	if err != nil {
+		return err
+	}
+	return WriteDiffs(o.Out, diffs)
+}
+
+// getDatadogAgent returns the DatadogAgent from the manifest, or from the cluster
+func (o *options) getDatadogAgent(ctx context.Context) (*v2alpha1.DatadogAgent, error) {
+	if o.file != "" {
+		dda, err := render.ReadDatadogAgent(o.file, o.In)
+		if err != nil {
+			return nil, err
+		}
+		if dda.Namespace == "" {
+			dda.Namespace = o.UserNamespace
+		}
+		if o.userDatadogAgentName != "" && o.userDatadogAgentName != dda.Name {
+			return nil, fmt.Errorf("the manifest describes the DatadogAgent %s, not %s", dda.Name, o.userDatadogAgentName)
+		}
+		return dda, nil
+	}
+
+	dda := &v2alpha1.DatadogAgent{}
+	err := o.Client.Get(ctx, client.ObjectKey{Namespace: o.UserNamespace, Name: o.userDatadogAgentName}, dda)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("DatadogAgent %s/%s not found", o.UserNamespace, o.userDatadogAgentName)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get DatadogAgent: %w", err)
+	}
+	return dda, nil
+}
+
+// getPlatformInfo returns the description of the cluster used by the operator
+func (o *options) getPlatformInfo() (kubernetes.PlatformInfo, error) {
+	versionInfo, err := o.Clientset.Discovery().ServerVersion()
+	if err != nil {
+		return kubernetes.PlatformInfo{}, fmt.Errorf("unable to get the Kubernetes version: %w", err)
+	}
+	groups, resources, err := o.Clientset.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return kubernetes.PlatformInfo{}, fmt.Errorf("unable to get the server resources: %w", err)
+	}
+	return kubernetes.NewPlatformInfo(versionInfo, groups, resources), nil
+}
+
+// WriteDiffs writes one line per changed object, followed by the diff of the updated objects
+func WriteDiffs(out io.Writer, diffs []datadogagent.ObjectDiff) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(out, "No changes")
+		return err
+	}
+	for _, d := range diffs {
+		name := d.Name
+		if d.Namespace != "" {
+			name = d.Namespace + "/" + d.Name
+		}
+		if _, err := fmt.Fprintf(out, "%s %s %s\n", d.Action, d.Kind, name); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, d.Diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent"
+)
+
+func TestWriteDiffs(t *testing.T) {
+	tests := []struct {
+		name  string
+		diffs []datadogagent.ObjectDiff
+		want  string
+	}{
+		{
+			name: "no changes",
+			want: "No changes\n",
+		},
+		{
+			name: "changes",
+			diffs: []datadogagent.ObjectDiff{
+				{Action: datadogagent.DiffActionCreate, Kind: "ClusterRole", Name: "foo-agent"},
+				{Action: datadogagent.DiffActionUpdate, Kind: "Service", Namespace: "default", Name: "foo-cluster-agent", Diff: "--- live\n+++ desired\n"},
+				{Action: datadogagent.DiffActionDelete, Kind: "DaemonSet", Namespace: "default", Name: "foo-agent-gke-cos"},
+			},
+			want: "create ClusterRole foo-agent\n" +
+				"update Service default/foo-cluster-agent\n--- live\n+++ desired\n" +
+				"delete DaemonSet default/foo-agent-gke-cos\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, WriteDiffs(out, tt.diffs))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
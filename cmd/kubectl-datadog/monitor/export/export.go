@@ -0,0 +1,247 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/render"
+	"github.com/DataDog/datadog-operator/controllers/datadogmonitor"
+	"github.com/DataDog/datadog-operator/pkg/config"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pageSize = 100
+	// maxNameLength keeps the generated names, suffixed with the monitor ID, readable and below the 253 characters limit
+	maxNameLength = 50
+)
+
+var exportExample = `
+  # export the monitors tagged team:foo as DatadogMonitors in the namespace monitoring
+  %[1]s monitor export --tags team:foo -n monitoring > monitors.yaml
+
+  # export the monitors matching a monitor search query
+  %[1]s monitor export --query "type:metric status:alert"
+`
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// options provides information required by the export command
+type options struct {
+	genericclioptions.IOStreams
+	configFlags *genericclioptions.ConfigFlags
+	namespace   string
+	tags        string
+	name        string
+	query       string
+	apiKey      string
+	appKey      string
+	site        string
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(false),
+		IOStreams:   streams,
+	}
+}
+
+// New provides a cobra command wrapping options for "export" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "export [flags]",
+		Short:        "Export existing Datadog monitors as DatadogMonitor manifests adopting them",
+		Example:      fmt.Sprintf(exportExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.tags, "tags", "", "Comma separated list of monitor tags, only the monitors having all of them are exported")
+	cmd.Flags().StringVar(&o.name, "name", "", "Only export the monitors whose name contains this string")
+	cmd.Flags().StringVar(&o.query, "query", "", "Monitor search query, as used in the Manage Monitors page, selecting the monitors to export")
+	cmd.Flags().StringVar(&o.apiKey, "api-key", "", fmt.Sprintf("Datadog API key, defaults to the %s environment variable", config.DDAPIKeyEnvVar))
+	cmd.Flags().StringVar(&o.appKey, "app-key", "", fmt.Sprintf("Datadog application key, defaults to the %s environment variable", config.DDAppKeyEnvVar))
+	cmd.Flags().StringVar(&o.site, "site", "", fmt.Sprintf("Datadog site, for example datadoghq.eu, defaults to the %s environment variable", apicommon.DDSite))
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed")
+	}
+	if o.apiKey == "" {
+		o.apiKey = os.Getenv(config.DDAPIKeyEnvVar)
+	}
+	if o.appKey == "" {
+		o.appKey = os.Getenv(config.DDAppKeyEnvVar)
+	}
+
+	namespace, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.namespace = namespace
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if o.apiKey == "" || o.appKey == "" {
+		return fmt.Errorf("the Datadog API and application keys are required, use --api-key and --app-key or %s and %s", config.DDAPIKeyEnvVar, config.DDAppKeyEnvVar)
+	}
+	if o.query != "" && (o.tags != "" || o.name != "") {
+		return errors.New("--query can't be used with --tags or --name")
+	}
+	return nil
+}
+
+// run runs the export command
+func (o *options) run() error {
+	ddClient, err := datadogclient.InitDatadogMonitorClient(logr.Discard(), config.Creds{APIKey: o.apiKey, AppKey: o.appKey, Site: o.site})
+	if err != nil {
+		return err
+	}
+
+	var monitors []datadogV1.Monitor
+	if o.query != "" {
+		monitors, err = searchMonitors(ddClient, o.query)
+	} else {
+		monitors, err = listMonitors(ddClient, o.tags, o.name)
+	}
+	if err != nil {
+		return err
+	}
+
+	objs := make([]client.Object, 0, len(monitors))
+	for _, m := range monitors {
+		dm, err := NewDatadogMonitor(m, o.namespace)
+		if err != nil {
+			fmt.Fprintf(o.ErrOut, "Skipping monitor %d: %v\n", m.GetId(), err)
+			continue
+		}
+		objs = append(objs, dm)
+	}
+	if len(objs) == 0 {
+		fmt.Fprintln(o.ErrOut, "No monitors to export")
+		return nil
+	}
+	return render.WriteObjects(o.Out, objs)
+}
+
+// listMonitors returns the monitors having all the tags and whose name contains name
+func listMonitors(ddClient datadogclient.DatadogMonitorClient, tags, name string) ([]datadogV1.Monitor, error) {
+	var monitors []datadogV1.Monitor
+	params := datadogV1.NewListMonitorsOptionalParameters().WithPageSize(pageSize)
+	if tags != "" {
+		params.WithMonitorTags(tags)
+	}
+	if name != "" {
+		params.WithName(name)
+	}
+	for page := int64(0); ; page++ {
+		result, _, err := ddClient.Client.ListMonitors(ddClient.Auth, *params.WithPage(page))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the monitors: %w", err)
+		}
+		monitors = append(monitors, result...)
+		if len(result) < pageSize {
+			return monitors, nil
+		}
+	}
+}
+
+// searchMonitors returns the monitors matching the search query. The search results don't contain the monitor
+// options, the monitors are retrieved one by one.
+func searchMonitors(ddClient datadogclient.DatadogMonitorClient, query string) ([]datadogV1.Monitor, error) {
+	var monitors []datadogV1.Monitor
+	params := datadogV1.NewSearchMonitorsOptionalParameters().WithQuery(query).WithPerPage(pageSize)
+	for page := int64(0); ; page++ {
+		result, _, err := ddClient.Client.SearchMonitors(ddClient.Auth, *params.WithPage(page))
+		if err != nil {
+			return nil, fmt.Errorf("unable to search the monitors: %w", err)
+		}
+		for _, r := range result.GetMonitors() {
+			m, _, err := ddClient.Client.GetMonitor(ddClient.Auth, r.GetId())
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the monitor %d: %w", r.GetId(), err)
+			}
+			monitors = append(monitors, m)
+		}
+		metadata := result.GetMetadata()
+		if page+1 >= metadata.GetPageCount() {
+			return monitors, nil
+		}
+	}
+}
+
+// NewDatadogMonitor returns a DatadogMonitor adopting an existing monitor, it returns an error if the
+// DatadogMonitor controller doesn't support the monitor type
+func NewDatadogMonitor(m datadogV1.Monitor, namespace string) (*v1alpha1.DatadogMonitor, error) {
+	spec := datadogmonitor.BuildDatadogMonitorSpec(m)
+	if !datadogmonitor.IsSupportedMonitorType(spec.Type) {
+		return nil, fmt.Errorf("monitor type %s not supported", spec.Type)
+	}
+	if err := v1alpha1.IsValidDatadogMonitor(&spec); err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.DatadogMonitor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "DatadogMonitor",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      monitorResourceName(m),
+			Namespace: namespace,
+			Annotations: map[string]string{
+				v1alpha1.DatadogMonitorIDAnnotationKey: strconv.FormatInt(m.GetId(), 10),
+			},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// monitorResourceName returns a valid resource name derived from the monitor name, suffixed with the monitor ID
+// to keep the names unique
+func monitorResourceName(m datadogV1.Monitor) string {
+	name := invalidNameCharacters.ReplaceAllString(strings.ToLower(m.GetName()), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = "monitor"
+	}
+	return fmt.Sprintf("%s-%d", name, m.GetId())
+}
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"testing"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDatadogMonitor(t *testing.T) {
+	tests := []struct {
+		name     string
+		monitor  datadogV1.Monitor
+		wantName string
+		wantErr  string
+	}{
+		{
+			name: "metric monitor",
+			monitor: datadogV1.Monitor{
+				Id:      datadogapi.PtrInt64(12345),
+				Name:    datadogapi.PtrString("[Prod] Disk usage is high on {{host.name}}"),
+				Message: datadogapi.PtrString("disk usage is high"),
+				Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.9",
+				Type:    datadogV1.MONITORTYPE_METRIC_ALERT,
+			},
+			wantName: "prod-disk-usage-is-high-on-host-name-12345",
+		},
+		{
+			name: "long name",
+			monitor: datadogV1.Monitor{
+				Id:      datadogapi.PtrInt64(12345),
+				Name:    datadogapi.PtrString("The average disk usage of the hosts of the production cluster is too high"),
+				Message: datadogapi.PtrString("disk usage is high"),
+				Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.9",
+				Type:    datadogV1.MONITORTYPE_METRIC_ALERT,
+			},
+			wantName: "the-average-disk-usage-of-the-hosts-of-the-product-12345",
+		},
+		{
+			name: "name without valid characters",
+			monitor: datadogV1.Monitor{
+				Id:      datadogapi.PtrInt64(12345),
+				Name:    datadogapi.PtrString("!!!"),
+				Message: datadogapi.PtrString("disk usage is high"),
+				Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.9",
+				Type:    datadogV1.MONITORTYPE_METRIC_ALERT,
+			},
+			wantName: "monitor-12345",
+		},
+		{
+			name: "unsupported monitor type",
+			monitor: datadogV1.Monitor{
+				Id:      datadogapi.PtrInt64(12345),
+				Name:    datadogapi.PtrString("synthetics"),
+				Message: datadogapi.PtrString("check failed"),
+				Query:   `"synthetics.http.check".over("*").last(2).count_by_status()`,
+				Type:    datadogV1.MONITORTYPE_SYNTHETICS_ALERT,
+			},
+			wantErr: "monitor type synthetics alert not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm, err := NewDatadogMonitor(tt.monitor, "monitoring")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, dm.Name)
+			assert.Equal(t, "monitoring", dm.Namespace)
+			assert.Equal(t, "DatadogMonitor", dm.Kind)
+			assert.Equal(t, "12345", dm.Annotations[v1alpha1.DatadogMonitorIDAnnotationKey])
+			assert.Equal(t, tt.monitor.Query, dm.Spec.Query)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultKubernetesVersion is the Kubernetes version used when none is provided
+const DefaultKubernetesVersion = "v1.25.0"
+
+var renderExample = `
+  # render the manifests of the DatadogAgent defined in datadog-agent.yaml
+  %[1]s render -f datadog-agent.yaml
+
+  # render the manifests for a Kubernetes 1.21 cluster, using ExtendedDaemonSets
+  %[1]s render -f datadog-agent.yaml --kube-version v1.21.14 --extended-daemonset
+`
+
+// options provides information required by the render command
+type options struct {
+	genericclioptions.IOStreams
+	Flags
+}
+
+// Flags contains the flags describing the DatadogAgent to render and the target cluster
+type Flags struct {
+	File              string
+	KubeVersion       string
+	ExtendedDaemonSet bool
+	SupportCilium     bool
+}
+
+// AddFlags adds the render flags to a command
+func (f *Flags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.File, "file", "f", "", "Path of the DatadogAgent manifest, - to read it from stdin")
+	cmd.Flags().StringVar(&f.KubeVersion, "kube-version", DefaultKubernetesVersion, "Kubernetes version of the target cluster")
+	cmd.Flags().BoolVar(&f.ExtendedDaemonSet, "extended-daemonset", false, "Deploy the node Agent with an ExtendedDaemonSet")
+	cmd.Flags().BoolVar(&f.SupportCilium, "cilium", false, "Render the Cilium network policies")
+}
+
+// Validate ensures that all required flag values are provided
+func (f *Flags) Validate() error {
+	if f.File == "" {
+		return errors.New("the DatadogAgent manifest is required, use --file")
+	}
+	return nil
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		IOStreams: streams,
+	}
+}
+
+// New provides a cobra command wrapping options for "render" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "render -f [DatadogAgent manifest]",
+		Short:        "Render the manifests the operator creates for a DatadogAgent, without a cluster",
+		Example:      fmt.Sprintf(renderExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return errors.New("no arguments are allowed")
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+// run runs the render command
+func (o *options) run() error {
+	objs, err := o.Render(context.TODO(), o.In)
+	if err != nil {
+		return err
+	}
+	return WriteObjects(o.Out, objs)
+}
+
+// Render reads the DatadogAgent manifest and returns the objects the operator creates for it
+func (f *Flags) Render(ctx context.Context, stdin io.Reader) ([]client.Object, error) {
+	dda, err := ReadDatadogAgent(f.File, stdin)
+	if err != nil {
+		return nil, err
+	}
+	platformInfo, err := kubernetes.NewPlatformInfoFromVersion(f.KubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	renderOptions := datadogagent.RenderOptions{
+		PlatformInfo: platformInfo,
+		Logger:       logr.Discard(),
+	}
+	renderOptions.ReconcilerOptions.ExtendedDaemonsetOptions.Enabled = f.ExtendedDaemonSet
+	renderOptions.ReconcilerOptions.SupportCilium = f.SupportCilium
+
+	return datadogagent.Render(ctx, dda, renderOptions)
+}
+
+// ReadDatadogAgent reads a v2alpha1 DatadogAgent manifest, from stdin if path is -
+func ReadDatadogAgent(path string, stdin io.Reader) (*v2alpha1.DatadogAgent, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the DatadogAgent manifest: %w", err)
+	}
+
+	dda := &v2alpha1.DatadogAgent{}
+	if err = yaml.UnmarshalStrict(data, dda); err != nil {
+		return nil, fmt.Errorf("unable to parse the DatadogAgent manifest: %w", err)
+	}
+	if dda.APIVersion != v2alpha1.GroupVersion.String() || dda.Kind != "DatadogAgent" {
+		return nil, fmt.Errorf("the manifest must contain a %s DatadogAgent, got %s %s", v2alpha1.GroupVersion.String(), dda.APIVersion, dda.Kind)
+	}
+	return dda, nil
+}
+
+// WriteObjects writes the objects as a multi-document YAML stream
+func WriteObjects(out io.Writer, objs []client.Object) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("unable to marshal %s %s/%s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		if _, err = fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+  namespace: monitoring
+spec:
+  global:
+    credentials:
+      apiKey: "0000000000000000000000"
+`
+
+func TestReadDatadogAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "valid manifest",
+			manifest: testManifest,
+		},
+		{
+			name:     "v1alpha1 manifest",
+			manifest: strings.Replace(testManifest, "v2alpha1", "v1alpha1", 1),
+			wantErr:  "must contain a datadoghq.com/v2alpha1 DatadogAgent",
+		},
+		{
+			name:     "unknown field",
+			manifest: testManifest + "  foo: bar\n",
+			wantErr:  "unable to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda, err := ReadDatadogAgent("-", strings.NewReader(tt.manifest))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "monitoring", dda.Namespace)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	flags := Flags{File: "-", KubeVersion: DefaultKubernetesVersion}
+	objs, err := flags.Render(context.TODO(), strings.NewReader(testManifest))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, WriteObjects(out, objs))
+	assert.Contains(t, out.String(), "---\napiVersion: apps/v1\nkind: DaemonSet\n")
+	assert.True(t, strings.HasPrefix(out.String(), "---\n"))
+}
@@ -195,6 +195,16 @@ func GetCCRRbacResourcesName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.DefaultClusterChecksRunnerResourceSuffix)
 }
 
+// GetClusterChecksRunnerPodDisruptionBudgetName returns the Cluster Checks Runner PodDisruptionBudget name
+func GetClusterChecksRunnerPodDisruptionBudgetName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.DefaultClusterChecksRunnerResourceSuffix)
+}
+
+// GetClusterChecksRunnerHorizontalPodAutoscalerName returns the Cluster Checks Runner HorizontalPodAutoscaler name
+func GetClusterChecksRunnerHorizontalPodAutoscalerName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.DefaultClusterChecksRunnerResourceSuffix)
+}
+
 func clusterChecksRunnerImage() string {
 	return fmt.Sprintf("%s/%s:%s", apicommon.DefaultImageRegistry, apicommon.DefaultAgentImageName, defaulting.AgentLatestVersion)
 }
@@ -7,6 +7,8 @@ package component
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -394,6 +396,11 @@ func GetDefaultSeccompConfigMapName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.SystemProbeAgentSecurityConfigMapSuffixName)
 }
 
+// GetSecretBackendRoleName returns the name of the Roles and ClusterRole granting the secret backend read access to Secrets
+func GetSecretBackendRoleName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), "secret-reader")
+}
+
 // BuildEnvVarFromSource return an *corev1.EnvVar from a Env Var name and *corev1.EnvVarSource
 func BuildEnvVarFromSource(name string, source *corev1.EnvVarSource) *corev1.EnvVar {
 	return &corev1.EnvVar{
@@ -415,7 +422,7 @@ func BuildEnvVarFromSecret(name, key string) *corev1.EnvVarSource {
 }
 
 // BuildKubernetesNetworkPolicy creates the base node agent kubernetes network policy
-func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.ComponentName) (string, string, metav1.LabelSelector, []netv1.PolicyType, []netv1.NetworkPolicyIngressRule, []netv1.NetworkPolicyEgressRule) {
+func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.ComponentName, proxy *v2alpha1.ProxyConfig) (string, string, metav1.LabelSelector, []netv1.PolicyType, []netv1.NetworkPolicyIngressRule, []netv1.NetworkPolicyEgressRule) {
 	policyName, podSelector := GetNetworkPolicyMetadata(dda, componentName)
 	ddaNamespace := dda.GetNamespace()
 
@@ -517,9 +524,83 @@ func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.Comp
 		ingress = []netv1.NetworkPolicyIngressRule{}
 	}
 
+	// Egress to the proxy, which may not listen on the Datadog intake port. Kubernetes network policies can't select
+	// hosts by name, so only the proxies configured with an IP are allowed: allowing the port to any destination
+	// would open the policy.
+	for _, endpoint := range GetProxyEndpoints(proxy) {
+		if ip := net.ParseIP(endpoint.Host); ip != nil {
+			egress = append(egress, egressKubernetesProxy(ip, endpoint.Port))
+		}
+	}
+
 	return policyName, ddaNamespace, podSelector, policyTypes, ingress, egress
 }
 
+// ProxyEndpoint is the host and port of a proxy
+type ProxyEndpoint struct {
+	Host string
+	Port int32
+}
+
+// GetProxyEndpoints returns the endpoints of the proxies configured with a URL, so that the network policies allow them.
+// The proxies configured in a Secret aren't returned: they must be allowed by additional network policies.
+func GetProxyEndpoints(proxy *v2alpha1.ProxyConfig) []ProxyEndpoint {
+	if proxy == nil {
+		return nil
+	}
+
+	var endpoints []ProxyEndpoint
+	for _, proxyURL := range []*string{proxy.HTTP, proxy.HTTPS} {
+		if proxyURL == nil || *proxyURL == "" {
+			continue
+		}
+		u, err := url.Parse(*proxyURL)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		endpoint := ProxyEndpoint{Host: u.Hostname(), Port: 80}
+		if u.Scheme == "https" {
+			endpoint.Port = 443
+		}
+		if port, err := strconv.ParseInt(u.Port(), 10, 32); err == nil {
+			endpoint.Port = int32(port)
+		}
+		if len(endpoints) == 0 || endpoints[0] != endpoint {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints
+}
+
+// kubernetes egress to a proxy
+func egressKubernetesProxy(ip net.IP, port int32) netv1.NetworkPolicyEgressRule {
+	return netv1.NetworkPolicyEgressRule{
+		Ports: []netv1.NetworkPolicyPort{
+			{
+				Port: &intstr.IntOrString{
+					Type:   intstr.Int,
+					IntVal: port,
+				},
+			},
+		},
+		To: []netv1.NetworkPolicyPeer{
+			{
+				IPBlock: &netv1.IPBlock{
+					CIDR: hostCIDR(ip),
+				},
+			},
+		},
+	}
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
 // GetNetworkPolicyMetadata generates a label selector based on component
 func GetNetworkPolicyMetadata(dda metav1.Object, componentName v2alpha1.ComponentName) (policyName string, podSelector metav1.LabelSelector) {
 	var suffix string
@@ -581,7 +662,7 @@ func ShouldCreateAgentLocalService(versionInfo *version.Info, forceEnableLocalSe
 }
 
 // BuildCiliumPolicy creates the base node agent, DCA, or CCR cilium network policy
-func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, dnsSelectorEndpoints []metav1.LabelSelector, componentName v2alpha1.ComponentName) (string, string, []cilium.NetworkPolicySpec) {
+func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, dnsSelectorEndpoints []metav1.LabelSelector, proxy *v2alpha1.ProxyConfig, componentName v2alpha1.ComponentName) (string, string, []cilium.NetworkPolicySpec) {
 	policyName, podSelector := GetNetworkPolicyMetadata(dda, componentName)
 	var policySpecs []cilium.NetworkPolicySpec
 
@@ -617,9 +698,47 @@ func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork
 			egressChecks(podSelector),
 		}
 	}
+	if endpoints := GetProxyEndpoints(proxy); len(endpoints) > 0 {
+		policySpecs = append(policySpecs, egressProxy(podSelector, endpoints))
+	}
 	return policyName, dda.GetNamespace(), policySpecs
 }
 
+// cilium egress to the proxies
+func egressProxy(podSelector metav1.LabelSelector, endpoints []ProxyEndpoint) cilium.NetworkPolicySpec {
+	egress := make([]cilium.EgressRule, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		rule := cilium.EgressRule{
+			ToPorts: []cilium.PortRule{
+				{
+					Ports: []cilium.PortProtocol{
+						{
+							Port:     strconv.Itoa(int(endpoint.Port)),
+							Protocol: cilium.ProtocolTCP,
+						},
+					},
+				},
+			},
+		}
+		if ip := net.ParseIP(endpoint.Host); ip != nil {
+			rule.ToCIDR = []string{hostCIDR(ip)}
+		} else {
+			rule.ToFQDNs = []cilium.FQDNSelector{
+				{
+					MatchName: endpoint.Host,
+				},
+			}
+		}
+		egress = append(egress, rule)
+	}
+
+	return cilium.NetworkPolicySpec{
+		Description:      "Egress to proxy",
+		EndpointSelector: podSelector,
+		Egress:           egress,
+	}
+}
+
 // cilium egress ports for ECS
 func egressECSPorts(podSelector metav1.LabelSelector) cilium.NetworkPolicySpec {
 	return cilium.NetworkPolicySpec{
@@ -70,6 +70,8 @@ type ReconcilerOptions struct {
 	OperatorMetricsEnabled   bool
 	V2Enabled                bool
 	IntrospectionEnabled     bool
+	PersistDefaultsEnabled   bool
+	OperatorNamespace        string
 }
 
 // Reconciler is the internal reconciler for Datadog Agent
@@ -166,6 +168,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, request reconcile.Re
 func reconcilerOptionsToFeatureOptions(opts *ReconcilerOptions, logger logr.Logger) *feature.Options {
 	return &feature.Options{
 		SupportExtendedDaemonset: opts.ExtendedDaemonsetOptions.Enabled,
+		OperatorNamespace:        opts.OperatorNamespace,
 		Logger:                   logger,
 	}
 }
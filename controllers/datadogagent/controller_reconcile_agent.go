@@ -32,14 +32,34 @@ import (
 
 func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature,
 	dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus,
-	provider string) (reconcile.Result, error) {
-	var result reconcile.Result
+	provider string, profile *datadoghqv2alpha1.NodeAgentProfile) (reconcile.Result, error) {
+	daemonsetLogger := logger.WithValues("component", datadoghqv2alpha1.NodeAgentComponentName)
+
+	eds, daemonset, disabled, err := r.buildV2Agent(logger, requiredComponents, features, dda, resourcesManager, newStatus, provider, profile)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if eds != nil {
+		if disabled {
+			return r.cleanupV2ExtendedDaemonSet(daemonsetLogger, dda, eds, newStatus)
+		}
+		return r.createOrUpdateExtendedDaemonset(daemonsetLogger, dda, eds, newStatus, updateEDSStatusV2WithAgent)
+	}
+	if disabled {
+		return r.cleanupV2DaemonSet(daemonsetLogger, dda, daemonset, newStatus)
+	}
+	return r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent)
+}
+
+// buildV2Agent builds the node Agent ExtendedDaemonSet, or DaemonSet, of a provider and a profile.
+// It returns true if the node Agent is disabled by an override, in which case the workload must be deleted.
+func (r *Reconciler) buildV2Agent(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature,
+	dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus,
+	provider string, profile *datadoghqv2alpha1.NodeAgentProfile) (*edsv1alpha1.ExtendedDaemonSet, *appsv1.DaemonSet, bool, error) {
 	var eds *edsv1alpha1.ExtendedDaemonSet
 	var daemonset *appsv1.DaemonSet
 	var podManagers feature.PodTemplateManagers
 
-	daemonsetLogger := logger.WithValues("component", datadoghqv2alpha1.NodeAgentComponentName)
-
 	// requiredComponents needs to be taken into account in case a feature(s) changes and
 	// a requiredComponent becomes disabled, in addition to taking into account override.Disabled
 	disabledByOverride := false
@@ -58,10 +78,13 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		// Apply features changes on the Deployment.Spec.Template
 		for _, feat := range features {
 			if errFeat := feat.ManageNodeAgent(podManagers, provider); errFeat != nil {
-				return result, errFeat
+				return nil, nil, false, errFeat
 			}
 		}
 
+		// Apply the defaults of the provider, before the override so users can change them
+		override.ProviderDefaults(podManagers, provider)
+
 		// If Override is defined for the node agent component, apply the override on the PodTemplateSpec, it will cascade to container.
 		componentOverride, overriden := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]
 		componentOverrideCopy := componentOverride.DeepCopy()
@@ -91,8 +114,25 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 			override.ExtendedDaemonSet(eds, componentOverrideCopy)
 		}
 
+		// Restrict the node Agent to the nodes of its profile, or to the nodes that aren't part of any profile.
+		affinity := eds.Spec.Template.Spec.Affinity.DeepCopy()
+		eds.Spec.Template.Spec.Affinity = addProfileNodeAffinity(affinity, profileNodeSelectorTerms(dda.Spec.Profiles, profile))
+		if profile != nil {
+			eds.Labels[apicommon.AgentDeploymentProfileLabelKey] = profile.Name
+			profileOverride := profileComponentOverride(eds.Name, profile)
+			if profileOverride.Disabled != nil {
+				disabledByOverride = *profileOverride.Disabled
+			}
+			override.PodTemplateSpec(logger, podManagers, profileOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name)
+			override.ExtendedDaemonSet(eds, profileOverride)
+		}
+
+		// Apply the global image policy to the final images
+		unpinnedImages := override.ImagePolicy(podManagers, dda, datadoghqv2alpha1.NodeAgentComponentName)
+		updateImagePolicyStatusCondition(newStatus, metav1.NewTime(time.Now()), datadoghqv2alpha1.NodeAgentComponentName, unpinnedImages)
+
 		if disabledByOverride {
-			if agentEnabled {
+			if agentEnabled && profile == nil {
 				// The override supersedes what's set in requiredComponents; update status to reflect the conflict
 				datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(
 					newStatus,
@@ -104,9 +144,8 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 					true,
 				)
 			}
-			return r.cleanupV2ExtendedDaemonSet(daemonsetLogger, dda, eds, newStatus)
 		}
-		return r.createOrUpdateExtendedDaemonset(daemonsetLogger, dda, eds, newStatus, updateEDSStatusV2WithAgent)
+		return eds, nil, disabledByOverride, nil
 	}
 
 	// Start by creating the Default Agent daemonset
@@ -119,15 +158,18 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 	for _, feat := range features {
 		if singleContainerStrategyEnabled {
 			if errFeat := feat.ManageSingleContainerNodeAgent(podManagers, provider); errFeat != nil {
-				return result, errFeat
+				return nil, nil, false, errFeat
 			}
 		} else {
 			if errFeat := feat.ManageNodeAgent(podManagers, provider); errFeat != nil {
-				return result, errFeat
+				return nil, nil, false, errFeat
 			}
 		}
 	}
 
+	// Apply the defaults of the provider, before the override so users can change them
+	override.ProviderDefaults(podManagers, provider)
+
 	// If Override is defined for the node agent component, apply the override on the PodTemplateSpec, it will cascade to container.
 	componentOverride, overriden := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]
 	componentOverrideCopy := componentOverride.DeepCopy()
@@ -157,8 +199,25 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		override.DaemonSet(daemonset, componentOverrideCopy)
 	}
 
+	// Restrict the node Agent to the nodes of its profile, or to the nodes that aren't part of any profile.
+	affinity := daemonset.Spec.Template.Spec.Affinity.DeepCopy()
+	daemonset.Spec.Template.Spec.Affinity = addProfileNodeAffinity(affinity, profileNodeSelectorTerms(dda.Spec.Profiles, profile))
+	if profile != nil {
+		daemonset.Labels[apicommon.AgentDeploymentProfileLabelKey] = profile.Name
+		profileOverride := profileComponentOverride(daemonset.Name, profile)
+		if profileOverride.Disabled != nil {
+			disabledByOverride = *profileOverride.Disabled
+		}
+		override.PodTemplateSpec(logger, podManagers, profileOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name)
+		override.DaemonSet(daemonset, profileOverride)
+	}
+
+	// Apply the global image policy to the final images
+	unpinnedImages := override.ImagePolicy(podManagers, dda, datadoghqv2alpha1.NodeAgentComponentName)
+	updateImagePolicyStatusCondition(newStatus, metav1.NewTime(time.Now()), datadoghqv2alpha1.NodeAgentComponentName, unpinnedImages)
+
 	if disabledByOverride {
-		if agentEnabled {
+		if agentEnabled && profile == nil {
 			// The override supersedes what's set in requiredComponents; update status to reflect the conflict
 			datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(
 				newStatus,
@@ -170,9 +229,8 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 				true,
 			)
 		}
-		return r.cleanupV2DaemonSet(daemonsetLogger, dda, daemonset, newStatus)
 	}
-	return r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent)
+	return nil, daemonset, disabledByOverride, nil
 }
 
 func updateDSStatusV2WithAgent(ds *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -208,7 +266,11 @@ func (r *Reconciler) cleanupV2DaemonSet(logger logr.Logger, dda *datadoghqv2alph
 		event := buildEventInfo(ds.Name, ds.Namespace, daemonSetKind, datadog.DeletionEvent)
 		r.recordEvent(dda, event)
 	}
+	removeStaleStatus(newStatus, ds.Name)
 	newStatus.Agent = nil
+	if len(newStatus.AgentList) > 0 {
+		newStatus.Agent = datadoghqv2alpha1.UpdateCombinedDaemonSetStatus(newStatus.AgentList)
+	}
 
 	return reconcile.Result{}, nil
 }
@@ -234,7 +296,11 @@ func (r *Reconciler) cleanupV2ExtendedDaemonSet(logger logr.Logger, dda *datadog
 		event := buildEventInfo(eds.Name, eds.Namespace, extendedDaemonSetKind, datadog.DeletionEvent)
 		r.recordEvent(dda, event)
 	}
+	removeStaleStatus(newStatus, eds.Name)
 	newStatus.Agent = nil
+	if len(newStatus.AgentList) > 0 {
+		newStatus.Agent = datadoghqv2alpha1.UpdateCombinedDaemonSetStatus(newStatus.AgentList)
+	}
 
 	return reconcile.Result{}, nil
 }
@@ -296,12 +362,16 @@ func (r *Reconciler) updateProviderStore(ctx context.Context) (map[string]struct
 
 	// recompute providers using updated node list
 	providersList := make(map[string]struct{})
-	for _, node := range nodeList.Items {
-		provider := kubernetes.DetermineProvider(node.Labels)
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		provider := kubernetes.DetermineNodeProvider(node)
 		if _, ok := providersList[provider]; !ok {
 			providersList[provider] = struct{}{}
 			r.log.V(1).Info("New provider detected", "provider", provider)
 		}
+		if def, missing := kubernetes.UnlabelledNodeProvider(node); missing {
+			r.log.V(1).Info("Node of provider without the provider label, it runs the default node Agent", "node", node.Name, "provider", def.Name(), "label", def.Label, "value", def.Value)
+		}
 	}
 
 	return r.providerStore.Reset(providersList), nil
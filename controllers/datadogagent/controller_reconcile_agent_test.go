@@ -240,6 +240,7 @@ func Test_updateProviderStore(t *testing.T) {
 		nodes             []client.Object
 		existingProviders map[string]struct{}
 		wantedProviders   map[string]struct{}
+		wantedNodeLabels  map[string]map[string]string
 	}{
 		{
 			name: "recompute all providers",
@@ -261,6 +262,39 @@ func Test_updateProviderStore(t *testing.T) {
 				"gke-cos": {},
 			},
 		},
+		{
+			name: "bottlerocket nodes with and without the provider label",
+			nodes: []client.Object{
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "bottlerocket-node",
+						Labels: map[string]string{
+							kubernetes.EKSBottlerocketProviderLabel: kubernetes.EKSBottlerocketType,
+						},
+					},
+					Status: corev1.NodeStatus{
+						NodeInfo: corev1.NodeSystemInfo{OSImage: "Bottlerocket OS 1.19.2 (aws-k8s-1.28)"},
+					},
+				},
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "unlabelled-bottlerocket-node",
+					},
+					Status: corev1.NodeStatus{
+						NodeInfo: corev1.NodeSystemInfo{OSImage: "Bottlerocket OS 1.19.2 (aws-k8s-1.28)"},
+					},
+				},
+			},
+			wantedProviders: map[string]struct{}{
+				"eks-bottlerocket": {},
+				"default":          {},
+			},
+			// the nodes aren't labelled by the Operator
+			wantedNodeLabels: map[string]map[string]string{
+				"bottlerocket-node":            {kubernetes.EKSBottlerocketProviderLabel: kubernetes.EKSBottlerocketType},
+				"unlabelled-bottlerocket-node": nil,
+			},
+		},
 		{
 			name:  "empty node list",
 			nodes: []client.Object{},
@@ -290,6 +324,12 @@ func Test_updateProviderStore(t *testing.T) {
 			providerList, err := r.updateProviderStore(ctx)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.wantedProviders, providerList)
+
+			for name, labels := range tt.wantedNodeLabels {
+				node := &corev1.Node{}
+				assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, node))
+				assert.Equal(t, labels, node.Labels)
+			}
 		})
 	}
 }
@@ -25,8 +25,21 @@ import (
 )
 
 func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature, dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (reconcile.Result, error) {
-	var result reconcile.Result
+	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterChecksRunnerReconcileConditionType)
 
+	deployment, disabled, err := r.buildV2ClusterChecksRunner(logger, requiredComponents, features, dda, resourcesManager, newStatus)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if disabled {
+		return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
+	}
+	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
+}
+
+// buildV2ClusterChecksRunner builds the Cluster Checks Runner Deployment and adds its dependencies to the store.
+// It returns true if the Cluster Checks Runner is disabled, in which case the Deployment must be deleted.
+func (r *Reconciler) buildV2ClusterChecksRunner(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature, dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (*appsv1.Deployment, bool, error) {
 	// Start by creating the Default Cluster-Agent deployment
 	deployment := componentccr.NewDefaultClusterChecksRunnerDeployment(dda)
 	podManagers := feature.NewPodTemplateManagers(&deployment.Spec.Template)
@@ -37,12 +50,10 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, required
 	// Apply features changes on the Deployment.Spec.Template
 	for _, feat := range features {
 		if errFeat := feat.ManageClusterChecksRunner(podManagers); errFeat != nil {
-			return result, errFeat
+			return nil, false, errFeat
 		}
 	}
 
-	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterChecksRunnerReconcileConditionType)
-
 	// The requiredComponents can change depending on if updates to features result in disabled components
 	ccrEnabled := requiredComponents.ClusterChecksRunner.IsEnabled()
 	dcaEnabled := requiredComponents.ClusterAgent.IsEnabled()
@@ -50,10 +61,10 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, required
 	// If the Cluster Agent is disabled, then CCR should be disabled too
 	if dcaOverride, ok := dda.Spec.Override[datadoghqv2alpha1.ClusterAgentComponentName]; ok {
 		if apiutils.BoolValue(dcaOverride.Disabled) {
-			return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
+			return deployment, true, nil
 		}
 	} else if !dcaEnabled {
-		return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
+		return deployment, true, nil
 	}
 
 	// If Override is defined for the CCR component, apply the override on the PodTemplateSpec, it will cascade to container.
@@ -72,15 +83,28 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, required
 				)
 			}
 			// Delete CCR
-			return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
+			return deployment, true, nil
 		}
 		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, dda.Name)
 		override.Deployment(deployment, componentOverride)
 	} else if !ccrEnabled {
-		return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
+		return deployment, true, nil
 	}
 
-	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
+	// Apply the global image policy to the final images
+	unpinnedImages := override.ImagePolicy(podManagers, dda, datadoghqv2alpha1.ClusterChecksRunnerComponentName)
+	updateImagePolicyStatusCondition(newStatus, metav1.NewTime(time.Now()), datadoghqv2alpha1.ClusterChecksRunnerComponentName, unpinnedImages)
+
+	componentOverride := dda.Spec.Override[datadoghqv2alpha1.ClusterChecksRunnerComponentName]
+	if err := addHorizontalPodAutoscalerV2(resourcesManager, deployment, componentccr.GetClusterChecksRunnerHorizontalPodAutoscalerName(dda), componentOverride); err != nil {
+		return nil, false, err
+	}
+
+	if err := addPodDisruptionBudgetV2(resourcesManager, deployment, componentccr.GetClusterChecksRunnerPodDisruptionBudgetName(dda), componentOverride); err != nil {
+		return nil, false, err
+	}
+
+	return deployment, false, nil
 }
 
 func updateStatusV2WithClusterChecksRunner(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
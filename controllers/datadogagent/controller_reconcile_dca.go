@@ -9,15 +9,20 @@ import (
 	"context"
 	"time"
 
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	componentdca "github.com/DataDog/datadog-operator/controllers/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/override"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 
 	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,8 +30,21 @@ import (
 )
 
 func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature, dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (reconcile.Result, error) {
-	var result reconcile.Result
+	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterAgentComponentName)
 
+	deployment, disabled, err := r.buildV2ClusterAgent(logger, requiredComponents, features, dda, resourcesManager, newStatus)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if disabled {
+		return r.cleanupV2ClusterAgent(deploymentLogger, dda, deployment, resourcesManager, newStatus)
+	}
+	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterAgent)
+}
+
+// buildV2ClusterAgent builds the Cluster Agent Deployment and adds its dependencies to the store.
+// It returns true if the Cluster Agent is disabled, in which case the Deployment must be deleted.
+func (r *Reconciler) buildV2ClusterAgent(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature, dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (*appsv1.Deployment, bool, error) {
 	// Start by creating the Default Cluster-Agent deployment
 	deployment := componentdca.NewDefaultClusterAgentDeployment(dda)
 	podManagers := feature.NewPodTemplateManagers(&deployment.Spec.Template)
@@ -37,12 +55,10 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, requiredCompone
 	// Apply features changes on the Deployment.Spec.Template
 	for _, feat := range features {
 		if errFeat := feat.ManageClusterAgent(podManagers); errFeat != nil {
-			return result, errFeat
+			return nil, false, errFeat
 		}
 	}
 
-	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterAgentComponentName)
-
 	// The requiredComponents can change depending on if updates to features result in disabled components
 	dcaEnabled := requiredComponents.ClusterAgent.IsEnabled()
 
@@ -61,15 +77,24 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, requiredCompone
 					true,
 				)
 			}
-			return r.cleanupV2ClusterAgent(deploymentLogger, dda, deployment, resourcesManager, newStatus)
+			return deployment, true, nil
 		}
 		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterAgentComponentName, dda.Name)
 		override.Deployment(deployment, componentOverride)
 	} else if !dcaEnabled {
 		// If the override is not defined, then disable based on dcaEnabled value
-		return r.cleanupV2ClusterAgent(deploymentLogger, dda, deployment, resourcesManager, newStatus)
+		return deployment, true, nil
 	}
-	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterAgent)
+
+	// Apply the global image policy to the final images
+	unpinnedImages := override.ImagePolicy(podManagers, dda, datadoghqv2alpha1.ClusterAgentComponentName)
+	updateImagePolicyStatusCondition(newStatus, metav1.NewTime(time.Now()), datadoghqv2alpha1.ClusterAgentComponentName, unpinnedImages)
+
+	if err := addPodDisruptionBudgetV2(resourcesManager, deployment, componentdca.GetClusterAgentPodDisruptionBudgetName(dda), dda.Spec.Override[datadoghqv2alpha1.ClusterAgentComponentName]); err != nil {
+		return nil, false, err
+	}
+
+	return deployment, false, nil
 }
 
 func updateStatusV2WithClusterAgent(dca *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -114,3 +139,34 @@ func (r *Reconciler) cleanupV2ClusterAgent(logger logr.Logger, dda *datadoghqv2a
 	newStatus.ClusterAgent = nil
 	return reconcile.Result{}, nil
 }
+
+// setAdmissionControllerCABundle sets the CA bundle of the MutatingWebhookConfiguration managed by the Operator
+// from the certificate created by the Cluster Agent. The configuration isn't created until the certificate exists.
+func (r *Reconciler) setAdmissionControllerCABundle(ctx context.Context, namespace string, depsStore *dependencies.Store) error {
+	configs := depsStore.Objects(kubernetes.MutatingWebhookConfigurationsKind)
+	if len(configs) == 0 {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: apicommon.DefaultAdmissionControllerCertificateSecretName}, secret); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	caBundle := secret.Data[apicommon.AdmissionControllerCertificateSecretKey]
+
+	for _, obj := range configs {
+		config, ok := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+		if !ok {
+			continue
+		}
+		if len(caBundle) == 0 {
+			r.log.V(1).Info("Waiting for the Cluster Agent certificate to create the admission controller webhook configuration", "name", config.Name)
+			depsStore.Delete(kubernetes.MutatingWebhookConfigurationsKind, "", config.Name)
+			continue
+		}
+		for i := range config.Webhooks {
+			config.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_setAdmissionControllerCABundle(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("Test_setAdmissionControllerCABundle")
+
+	newCertificateSecret := func(namespace string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: apicommon.DefaultAdmissionControllerCertificateSecretName},
+			Data:       data,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		objects      []client.Object
+		wantConfig   bool
+		wantCABundle []byte
+	}{
+		{
+			name: "certificate created by the Cluster Agent",
+			objects: []client.Object{
+				newCertificateSecret("datadog", map[string][]byte{apicommon.AdmissionControllerCertificateSecretKey: []byte("ca")}),
+			},
+			wantConfig:   true,
+			wantCABundle: []byte("ca"),
+		},
+		{
+			name: "certificate not created yet",
+		},
+		{
+			name: "certificate in another namespace",
+			objects: []client.Object{
+				newCertificateSecret("default", map[string][]byte{apicommon.AdmissionControllerCertificateSecretKey: []byte("ca")}),
+			},
+		},
+		{
+			name: "certificate without the PEM key",
+			objects: []client.Object{
+				newCertificateSecret("datadog", map[string][]byte{"key.pem": []byte("key")}),
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{
+				client: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+				log:    logger,
+			}
+			dda := v2alpha1test.NewInitializedDatadogAgentBuilder("datadog", "foo").BuildWithDefaults()
+			store := dependencies.NewStore(dda, nil)
+			assert.NoError(t, store.AddOrUpdate(kubernetes.MutatingWebhookConfigurationsKind, &admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "datadog-webhook"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{Name: "datadog.webhook.agent.config"},
+					{Name: "datadog.webhook.standard.tags"},
+				},
+			}))
+
+			assert.NoError(t, r.setAdmissionControllerCABundle(ctx, "datadog", store))
+
+			obj, found := store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+			assert.Equal(t, tt.wantConfig, found)
+			if !tt.wantConfig {
+				return
+			}
+			for _, webhook := range obj.(*admissionregistrationv1.MutatingWebhookConfiguration).Webhooks {
+				assert.Equal(t, tt.wantCABundle, webhook.ClientConfig.CABundle)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ package datadogagent
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -75,13 +76,11 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 		return result, err
 	}
 
-	// TODO check if IsValideDatadogAgent function is needed for v2
-	/*
-		if err = datadoghqv2alpha1.IsValidDatadogAgent(&instance.Spec); err != nil {
-			reqLogger.V(1).Info("Invalid spec", "error", err)
-			return r.updateStatusIfNeeded(reqLogger, instance, &instance.Status, result, err)
+	if r.options.PersistDefaultsEnabled {
+		if result, err = r.persistDefaultsV2(ctx, reqLogger, instance); utils.ShouldReturn(result, err) {
+			return result, err
 		}
-	*/
+	}
 
 	// Set default values for GlobalConfig and Features
 	instanceCopy := instance.DeepCopy()
@@ -90,11 +89,36 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 	return r.reconcileInstanceV2(ctx, reqLogger, instanceCopy)
 }
 
+// persistDefaultsV2 writes the default values in the DatadogAgent spec, so that the effective configuration is visible on the object.
+func (r *Reconciler) persistDefaultsV2(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent) (reconcile.Result, error) {
+	defaulted := instance.DeepCopy()
+	datadoghqv2alpha1.DefaultDatadogAgentWithAnnotations(defaulted)
+	if apiequality.Semantic.DeepEqual(instance.Spec, defaulted.Spec) && apiequality.Semantic.DeepEqual(instance.Annotations, defaulted.Annotations) {
+		return reconcile.Result{}, nil
+	}
+
+	logger.Info("Persisting DatadogAgent defaults")
+	if err := r.client.Update(ctx, defaulted); err != nil {
+		if apierrors.IsConflict(err) {
+			logger.V(1).Info("unable to persist DatadogAgent defaults due to update conflict")
+			return reconcile.Result{RequeueAfter: time.Second}, nil
+		}
+		logger.Error(err, "failed to update DatadogAgent")
+		return reconcile.Result{}, err
+	}
+	// Keep reconciling with the updated DatadogAgent, its new resource version is needed for the status update.
+	defaulted.DeepCopyInto(instance)
+	return reconcile.Result{}, nil
+}
+
 func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent) (reconcile.Result, error) {
 	var result reconcile.Result
 	newStatus := instance.Status.DeepCopy()
+	updateInvalidSpecStatusCondition(logger, instance, newStatus, metav1.NewTime(time.Now()))
+	updateSpecWarningStatusCondition(logger, instance, newStatus, metav1.NewTime(time.Now()))
 
-	features, requiredComponents := feature.BuildFeatures(instance, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	featureOptions := reconcilerOptionsToFeatureOptions(&r.options, logger)
+	features, requiredComponents := feature.BuildFeatures(instance, featureOptions)
 	// update list of enabled features for metrics forwarder
 	r.updateMetricsForwardersFeatures(instance, features)
 
@@ -114,12 +138,17 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	var errs []error
 
 	// Set up dependencies required by enabled features
+	manageErrs := featureErrors{}
 	for _, feat := range features {
 		logger.V(1).Info("Dependency ManageDependencies", "featureID", feat.ID())
+		depsStore.SetCurrentFeature(string(feat.ID()))
 		if featErr := feat.ManageDependencies(resourceManagers, requiredComponents); featErr != nil {
 			errs = append(errs, featErr)
+			manageErrs[feat.ID()] = append(manageErrs[feat.ID()], featErr)
 		}
 	}
+	depsStore.SetCurrentFeature("")
+	updateFeatureStatusConditions(newStatus, metav1.NewTime(time.Now()), features, manageErrs, nil)
 
 	// Examine user configuration to override any external dependencies (e.g. RBACs)
 	errs = append(errs, override.Dependencies(logger, resourceManagers, instance)...)
@@ -149,10 +178,18 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 			errs = append(errs, err)
 		}
 	}
-	for provider := range providersList {
-		result, err = r.reconcileV2Agent(logger, requiredComponents, features, instance, resourceManagers, newStatus, provider)
-		if utils.ShouldReturn(result, err) {
-			return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err)
+	// delete the node agents of the profiles that were removed
+	if err = r.cleanupDaemonSetsForProfilesThatNoLongerApply(ctx, instance, newStatus); err != nil {
+		errs = append(errs, err)
+	}
+	// for the nodes that aren't part of any profile and for each profile, reconcile the node agent of all providers
+	for _, profile := range nodeAgentProfiles(instance) {
+		for provider := range providersList {
+			agentFeatures, agentRequiredComponents := nodeAgentFeatures(instance, featureOptions, features, requiredComponents, profile, provider)
+			result, err = r.reconcileV2Agent(logger, agentRequiredComponents, agentFeatures, instance, resourceManagers, newStatus, provider, profile)
+			if utils.ShouldReturn(result, err) {
+				return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err)
+			}
 		}
 	}
 
@@ -164,10 +201,14 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	// ------------------------------
 	// Create and update dependencies
 	// ------------------------------
+	if err = r.setAdmissionControllerCABundle(ctx, instance.Namespace, depsStore); err != nil {
+		errs = append(errs, err)
+	}
 	errs = append(errs, depsStore.Apply(ctx, r.client)...)
+	updateFeatureStatusConditions(newStatus, metav1.NewTime(time.Now()), features, manageErrs, depsStore)
 	if len(errs) > 0 {
 		logger.V(2).Info("Dependencies apply error", "errs", errs)
-		return result, errors.NewAggregate(errs)
+		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs))
 	}
 
 	// -----------------------------
@@ -211,6 +252,40 @@ func (r *Reconciler) updateStatusIfNeededV2(logger logr.Logger, agentdeployment
 	return result, currentError
 }
 
+// updateInvalidSpecStatusCondition reports a spec that doesn't pass the validation in the status.
+// The validating webhook is optional and the DatadogAgents created before a validation rule was added
+// must keep working, so an invalid spec is still reconciled.
+func updateInvalidSpecStatusCondition(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	if err := datadoghqv2alpha1.IsValidDatadogAgent(dda); err != nil {
+		logger.Info("Invalid DatadogAgent spec", "error", err)
+		datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, datadoghqv2alpha1.DatadogAgentInvalidSpecConditionType, metav1.ConditionTrue, "DatadogAgent_invalid_spec", err.Error(), false)
+		return
+	}
+	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, datadoghqv2alpha1.DatadogAgentInvalidSpecConditionType, metav1.ConditionFalse, "DatadogAgent_valid_spec", "DatadogAgent spec is valid", false)
+}
+
+// updateSpecWarningStatusCondition reports the settings of the spec that are accepted, but can harm the cluster, in the status.
+func updateSpecWarningStatusCondition(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	if warnings := datadoghqv2alpha1.GetDatadogAgentSpecWarnings(dda); len(warnings) > 0 {
+		logger.Info("DatadogAgent spec warnings", "warnings", warnings)
+		datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, datadoghqv2alpha1.DatadogAgentSpecWarningConditionType, metav1.ConditionTrue, "DatadogAgent_spec_warning", strings.Join(warnings, "; "), false)
+		return
+	}
+	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, datadoghqv2alpha1.DatadogAgentSpecWarningConditionType, metav1.ConditionFalse, "DatadogAgent_no_spec_warning", "DatadogAgent spec has no warning", false)
+}
+
+// updateImagePolicyStatusCondition reports the images of a component that the image policy manifests don't pin to a digest.
+// The images are the same for all the node Agent profiles and providers, so the condition is set per component.
+func updateImagePolicyStatusCondition(newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time, componentName datadoghqv2alpha1.ComponentName, unpinnedImages []string) {
+	conditionType := datadoghqv2alpha1.GetImagePolicyUnpinnedConditionType(componentName)
+	if len(unpinnedImages) > 0 {
+		message := fmt.Sprintf("Images not listed in global.imagePolicy.manifests, deployed without digest: %s", strings.Join(unpinnedImages, ", "))
+		datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, conditionType, metav1.ConditionTrue, "ImagePolicy_unpinned_images", message, false)
+		return
+	}
+	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, conditionType, metav1.ConditionFalse, "ImagePolicy_pinned_images", "All the images are pinned to a digest", false)
+}
+
 // setMetricsForwarderStatus sets the metrics forwarder status condition if enabled
 func (r *Reconciler) setMetricsForwarderStatusV2(logger logr.Logger, agentdeployment *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus) {
 	if r.options.OperatorMetricsEnabled {
@@ -17,6 +17,7 @@ import (
 	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	testutils "github.com/DataDog/datadog-operator/controllers/datadogagent/testutils"
 	assert "github.com/stretchr/testify/require"
 
@@ -26,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -495,6 +497,89 @@ func Test_Introspection(t *testing.T) {
 	}
 }
 
+func Test_Profiles(t *testing.T) {
+	const resourcesName = "foo"
+	const resourcesNamespace = "bar"
+
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconcileDatadogAgent_Reconcile"})
+	forwarders := dummyManager{}
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// Register operator types with the runtime scheme.
+	s := testutils.TestScheme(true)
+
+	gpuRequirement := corev1.NodeSelectorRequirement{Key: "nvidia.com/gpu.present", Operator: corev1.NodeSelectorOpExists}
+
+	c := fake.NewFakeClient()
+	r := &Reconciler{
+		client:     c,
+		scheme:     s,
+		recorder:   recorder,
+		log:        logf.Log.WithName("Test_Profiles"),
+		forwarders: forwarders,
+		options: ReconcilerOptions{
+			ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{
+				Enabled: false,
+			},
+			SupportCilium: false,
+			V2Enabled:     true,
+		},
+	}
+
+	// DaemonSet of a profile that was removed from the DatadogAgent
+	staleDS := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      "foo-agent-old",
+			Labels: map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:    resourcesName,
+				apicommon.AgentDeploymentProfileLabelKey: "old",
+			},
+		},
+	}
+	assert.NoError(t, c.Create(context.TODO(), staleDS))
+
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+		WithProfiles(v2alpha1.NodeAgentProfile{
+			Name:                     "gpu",
+			NodeSelectorRequirements: []corev1.NodeSelectorRequirement{gpuRequirement},
+			DisabledFeatures:         []string{"apm"},
+		}).
+		Build()
+	assert.NoError(t, c.Create(context.TODO(), dda))
+
+	_, err := r.Reconcile(context.TODO(), newRequest(resourcesNamespace, resourcesName))
+	assert.NoError(t, err)
+
+	// The default DaemonSet runs on the nodes that aren't part of the profile
+	assert.NoError(t, verifyDaemonsetContainers(c, resourcesNamespace, "foo-agent", []string{
+		string(apicommonv1.CoreAgentContainerName),
+		string(apicommonv1.ProcessAgentContainerName),
+		string(apicommonv1.TraceAgentContainerName),
+	}))
+	ds := &appsv1.DaemonSet{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: "foo-agent"}, ds))
+	assert.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+		{Key: "nvidia.com/gpu.present", Operator: corev1.NodeSelectorOpDoesNotExist},
+	}}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// The profile DaemonSet runs on the nodes of the profile, without the disabled features
+	assert.NoError(t, verifyDaemonsetContainers(c, resourcesNamespace, "foo-agent-gpu", []string{
+		string(apicommonv1.CoreAgentContainerName),
+		string(apicommonv1.ProcessAgentContainerName),
+	}))
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: "foo-agent-gpu"}, ds))
+	assert.Equal(t, "gpu", ds.Labels[apicommon.AgentDeploymentProfileLabelKey])
+	assert.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpuRequirement}}},
+		ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// The DaemonSet of the removed profile is deleted
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: "foo-agent-old"}, ds)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func verifyDaemonsetContainers(c client.Client, resourcesNamespace, dsName string, expectedContainers []string) error {
 	ds := &appsv1.DaemonSet{}
 	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: dsName}, ds); err != nil {
@@ -529,3 +614,102 @@ func verifyDaemonsetNames(t *testing.T, c client.Client, resourcesNamespace, dsN
 	assert.Equal(t, expectedDSNames, actualDSNames)
 	return nil
 }
+
+func Test_updateInvalidSpecStatusCondition(t *testing.T) {
+	logger := logf.Log.WithName("Test_updateInvalidSpecStatusCondition")
+	now := metav1.NewTime(time.Now())
+
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder("bar", "foo").WithCredentials("0000000000000000000000", "").Build()
+	status := &v2alpha1.DatadogAgentStatus{}
+	updateInvalidSpecStatusCondition(logger, dda, status, now)
+	assert.Empty(t, status.Conditions)
+
+	dda.Spec.Global.Credentials.APIKey = nil
+	dda.Spec.Global.Credentials.APISecret = nil
+	updateInvalidSpecStatusCondition(logger, dda, status, now)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, v2alpha1.DatadogAgentInvalidSpecConditionType, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+
+	dda.Spec.Global.Credentials.APIKey = apiutils.NewStringPointer("0000000000000000000000")
+	updateInvalidSpecStatusCondition(logger, dda, status, now)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+}
+
+func Test_updateSpecWarningStatusCondition(t *testing.T) {
+	logger := logf.Log.WithName("Test_updateSpecWarningStatusCondition")
+	now := metav1.NewTime(time.Now())
+
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder("bar", "foo").WithCredentials("0000000000000000000000", "").Build()
+	status := &v2alpha1.DatadogAgentStatus{}
+	updateSpecWarningStatusCondition(logger, dda, status, now)
+	assert.Empty(t, status.Conditions)
+
+	dda.Spec.Features.AdmissionController = &v2alpha1.AdmissionControllerFeatureConfig{
+		MutateUnlabelled: apiutils.NewBoolPointer(true),
+		FailurePolicy:    apiutils.NewStringPointer("Fail"),
+	}
+	updateSpecWarningStatusCondition(logger, dda, status, now)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, v2alpha1.DatadogAgentSpecWarningConditionType, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+	assert.Contains(t, status.Conditions[0].Message, "spec.features.admissionController.failurePolicy")
+
+	// The spec is still valid, and reconciled.
+	assert.NoError(t, v2alpha1.IsValidDatadogAgent(dda))
+
+	dda.Spec.Features.AdmissionController.FailurePolicy = apiutils.NewStringPointer("Ignore")
+	updateSpecWarningStatusCondition(logger, dda, status, now)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+}
+
+func Test_updateImagePolicyStatusCondition(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	conditionType := v2alpha1.GetImagePolicyUnpinnedConditionType(v2alpha1.ClusterAgentComponentName)
+
+	status := &v2alpha1.DatadogAgentStatus{}
+	updateImagePolicyStatusCondition(status, now, v2alpha1.ClusterAgentComponentName, nil)
+	assert.Empty(t, status.Conditions)
+
+	updateImagePolicyStatusCondition(status, now, v2alpha1.ClusterAgentComponentName, []string{"registry.example.com/datadog/cluster-agent:7.50.3"})
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, "ImagePolicyUnpinned-clusterAgent", status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+	assert.Contains(t, status.Conditions[0].Message, "registry.example.com/datadog/cluster-agent:7.50.3")
+
+	updateImagePolicyStatusCondition(status, now, v2alpha1.ClusterAgentComponentName, nil)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, conditionType, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+}
+
+func Test_persistDefaultsV2(t *testing.T) {
+	logger := logf.Log.WithName("Test_persistDefaultsV2")
+	s := testutils.TestScheme(true)
+
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder("bar", "foo").WithCredentials("0000000000000000000000", "").Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dda).Build()
+	r := &Reconciler{client: c, scheme: s, log: logger}
+
+	instance := &v2alpha1.DatadogAgent{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "bar", Name: "foo"}, instance))
+	result, err := r.persistDefaultsV2(context.TODO(), logger, instance)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	// The defaults are written, and the instance is refreshed with the stored object.
+	stored := &v2alpha1.DatadogAgent{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "bar", Name: "foo"}, stored))
+	assert.NotNil(t, stored.Spec.Global.Site)
+	assert.Equal(t, "1", stored.Annotations[apicommon.DefaultsVersionAnnotationKey])
+	assert.Equal(t, stored.ResourceVersion, instance.ResourceVersion)
+
+	// Nothing is written when the defaults are already persisted.
+	result, err = r.persistDefaultsV2(context.TODO(), logger, instance)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "bar", Name: "foo"}, stored))
+	assert.Equal(t, instance.ResourceVersion, stored.ResourceVersion)
+}
@@ -49,8 +49,10 @@ type StoreClient interface {
 // NewStore returns a new Store instance
 func NewStore(owner metav1.Object, options *StoreOptions) *Store {
 	store := &Store{
-		deps:  make(map[kubernetes.ObjectKind]map[string]client.Object),
-		owner: owner,
+		deps:             make(map[kubernetes.ObjectKind]map[string]client.Object),
+		owner:            owner,
+		objectFeatures:   make(map[string]string),
+		featureApplyErrs: make(map[string][]error),
 	}
 	if options != nil {
 		store.supportCilium = options.SupportCilium
@@ -69,6 +71,12 @@ type Store struct {
 	deps  map[kubernetes.ObjectKind]map[string]client.Object
 	mutex sync.RWMutex
 
+	// currentFeature is the feature adding objects to the store, the objects are associated with it
+	// so that errors can be reported per feature.
+	currentFeature   string
+	objectFeatures   map[string]string
+	featureApplyErrs map[string][]error
+
 	supportCilium bool
 	versionInfo   *version.Info
 	platformInfo  kubernetes.PlatformInfo
@@ -132,9 +140,41 @@ func (ds *Store) AddOrUpdate(kind kubernetes.ObjectKind, obj client.Object) erro
 	}
 
 	ds.deps[kind][id] = obj
+	if ds.currentFeature != "" {
+		if ds.objectFeatures == nil {
+			ds.objectFeatures = make(map[string]string)
+		}
+		ds.objectFeatures[buildFeatureKey(kind, id)] = ds.currentFeature
+	}
 	return nil
 }
 
+// SetCurrentFeature sets the feature adding the next objects to the Store, an empty featureID
+// stops associating the objects with a feature.
+func (ds *Store) SetCurrentFeature(featureID string) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	ds.currentFeature = featureID
+}
+
+// FeatureApplyErrors returns the errors returned by the last Apply for the objects added by a feature.
+func (ds *Store) FeatureApplyErrors(featureID string) []error {
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	return ds.featureApplyErrs[featureID]
+}
+
+// addApplyError records an Apply error, and associates it with the feature of the object.
+// The Store lock must be held by the caller.
+func (ds *Store) addApplyError(errs []error, kind kubernetes.ObjectKind, obj client.Object, err error) []error {
+	if featureID, found := ds.objectFeatures[buildFeatureKey(kind, buildID(obj.GetNamespace(), obj.GetName()))]; found {
+		ds.featureApplyErrs[featureID] = append(ds.featureApplyErrs[featureID], err)
+	}
+	return append(errs, err)
+}
+
 // AddOrUpdateStore used to add or update an object in the Store
 // kind correspond to the object kind, and id can be `namespace/name` identifier of just
 // `name` if we are talking about a cluster scope object like `ClusterRole`.
@@ -161,6 +201,18 @@ func (ds *Store) Get(kind kubernetes.ObjectKind, namespace string, name string)
 	return nil, false
 }
 
+// Objects returns the objects of a kind added in the Store.
+func (ds *Store) Objects(kind kubernetes.ObjectKind) []client.Object {
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	objs := make([]client.Object, 0, len(ds.deps[kind]))
+	for _, obj := range ds.deps[kind] {
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
 // GetOrCreate returns the client.Object instance.
 //   - if it was previously added in the Store, it returns the corresponding object
 //   - if it wasn't previously added in the Store, it returns a new instance of the object Kind with
@@ -198,12 +250,15 @@ func (ds *Store) Delete(kind kubernetes.ObjectKind, namespace string, name strin
 
 // Apply use to create/update resources in the api-server
 func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
-	ds.mutex.RLock()
-	defer ds.mutex.RUnlock()
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	ds.featureApplyErrs = make(map[string][]error)
 
 	var errs []error
 	var objsToCreate []client.Object
 	var objsToUpdate []client.Object
+	objKinds := map[client.Object]kubernetes.ObjectKind{}
 	for kind := range ds.deps {
 		for objID, objStore := range ds.deps[kind] {
 			objNSName := buildObjectKey(objID)
@@ -212,9 +267,10 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 			if err != nil && apierrors.IsNotFound(err) {
 				ds.logger.V(2).Info("dependencies.store Add object to create", "obj.namespace", objStore.GetNamespace(), "obj.name", objStore.GetName(), "obj.kind", kind)
 				objsToCreate = append(objsToCreate, objStore)
+				objKinds[objStore] = kind
 				continue
 			} else if err != nil {
-				errs = append(errs, err)
+				errs = ds.addApplyError(errs, kind, objStore, err)
 				continue
 			}
 
@@ -224,14 +280,15 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 				objStore.(*v1.Service).Spec.ClusterIPs = objAPIServer.(*v1.Service).Spec.ClusterIPs
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
-			// The APIServiceKind resource version must be set.
-			if kind == kubernetes.APIServiceKind {
+			// The APIServiceKind and MutatingWebhookConfigurationsKind resource version must be set.
+			if kind == kubernetes.APIServiceKind || kind == kubernetes.MutatingWebhookConfigurationsKind {
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
 
 			if !equality.IsEqualObject(kind, objStore, objAPIServer) {
 				ds.logger.V(2).Info("dependencies.store Add object to update", "obj.namespace", objStore.GetNamespace(), "obj.name", objStore.GetName(), "obj.kind", kind)
 				objsToUpdate = append(objsToUpdate, objStore)
+				objKinds[objStore] = kind
 				continue
 			}
 		}
@@ -241,7 +298,7 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 	for _, obj := range objsToCreate {
 		if err := k8sClient.Create(ctx, obj); err != nil {
 			ds.logger.Error(err, "dependencies.store Create", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
-			errs = append(errs, err)
+			errs = ds.addApplyError(errs, objKinds[obj], obj, err)
 		}
 	}
 
@@ -249,7 +306,7 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 	for _, obj := range objsToUpdate {
 		if err := k8sClient.Update(ctx, obj); err != nil {
 			ds.logger.Error(err, "dependencies.store Update", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
-			errs = append(errs, err)
+			errs = ds.addApplyError(errs, objKinds[obj], obj, err)
 		}
 	}
 	return errs
@@ -257,10 +314,22 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 
 // Cleanup use to cleanup resources that are not needed anymore
 func (ds *Store) Cleanup(ctx context.Context, k8sClient client.Client) []error {
+	objsToDelete, errs := ds.ListObjectsToDelete(ctx, k8sClient)
+	for _, kind := range ds.platformInfo.GetAgentResourcesKind(ds.supportCilium) {
+		errs = append(errs, deleteObjects(ctx, k8sClient, objsToDelete[kind])...)
+	}
+
+	return errs
+}
+
+// ListObjectsToDelete returns the resources that are not needed anymore, by kind,
+// these are the resources deleted by Cleanup
+func (ds *Store) ListObjectsToDelete(ctx context.Context, k8sClient client.Client) (map[kubernetes.ObjectKind][]client.Object, []error) {
 	ds.mutex.RLock()
 	defer ds.mutex.RUnlock()
 
 	var errs []error
+	objsToDelete := map[kubernetes.ObjectKind][]client.Object{}
 
 	requirementLabel, _ := labels.NewRequirement(operatorStoreLabelKey, selection.Exists, nil)
 	listOptions := &client.ListOptions{
@@ -273,15 +342,17 @@ func (ds *Store) Cleanup(ctx context.Context, k8sClient client.Client) []error {
 			continue
 		}
 
-		objsToDelete, err := ds.listObjectToDelete(objList, ds.deps[kind])
+		kindObjsToDelete, err := ds.listObjectToDelete(objList, ds.deps[kind])
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		errs = append(errs, deleteObjects(ctx, k8sClient, objsToDelete)...)
+		if len(kindObjsToDelete) > 0 {
+			objsToDelete[kind] = kindObjsToDelete
+		}
 	}
 
-	return errs
+	return objsToDelete, errs
 }
 
 // GetVersionInfo returns the Kubernetes version
@@ -399,6 +470,10 @@ func buildID(ns, name string) string {
 	return fmt.Sprintf("%s/%s", ns, name)
 }
 
+func buildFeatureKey(kind kubernetes.ObjectKind, id string) string {
+	return fmt.Sprintf("%s/%s", kind, id)
+}
+
 func buildObjectKey(key string) types.NamespacedName {
 	keySplit := strings.Split(key, string(types.Separator))
 	var ns, name string
@@ -423,6 +498,8 @@ func shouldSetOwnerReference(kind kubernetes.ObjectKind, objNamespace, ownerName
 		return false
 	case kubernetes.APIServiceKind:
 		return false
+	case kubernetes.MutatingWebhookConfigurationsKind:
+		return false
 	}
 
 	// Owner-reference should not be added to namespaced resources in a different namespace than the owner
@@ -391,6 +391,40 @@ func TestStore_Apply(t *testing.T) {
 	}
 }
 
+func TestStore_FeatureApplyErrors(t *testing.T) {
+	ds := NewStore(nil, &StoreOptions{Logger: logf.Log.WithName(t.Name())})
+
+	ds.SetCurrentFeature("foo")
+	ds.AddOrUpdate(kubernetes.ConfigMapKind, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}})
+	ds.SetCurrentFeature("")
+	ds.AddOrUpdate(kubernetes.ConfigMapKind, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "no-feature"}})
+
+	// the ConfigMap kind isn't registered in the client scheme, every object fails
+	errs := ds.Apply(context.TODO(), fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build())
+	assert.Len(t, errs, 2)
+	assert.Len(t, ds.FeatureApplyErrors("foo"), 1)
+	assert.True(t, runtime.IsNotRegisteredError(ds.FeatureApplyErrors("foo")[0]))
+	assert.Empty(t, ds.FeatureApplyErrors("bar"))
+
+	// the errors are reset by the next Apply
+	errs = ds.Apply(context.TODO(), fake.NewClientBuilder().Build())
+	assert.Empty(t, errs)
+	assert.Empty(t, ds.FeatureApplyErrors("foo"))
+}
+
+func TestStore_Objects(t *testing.T) {
+	ds := NewStore(nil, &StoreOptions{Logger: logf.Log.WithName(t.Name())})
+	assert.Empty(t, ds.Objects(kubernetes.ConfigMapKind))
+
+	ds.AddOrUpdate(kubernetes.ConfigMapKind, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}})
+	ds.AddOrUpdate(kubernetes.SecretsKind, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}})
+
+	objs := ds.Objects(kubernetes.ConfigMapKind)
+	assert.Len(t, objs, 1)
+	assert.Equal(t, "foo", objs[0].GetName())
+	assert.IsType(t, &corev1.ConfigMap{}, objs[0])
+}
+
 func TestStore_Cleanup(t *testing.T) {
 	dummyConfigMap1 := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,273 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/pmezard/go-difflib/difflib"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/equality"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// DiffAction is the action the operator would take on an object
+type DiffAction string
+
+const (
+	// DiffActionCreate the object doesn't exist and would be created
+	DiffActionCreate DiffAction = "create"
+	// DiffActionUpdate the object exists and would be updated
+	DiffActionUpdate DiffAction = "update"
+	// DiffActionDelete the object exists and would be garbage collected
+	DiffActionDelete DiffAction = "delete"
+)
+
+// ObjectDiff describes the change the operator would apply to an object
+type ObjectDiff struct {
+	Action    DiffAction
+	Kind      string
+	Namespace string
+	Name      string
+	// Diff is a unified diff between the live and the desired objects, it is only set on updates.
+	Diff string
+}
+
+// Diff renders a DatadogAgent and compares the rendered objects with the live objects, it returns the objects
+// the operator would create, update or garbage collect. The unchanged objects are not returned.
+// When the DatadogAgent exists in the cluster, its status is used to render it, so that the generated values
+// (like the Cluster Agent token) are the live ones.
+func Diff(ctx context.Context, liveClient client.Client, dda *datadoghqv2alpha1.DatadogAgent, options RenderOptions) ([]ObjectDiff, error) {
+	instance := dda.DeepCopy()
+	if instance.Namespace == "" {
+		instance.Namespace = corev1.NamespaceDefault
+	}
+	liveDDA := &datadoghqv2alpha1.DatadogAgent{}
+	if err := liveClient.Get(ctx, client.ObjectKeyFromObject(instance), liveDDA); err == nil {
+		liveDDA.Status.DeepCopyInto(&instance.Status)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get DatadogAgent %s/%s: %w", instance.Namespace, instance.Name, err)
+	}
+
+	rendered, instance, err := render(ctx, instance, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s := liveClient.Scheme()
+	var diffs []ObjectDiff
+	store := dependencies.NewStore(instance, &dependencies.StoreOptions{
+		SupportCilium: options.ReconcilerOptions.SupportCilium,
+		VersionInfo:   options.PlatformInfo.GetVersionInfo(),
+		PlatformInfo:  options.PlatformInfo,
+		Logger:        options.Logger,
+		Scheme:        s,
+	})
+	desiredWorkloads := map[string]bool{}
+	for _, r := range rendered {
+		if r.kind == "" {
+			desiredWorkloads[objectID(r.obj)] = true
+		} else if err = store.AddOrUpdate(r.kind, r.obj); err != nil {
+			return nil, err
+		}
+
+		objDiff, err := diffObject(ctx, liveClient, r)
+		if err != nil {
+			return nil, err
+		}
+		if objDiff != nil {
+			diffs = append(diffs, *objDiff)
+		}
+	}
+
+	objsToDelete, errs := store.ListObjectsToDelete(ctx, liveClient)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unable to list the objects to delete: %v", errs)
+	}
+	for kind, objs := range objsToDelete {
+		// The objects to delete only contain their metadata, their kind is the one of the store.
+		gvk, err := apiutil.GVKForObject(kubernetes.ObjectFromKind(kind, options.PlatformInfo), s)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			diffs = append(diffs, newObjectDiff(DiffActionDelete, obj))
+		}
+	}
+
+	workloadsToDelete, err := listWorkloadsToDelete(ctx, liveClient, s, instance, options.ReconcilerOptions, desiredWorkloads)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range workloadsToDelete {
+		diffs = append(diffs, newObjectDiff(DiffActionDelete, obj))
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs, nil
+}
+
+// diffObject compares a rendered object with the live one, it returns nil when the object is unchanged
+func diffObject(ctx context.Context, liveClient client.Client, r renderedObject) (*ObjectDiff, error) {
+	live, ok := r.obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy %s %s", r.obj.GetObjectKind().GroupVersionKind().Kind, objectID(r.obj))
+	}
+	if err := liveClient.Get(ctx, client.ObjectKeyFromObject(r.obj), live); err != nil {
+		if apierrors.IsNotFound(err) {
+			objDiff := newObjectDiff(DiffActionCreate, r.obj)
+			return &objDiff, nil
+		}
+		return nil, fmt.Errorf("unable to get %s %s: %w", r.obj.GetObjectKind().GroupVersionKind().Kind, objectID(r.obj), err)
+	}
+	live.GetObjectKind().SetGroupVersionKind(r.obj.GetObjectKind().GroupVersionKind())
+
+	desired := r.obj
+	if r.kind == "" {
+		// The workloads are compared like in the reconcile loop, with the hash of their spec.
+		hash := desired.GetAnnotations()[apicommon.MD5AgentDeploymentAnnotationKey]
+		if comparison.IsSameSpecMD5Hash(hash, live.GetAnnotations()) {
+			return nil, nil
+		}
+	} else {
+		// The cluster IPs are immutable, they are kept by the updates.
+		if r.kind == kubernetes.ServicesKind {
+			desired.(*corev1.Service).Spec.ClusterIP = live.(*corev1.Service).Spec.ClusterIP
+			desired.(*corev1.Service).Spec.ClusterIPs = live.(*corev1.Service).Spec.ClusterIPs
+		}
+		if equality.IsEqualObject(r.kind, desired, live) {
+			return nil, nil
+		}
+	}
+
+	diff, err := unifiedDiff(live, desired)
+	if err != nil {
+		return nil, err
+	}
+	if diff == "" {
+		// The update would not change the object, e.g. the Secrets are always updated.
+		return nil, nil
+	}
+	objDiff := newObjectDiff(DiffActionUpdate, desired)
+	objDiff.Diff = diff
+	return &objDiff, nil
+}
+
+// listWorkloadsToDelete returns the live DaemonSets, ExtendedDaemonSets and Deployments of the DatadogAgent
+// that are not rendered anymore
+func listWorkloadsToDelete(ctx context.Context, liveClient client.Client, s *runtime.Scheme, dda *datadoghqv2alpha1.DatadogAgent, options ReconcilerOptions, desired map[string]bool) ([]client.Object, error) {
+	lists := []client.ObjectList{&appsv1.DaemonSetList{}, &appsv1.DeploymentList{}}
+	if options.ExtendedDaemonsetOptions.Enabled {
+		lists = append(lists, &edsv1alpha1.ExtendedDaemonSetList{})
+	}
+
+	var objsToDelete []client.Object
+	for _, objList := range lists {
+		if err := liveClient.List(ctx, objList, client.InNamespace(dda.Namespace), client.MatchingLabels{apicommon.AgentDeploymentNameLabelKey: dda.Name}); err != nil {
+			return nil, fmt.Errorf("unable to list the live workloads: %w", err)
+		}
+		items, err := apimeta.ExtractList(objList)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if gvk, err := apiutil.GVKForObject(obj, s); err == nil {
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+			}
+			if desired[objectID(obj)] {
+				continue
+			}
+			objsToDelete = append(objsToDelete, obj)
+		}
+	}
+	return objsToDelete, nil
+}
+
+// unifiedDiff returns the unified diff between the YAML representations of the live and desired objects
+func unifiedDiff(live, desired client.Object) (string, error) {
+	liveYAML, err := sanitizedYAML(live)
+	if err != nil {
+		return "", err
+	}
+	desiredYAML, err := sanitizedYAML(desired)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(desiredYAML),
+		FromFile: "live",
+		ToFile:   "desired",
+		Context:  3,
+	})
+}
+
+// sanitizedYAML marshals an object without the fields set by the api-server
+func sanitizedYAML(original client.Object) (string, error) {
+	obj, ok := original.DeepCopyObject().(client.Object)
+	if !ok {
+		return "", fmt.Errorf("unable to copy %s", objectID(original))
+	}
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(data, "status")
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func newObjectDiff(action DiffAction, obj client.Object) ObjectDiff {
+	return ObjectDiff{
+		Action:    action,
+		Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+func objectID(obj client.Object) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+}
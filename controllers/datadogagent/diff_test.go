@@ -0,0 +1,149 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestDiff(t *testing.T) {
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build()
+	dda.Namespace = "default"
+	// a fixed token keeps the rendered objects stable between two renders
+	dda.Spec.Global.ClusterAgentToken = apiutils.NewStringPointer("0123456789abcdef0123456789abcdef")
+
+	platformInfo, err := kubernetes.NewPlatformInfoFromVersion("v1.25.3")
+	require.NoError(t, err)
+	options := RenderOptions{
+		PlatformInfo: platformInfo,
+		Logger:       logf.Log.WithName(t.Name()),
+	}
+	rendered, err := Render(context.TODO(), dda, options)
+	require.NoError(t, err)
+
+	liveObjects := func(update func(obj client.Object) client.Object) []client.Object {
+		objs := []client.Object{}
+		for _, obj := range rendered {
+			if obj = update(obj.DeepCopyObject().(client.Object)); obj != nil {
+				objs = append(objs, obj)
+			}
+		}
+		return objs
+	}
+	unchanged := func(obj client.Object) client.Object { return obj }
+
+	staleService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-stale",
+			Namespace: "default",
+			Labels: map[string]string{
+				"operator.datadoghq.com/managed-by-store": "true",
+				kubernetes.AppKubernetesPartOfLabelKey:    object.NewPartOfLabelValue(dda).String(),
+			},
+		},
+	}
+	staleDaemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-agent-gke-cos",
+			Namespace: "default",
+			Labels:    map[string]string{apicommon.AgentDeploymentNameLabelKey: "foo"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		live []client.Object
+		want map[string]DiffAction
+	}{
+		{
+			name: "up to date",
+			live: liveObjects(unchanged),
+			want: map[string]DiffAction{},
+		},
+		{
+			name: "new DatadogAgent",
+			want: map[string]DiffAction{
+				"DaemonSet/default/foo-agent":          DiffActionCreate,
+				"Deployment/default/foo-cluster-agent": DiffActionCreate,
+				"ServiceAccount/default/foo-agent":     DiffActionCreate,
+			},
+		},
+		{
+			name: "updated objects",
+			live: liveObjects(func(obj client.Object) client.Object {
+				switch o := obj.(type) {
+				case *corev1.ServiceAccount:
+					if o.Name == "foo-agent" {
+						return nil
+					}
+				case *appsv1.DaemonSet:
+					o.Annotations[apicommon.MD5AgentDeploymentAnnotationKey] = "outdated"
+				case *corev1.Service:
+					if o.Name == "foo-cluster-agent" {
+						o.Spec.Ports = nil
+					}
+				}
+				return obj
+			}),
+			want: map[string]DiffAction{
+				"DaemonSet/default/foo-agent":       DiffActionUpdate,
+				"Service/default/foo-cluster-agent": DiffActionUpdate,
+				"ServiceAccount/default/foo-agent":  DiffActionCreate,
+			},
+		},
+		{
+			name: "garbage collected objects",
+			live: append(liveObjects(unchanged), staleService, staleDaemonSet),
+			want: map[string]DiffAction{
+				"Service/default/foo-stale":           DiffActionDelete,
+				"DaemonSet/default/foo-agent-gke-cos": DiffActionDelete,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			liveClient := fake.NewClientBuilder().WithScheme(renderScheme()).WithObjects(tt.live...).Build()
+
+			diffs, err := Diff(context.TODO(), liveClient, dda, options)
+			require.NoError(t, err)
+
+			got := map[string]DiffAction{}
+			for _, diff := range diffs {
+				id := diff.Kind + "/" + diff.Namespace + "/" + diff.Name
+				got[id] = diff.Action
+				if diff.Action == DiffActionUpdate {
+					assert.Contains(t, diff.Diff, "--- live\n+++ desired\n", id)
+				} else {
+					assert.Empty(t, diff.Diff, id)
+				}
+			}
+			if len(tt.live) > 0 {
+				assert.Equal(t, tt.want, got)
+				return
+			}
+			for id, action := range tt.want {
+				assert.Equal(t, action, got[id], id)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	componentdca "github.com/DataDog/datadog-operator/controllers/datadogagent/component/clusteragent"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,13 +34,28 @@ type admissionControllerFeature struct {
 	agentCommunicationMode string
 	localServiceName       string
 	failurePolicy          string
+	timeoutSeconds         *int32
+	namespaceSelector      *metav1.LabelSelector
+	objectSelector         *metav1.LabelSelector
+	injectConfig           *bool
+	injectTags             *bool
+	injectLibraries        *bool
+
+	// manageWebhookConfiguration is true when the Operator manages the MutatingWebhookConfiguration
+	// instead of the Cluster Agent.
+	manageWebhookConfiguration bool
+	operatorNamespace          string
 
 	serviceAccountName string
 	owner              metav1.Object
 }
 
 func buildAdmissionControllerFeature(options *feature.Options) feature.Feature {
-	return &admissionControllerFeature{}
+	admissionControllerFeat := &admissionControllerFeature{}
+	if options != nil {
+		admissionControllerFeat.operatorNamespace = options.OperatorNamespace
+	}
+	return admissionControllerFeat
 }
 
 // ID returns the ID of the Feature
@@ -83,6 +99,13 @@ func (f *admissionControllerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqC
 		if ac.WebhookName != nil {
 			f.webhookName = *ac.WebhookName
 		}
+		f.timeoutSeconds = ac.TimeoutSeconds
+		f.namespaceSelector = ac.NamespaceSelector
+		f.objectSelector = ac.ObjectSelector
+		f.injectConfig = ac.InjectConfig
+		f.injectTags = ac.InjectTags
+		f.injectLibraries = ac.InjectLibraries
+		f.manageWebhookConfiguration = true
 	}
 	return reqComp
 }
@@ -128,13 +151,26 @@ func (f *admissionControllerFeature) ManageDependencies(managers feature.Resourc
 		return err
 	}
 
+	// webhook configuration
+	if f.manageWebhookConfiguration {
+		if err := managers.Store().AddOrUpdate(kubernetes.MutatingWebhookConfigurationsKind, f.buildMutatingWebhookConfiguration()); err != nil {
+			return err
+		}
+	}
+
 	// rbac
-	if err := managers.RBACManager().AddClusterPolicyRules(ns, rbacName, f.serviceAccountName, getRBACClusterPolicyRules(f.webhookName)); err != nil {
+	if err := managers.RBACManager().AddClusterPolicyRules(ns, rbacName, f.serviceAccountName, getRBACClusterPolicyRules(f.webhookName, !f.manageWebhookConfiguration, f.lookupPodOwners())); err != nil {
 		return err
 	}
 	return managers.RBACManager().AddPolicyRules(ns, rbacName, f.serviceAccountName, getRBACPolicyRules())
 }
 
+// lookupPodOwners returns whether the Cluster Agent needs to read the workloads owning the pods,
+// to inject their tags or the APM libraries.
+func (f *admissionControllerFeature) lookupPodOwners() bool {
+	return f.injectTags == nil || *f.injectTags || f.injectLibraries == nil || *f.injectLibraries
+}
+
 func (f *admissionControllerFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
 	managers.EnvVar().AddEnvVarToContainer(common.ClusterAgentContainerName, &corev1.EnvVar{
 		Name:  apicommon.DDAdmissionControllerEnabled,
@@ -177,6 +213,27 @@ func (f *admissionControllerFeature) ManageClusterAgent(managers feature.PodTemp
 		Value: f.webhookName,
 	})
 
+	if f.injectConfig != nil {
+		managers.EnvVar().AddEnvVarToContainer(common.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAdmissionControllerInjectConfig,
+			Value: apiutils.BoolToString(f.injectConfig),
+		})
+	}
+
+	if f.injectTags != nil {
+		managers.EnvVar().AddEnvVarToContainer(common.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAdmissionControllerInjectTags,
+			Value: apiutils.BoolToString(f.injectTags),
+		})
+	}
+
+	if f.injectLibraries != nil {
+		managers.EnvVar().AddEnvVarToContainer(common.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAdmissionControllerAutoInstrumentation,
+			Value: apiutils.BoolToString(f.injectLibraries),
+		})
+	}
+
 	return nil
 }
 
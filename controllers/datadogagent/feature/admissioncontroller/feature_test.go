@@ -13,13 +13,18 @@ import (
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAdmissionControllerFeature(t *testing.T) {
@@ -48,7 +53,12 @@ func TestAdmissionControllerFeature(t *testing.T) {
 			Name:          "v1alpha1 admission controller enabled",
 			DDAv1:         newV1Agent(true),
 			WantConfigure: true,
-			ClusterAgent:  testDCAResources("hostip"),
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				// The Cluster Agent manages the webhook configuration
+				_, found := store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				assert.False(t, found, "Shouldn't have created the MutatingWebhookConfiguration")
+			},
+			ClusterAgent: testDCAResources("hostip"),
 		},
 
 		//////////////////////////
@@ -77,6 +87,79 @@ func TestAdmissionControllerFeature(t *testing.T) {
 			WantConfigure: true,
 			ClusterAgent:  testDCAResources("socket"),
 		},
+		{
+			Name:          "v2alpha1 admission controller enabled, inject tags disabled",
+			DDAv2:         newV2AgentWithInjectTags(false),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				// The workloads owning the pods are still read to inject the APM libraries
+				obj, found := store.Get(kubernetes.ClusterRolesKind, "", "-cluster-agent")
+				if !assert.True(t, found, "Should have created the Cluster Agent ClusterRole") {
+					return
+				}
+				assert.Equal(t, getRBACClusterPolicyRules("datadog-webhook", false, true), obj.(*rbacv1.ClusterRole).Rules)
+
+				obj, found = store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				if !assert.True(t, found, "Should have created the MutatingWebhookConfiguration") {
+					return
+				}
+				webhooks := obj.(*admissionregistrationv1.MutatingWebhookConfiguration).Webhooks
+				if !assert.Len(t, webhooks, 2) {
+					return
+				}
+				assert.Equal(t, injectConfigWebhookName, webhooks[0].Name)
+				assert.Equal(t, injectLibrariesWebhookName, webhooks[1].Name)
+			},
+			ClusterAgent: testDCAResources("", &corev1.EnvVar{
+				Name:  apicommon.DDAdmissionControllerInjectTags,
+				Value: "false",
+			}),
+		},
+		{
+			Name:          "v2alpha1 admission controller enabled, selectors and mutations",
+			DDAv2:         newV2AgentWithSelectorsAndMutations(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.ClusterRolesKind, "", "-cluster-agent")
+				if !assert.True(t, found, "Should have created the Cluster Agent ClusterRole") {
+					return
+				}
+				assert.Equal(t, getRBACClusterPolicyRules("datadog-webhook", false, false), obj.(*rbacv1.ClusterRole).Rules)
+
+				obj, found = store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				if !assert.True(t, found, "Should have created the MutatingWebhookConfiguration") {
+					return
+				}
+				webhooks := obj.(*admissionregistrationv1.MutatingWebhookConfiguration).Webhooks
+				if !assert.Len(t, webhooks, 1) {
+					return
+				}
+				webhook := webhooks[0]
+				assert.Equal(t, injectConfigWebhookName, webhook.Name)
+				assert.Equal(t, "testServiceName", webhook.ClientConfig.Service.Name)
+				assert.Equal(t, injectConfigWebhookPath, *webhook.ClientConfig.Service.Path)
+				assert.Equal(t, admissionregistrationv1.Fail, *webhook.FailurePolicy)
+				assert.Equal(t, int32(5), *webhook.TimeoutSeconds)
+				assert.Equal(t, &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "web"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+					},
+				}, webhook.NamespaceSelector)
+				assert.Equal(t, &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: metav1.LabelSelectorOpExists},
+						{Key: apicommon.AgentDeploymentComponentLabelKey, Operator: metav1.LabelSelectorOpDoesNotExist},
+					},
+				}, webhook.ObjectSelector)
+			},
+			ClusterAgent: testDCAResources("",
+				&corev1.EnvVar{Name: apicommon.DDAdmissionControllerFailurePolicy, Value: "Fail"},
+				&corev1.EnvVar{Name: apicommon.DDAdmissionControllerInjectConfig, Value: "true"},
+				&corev1.EnvVar{Name: apicommon.DDAdmissionControllerInjectTags, Value: "false"},
+				&corev1.EnvVar{Name: apicommon.DDAdmissionControllerAutoInstrumentation, Value: "false"},
+			),
+		},
 	}
 
 	tests.Run(t, buildAdmissionControllerFeature)
@@ -124,7 +207,32 @@ func newV2Agent(enabled bool, acm string, apm *v2alpha1.APMFeatureConfig, dsd *v
 	return dda
 }
 
-func testDCAResources(acm string) *test.ComponentTest {
+func newV2AgentWithInjectTags(injectTags bool) *v2alpha1.DatadogAgent {
+	dda := newV2Agent(true, "", &v2alpha1.APMFeatureConfig{}, &v2alpha1.DogstatsdFeatureConfig{})
+	dda.Spec.Features.AdmissionController.InjectTags = apiutils.NewBoolPointer(injectTags)
+	return dda
+}
+
+func newV2AgentWithSelectorsAndMutations() *v2alpha1.DatadogAgent {
+	dda := newV2Agent(true, "", &v2alpha1.APMFeatureConfig{}, &v2alpha1.DogstatsdFeatureConfig{})
+	ac := dda.Spec.Features.AdmissionController
+	ac.FailurePolicy = apiutils.NewStringPointer("Fail")
+	ac.TimeoutSeconds = apiutils.NewInt32Pointer(5)
+	ac.NamespaceSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "web"},
+	}
+	ac.ObjectSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpExists},
+		},
+	}
+	ac.InjectConfig = apiutils.NewBoolPointer(true)
+	ac.InjectTags = apiutils.NewBoolPointer(false)
+	ac.InjectLibraries = apiutils.NewBoolPointer(false)
+	return dda
+}
+
+func testDCAResources(acm string, extraEnvs ...*corev1.EnvVar) *test.ComponentTest {
 	return test.NewDefaultComponentTest().WithWantFunc(
 		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
 			mgr := mgrInterface.(*fake.PodTemplateManagers)
@@ -159,6 +267,7 @@ func testDCAResources(acm string) *test.ComponentTest {
 				}
 				expectedAgentEnvs = append(expectedAgentEnvs, &acmEnv)
 			}
+			expectedAgentEnvs = append(expectedAgentEnvs, extraEnvs...)
 
 			assert.ElementsMatch(t,
 				agentEnvs,
@@ -12,27 +12,53 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 )
 
-func getRBACClusterPolicyRules(webhookName string) []rbacv1.PolicyRule {
-	return []rbacv1.PolicyRule{
-		// MutatingWebhooksConfigs
-		{
-			APIGroups: []string{rbac.AdmissionAPIGroup},
-			Resources: []string{rbac.MutatingConfigResource},
-			Verbs: []string{
-				rbac.CreateVerb,
+// getRBACClusterPolicyRules returns the ClusterRole rules of the Cluster Agent. It only updates the
+// MutatingWebhookConfiguration when the Operator doesn't manage it, otherwise it only reads it.
+func getRBACClusterPolicyRules(webhookName string, updateWebhookConfiguration, lookupPodOwners bool) []rbacv1.PolicyRule {
+	var rules []rbacv1.PolicyRule
+	if updateWebhookConfiguration {
+		rules = []rbacv1.PolicyRule{
+			// MutatingWebhooksConfigs
+			{
+				APIGroups: []string{rbac.AdmissionAPIGroup},
+				Resources: []string{rbac.MutatingConfigResource},
+				Verbs: []string{
+					rbac.CreateVerb,
+				},
 			},
-		},
-		{
-			APIGroups:     []string{rbac.AdmissionAPIGroup},
-			Resources:     []string{rbac.MutatingConfigResource},
-			ResourceNames: []string{webhookName},
-			Verbs: []string{
-				rbac.GetVerb,
-				rbac.ListVerb,
-				rbac.WatchVerb,
-				rbac.UpdateVerb,
+			{
+				APIGroups:     []string{rbac.AdmissionAPIGroup},
+				Resources:     []string{rbac.MutatingConfigResource},
+				ResourceNames: []string{webhookName},
+				Verbs: []string{
+					rbac.GetVerb,
+					rbac.ListVerb,
+					rbac.WatchVerb,
+					rbac.UpdateVerb,
+				},
 			},
-		},
+		}
+	} else {
+		rules = []rbacv1.PolicyRule{
+			// MutatingWebhooksConfigs
+			{
+				APIGroups:     []string{rbac.AdmissionAPIGroup},
+				Resources:     []string{rbac.MutatingConfigResource},
+				ResourceNames: []string{webhookName},
+				Verbs: []string{
+					rbac.GetVerb,
+					rbac.ListVerb,
+					rbac.WatchVerb,
+				},
+			},
+		}
+	}
+	if !lookupPodOwners {
+		return rules
+	}
+
+	// Workloads owning the pods
+	return append(rules, []rbacv1.PolicyRule{
 		// ExtendedDaemonsetReplicaSets
 		{
 			APIGroups: []string{extendeddaemonset.GroupVersion.Group},
@@ -72,7 +98,7 @@ func getRBACClusterPolicyRules(webhookName string) []rbacv1.PolicyRule {
 				rbac.GetVerb,
 			},
 		},
-	}
+	}...)
 }
 
 func getRBACPolicyRules() []rbacv1.PolicyRule {
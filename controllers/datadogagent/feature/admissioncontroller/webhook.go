@@ -0,0 +1,147 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+)
+
+const (
+	defaultWebhookFailurePolicy  = admissionregistrationv1.Ignore
+	defaultWebhookTimeoutSeconds = int32(10)
+
+	injectConfigWebhookName    = "datadog.webhook.agent.config"
+	injectConfigWebhookPath    = "/injectconfig"
+	injectTagsWebhookName      = "datadog.webhook.standard.tags"
+	injectTagsWebhookPath      = "/injecttags"
+	injectLibrariesWebhookName = "datadog.webhook.lib.injection"
+	injectLibrariesWebhookPath = "/injectlib"
+)
+
+// buildMutatingWebhookConfiguration returns the MutatingWebhookConfiguration of the webhooks served by the Cluster Agent,
+// with one webhook per enabled mutation. The CA bundle is set during the reconcile, from the Cluster Agent certificate.
+func (f *admissionControllerFeature) buildMutatingWebhookConfiguration() *admissionregistrationv1.MutatingWebhookConfiguration {
+	webhooks := []admissionregistrationv1.MutatingWebhook{}
+	if f.injectConfig == nil || *f.injectConfig {
+		webhooks = append(webhooks, f.buildWebhook(injectConfigWebhookName, injectConfigWebhookPath))
+	}
+	if f.injectTags == nil || *f.injectTags {
+		webhooks = append(webhooks, f.buildWebhook(injectTagsWebhookName, injectTagsWebhookPath))
+	}
+	if f.injectLibraries == nil || *f.injectLibraries {
+		webhooks = append(webhooks, f.buildWebhook(injectLibrariesWebhookName, injectLibrariesWebhookPath))
+	}
+
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: f.webhookName,
+		},
+		Webhooks: webhooks,
+	}
+}
+
+// buildWebhook returns a webhook mutating the created pods. The defaults of the API server are set
+// so that the configuration isn't updated on every reconcile.
+func (f *admissionControllerFeature) buildWebhook(name, path string) admissionregistrationv1.MutatingWebhook {
+	failurePolicy := defaultWebhookFailurePolicy
+	if f.failurePolicy != "" {
+		failurePolicy = admissionregistrationv1.FailurePolicyType(f.failurePolicy)
+	}
+	timeoutSeconds := defaultWebhookTimeoutSeconds
+	if f.timeoutSeconds != nil {
+		timeoutSeconds = *f.timeoutSeconds
+	}
+	matchPolicy := admissionregistrationv1.Equivalent
+	reinvocationPolicy := admissionregistrationv1.NeverReinvocationPolicy
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	scope := admissionregistrationv1.AllScopes
+
+	return admissionregistrationv1.MutatingWebhook{
+		Name: name,
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: f.owner.GetNamespace(),
+				Name:      f.serviceName,
+				Path:      apiutils.NewStringPointer(path),
+				Port:      apiutils.NewInt32Pointer(apicommon.DefaultAdmissionControllerServicePort),
+			},
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+					Scope:       &scope,
+				},
+			},
+		},
+		FailurePolicy:           &failurePolicy,
+		MatchPolicy:             &matchPolicy,
+		NamespaceSelector:       f.buildNamespaceSelector(),
+		ObjectSelector:          f.buildObjectSelector(),
+		SideEffects:             &sideEffects,
+		TimeoutSeconds:          &timeoutSeconds,
+		AdmissionReviewVersions: []string{"v1", "v1beta1"},
+		ReinvocationPolicy:      &reinvocationPolicy,
+	}
+}
+
+// buildNamespaceSelector returns the namespace selector set by the user, excluding kube-system and the namespace of the Operator.
+func (f *admissionControllerFeature) buildNamespaceSelector() *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{}
+	if f.namespaceSelector != nil {
+		selector = f.namespaceSelector.DeepCopy()
+	}
+
+	excludedNamespaces := []string{common.KubeSystemResourceName}
+	if f.operatorNamespace != "" && f.operatorNamespace != common.KubeSystemResourceName {
+		excludedNamespaces = append(excludedNamespaces, f.operatorNamespace)
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      corev1.LabelMetadataName,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   excludedNamespaces,
+	})
+	return selector
+}
+
+// buildObjectSelector returns the object selector set by the user, or the selection with the `admission.datadoghq.com/enabled`
+// pod label, excluding the pods of the Datadog components: the Agents must be able to start when the Cluster Agent isn't available.
+func (f *admissionControllerFeature) buildObjectSelector() *metav1.LabelSelector {
+	var selector *metav1.LabelSelector
+	switch {
+	case f.objectSelector != nil:
+		selector = f.objectSelector.DeepCopy()
+	case f.mutateUnlabelled:
+		selector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      apicommon.AdmissionControllerEnabledLabelKey,
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"false"},
+				},
+			},
+		}
+	default:
+		selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{apicommon.AdmissionControllerEnabledLabelKey: "true"},
+		}
+	}
+
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      apicommon.AgentDeploymentComponentLabelKey,
+		Operator: metav1.LabelSelectorOpDoesNotExist,
+	})
+	return selector
+}
@@ -0,0 +1,129 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+)
+
+func Test_buildNamespaceSelector(t *testing.T) {
+	tests := []struct {
+		name              string
+		operatorNamespace string
+		namespaceSelector *metav1.LabelSelector
+		want              *metav1.LabelSelector
+	}{
+		{
+			name: "operator namespace unknown",
+			want: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+				},
+			},
+		},
+		{
+			name:              "operator namespace excluded",
+			operatorNamespace: "datadog",
+			want: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system", "datadog"}},
+				},
+			},
+		},
+		{
+			name:              "operator in kube-system",
+			operatorNamespace: "kube-system",
+			want: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+				},
+			},
+		},
+		{
+			name:              "user selector",
+			operatorNamespace: "datadog",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"web"}},
+				},
+			},
+			want: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"web"}},
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system", "datadog"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &admissionControllerFeature{
+				operatorNamespace: tt.operatorNamespace,
+				namespaceSelector: tt.namespaceSelector,
+			}
+			assert.Equal(t, tt.want, f.buildNamespaceSelector())
+			if tt.namespaceSelector != nil {
+				assert.Len(t, tt.namespaceSelector.MatchExpressions, 1, "The user selector shouldn't be modified")
+			}
+		})
+	}
+}
+
+func Test_buildObjectSelector(t *testing.T) {
+	excludeComponents := metav1.LabelSelectorRequirement{
+		Key:      apicommon.AgentDeploymentComponentLabelKey,
+		Operator: metav1.LabelSelectorOpDoesNotExist,
+	}
+
+	tests := []struct {
+		name             string
+		mutateUnlabelled bool
+		objectSelector   *metav1.LabelSelector
+		want             *metav1.LabelSelector
+	}{
+		{
+			name: "labelled pods",
+			want: &metav1.LabelSelector{
+				MatchLabels:      map[string]string{apicommon.AdmissionControllerEnabledLabelKey: "true"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{excludeComponents},
+			},
+		},
+		{
+			name:             "unlabelled pods",
+			mutateUnlabelled: true,
+			want: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: apicommon.AdmissionControllerEnabledLabelKey, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"false"}},
+					excludeComponents,
+				},
+			},
+		},
+		{
+			name:             "user selector",
+			mutateUnlabelled: true,
+			objectSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			want: &metav1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "web"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{excludeComponents},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &admissionControllerFeature{
+				mutateUnlabelled: tt.mutateUnlabelled,
+				objectSelector:   tt.objectSelector,
+			}
+			assert.Equal(t, tt.want, f.buildObjectSelector())
+		})
+	}
+}
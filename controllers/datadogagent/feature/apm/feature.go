@@ -6,8 +6,10 @@
 package apm
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -54,6 +56,16 @@ type apmFeature struct {
 
 	createKubernetesNetworkPolicy bool
 	createCiliumNetworkPolicy     bool
+
+	singleStepInstrumentation *instrumentationConfig
+}
+
+// instrumentationConfig contains the APM library injection settings configured in the Cluster Agent.
+type instrumentationConfig struct {
+	enabled            bool
+	enabledNamespaces  []string
+	disabledNamespaces []string
+	libVersions        map[string]string
 }
 
 // ID returns the ID of the Feature
@@ -97,11 +109,29 @@ func (f *apmFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.Requ
 				},
 			},
 		}
+
+		// library injection is done by the Admission Controller of the Cluster Agent
+		if v2alpha1.IsSingleStepInstrumentationEnabled(apm) && isAdmissionControllerEnabled(dda) {
+			ssi := apm.SingleStepInstrumentation
+			f.singleStepInstrumentation = &instrumentationConfig{
+				enabled:            apiutils.BoolValue(ssi.Enabled),
+				enabledNamespaces:  ssi.EnabledNamespaces,
+				disabledNamespaces: ssi.DisabledNamespaces,
+				libVersions:        ssi.LibVersions,
+			}
+			reqComp.ClusterAgent = feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)}
+		}
 	}
 
 	return reqComp
 }
 
+// isAdmissionControllerEnabled returns whether the Admission Controller is enabled. It's enabled by default.
+func isAdmissionControllerEnabled(dda *v2alpha1.DatadogAgent) bool {
+	ac := dda.Spec.Features.AdmissionController
+	return ac == nil || ac.Enabled == nil || *ac.Enabled
+}
+
 // ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
 func (f *apmFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	f.owner = dda
@@ -228,6 +258,40 @@ func (f *apmFeature) ManageDependencies(managers feature.ResourceManagers, compo
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *apmFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	if f.singleStepInstrumentation == nil {
+		return nil
+	}
+
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.ClusterAgentContainerName, &corev1.EnvVar{
+		Name:  apicommon.DDAPMInstrumentationEnabled,
+		Value: apiutils.BoolToString(&f.singleStepInstrumentation.enabled),
+	})
+
+	if len(f.singleStepInstrumentation.enabledNamespaces) > 0 {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMInstrumentationEnabledNamespaces,
+			Value: strings.Join(f.singleStepInstrumentation.enabledNamespaces, " "),
+		})
+	}
+
+	if len(f.singleStepInstrumentation.disabledNamespaces) > 0 {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMInstrumentationDisabledNamespaces,
+			Value: strings.Join(f.singleStepInstrumentation.disabledNamespaces, " "),
+		})
+	}
+
+	if len(f.singleStepInstrumentation.libVersions) > 0 {
+		libVersions, err := json.Marshal(f.singleStepInstrumentation.libVersions)
+		if err != nil {
+			return err
+		}
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMInstrumentationLibVersions,
+			Value: string(libVersions),
+		})
+	}
+
 	return nil
 }
 
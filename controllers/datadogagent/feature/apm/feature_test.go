@@ -11,6 +11,7 @@ import (
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
 	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
@@ -110,6 +111,58 @@ func TestAPMFeature(t *testing.T) {
 			WantConfigure: true,
 			Agent:         testAgentHostPortUDS(apicommonv1.UnprivilegedSingleAgentContainerName),
 		},
+		{
+			Name: "v2alpha1 apm enabled, library injection in all namespaces",
+			DDAv2: v2alpha1test.NewDatadogAgentBuilder().
+				WithAPMEnabled(true).
+				WithAPMHostPortEnabled(false, 8126).
+				WithAPMUDSEnabled(true, apmSocketHostPath).
+				WithAPMSingleStepInstrumentation(&v2alpha1.SingleStepInstrumentation{
+					Enabled:            apiutils.NewBoolPointer(true),
+					DisabledNamespaces: []string{"kube-system", "datadog"},
+					LibVersions:        map[string]string{"java": "v1.31.0", "python": "v2"},
+				}).
+				Build(),
+			WantConfigure: true,
+			Agent:         testAgentUDSOnly(apicommonv1.TraceAgentContainerName),
+			ClusterAgent: testDCASingleStepInstrumentation([]*corev1.EnvVar{
+				{
+					Name:  apicommon.DDAPMInstrumentationEnabled,
+					Value: "true",
+				},
+				{
+					Name:  apicommon.DDAPMInstrumentationDisabledNamespaces,
+					Value: "kube-system datadog",
+				},
+				{
+					Name:  apicommon.DDAPMInstrumentationLibVersions,
+					Value: `{"java":"v1.31.0","python":"v2"}`,
+				},
+			}),
+		},
+		{
+			Name: "v2alpha1 apm enabled, library injection in some namespaces",
+			DDAv2: v2alpha1test.NewDatadogAgentBuilder().
+				WithAPMEnabled(true).
+				WithAPMHostPortEnabled(false, 8126).
+				WithAPMUDSEnabled(true, apmSocketHostPath).
+				WithAPMSingleStepInstrumentation(&v2alpha1.SingleStepInstrumentation{
+					EnabledNamespaces: []string{"default"},
+				}).
+				Build(),
+			WantConfigure: true,
+			Agent:         testAgentUDSOnly(apicommonv1.TraceAgentContainerName),
+			ClusterAgent: testDCASingleStepInstrumentation([]*corev1.EnvVar{
+				{
+					Name:  apicommon.DDAPMInstrumentationEnabled,
+					Value: "false",
+				},
+				{
+					Name:  apicommon.DDAPMInstrumentationEnabledNamespaces,
+					Value: "default",
+				},
+			}),
+		},
 	}
 
 	tests.Run(t, buildAPMFeature)
@@ -328,3 +381,18 @@ func testAgentHostPortUDS(agentContainerName apicommonv1.AgentContainerName) *te
 		},
 	)
 }
+
+func testDCASingleStepInstrumentation(want []*corev1.EnvVar) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			dcaEnvs := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.ClusterAgentContainerName]
+			assert.True(
+				t,
+				apiutils.IsEqualStruct(dcaEnvs, want),
+				"Cluster Agent envvars \ndiff = %s", cmp.Diff(dcaEnvs, want),
+			)
+		},
+	)
+}
@@ -7,6 +7,8 @@ package enabledefault
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
@@ -21,10 +23,12 @@ import (
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 	"github.com/DataDog/datadog-operator/pkg/version"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 )
@@ -65,6 +69,7 @@ type defaultFeature struct {
 	clusterAgent        clusterAgentConfig
 	agent               agentConfig
 	clusterChecksRunner clusterChecksRunnerConfig
+	secretBackend       *v2alpha1.SecretBackendConfig
 	logger              logr.Logger
 
 	customConfigAnnotationKey   string
@@ -146,6 +151,8 @@ func (f *defaultFeature) Configure(dda *v2alpha1.DatadogAgent) feature.RequiredC
 			}
 		}
 
+		f.secretBackend = dda.Spec.Global.SecretBackend
+
 		// DCA Token management
 		f.dcaTokenInfo.token.SecretName = v2alpha1.GetDefaultDCATokenSecretName(dda)
 		f.dcaTokenInfo.token.SecretKey = apicommon.DefaultTokenKey
@@ -296,6 +303,11 @@ func (f *defaultFeature) ManageDependencies(managers feature.ResourceManagers, c
 		}
 	}
 
+	// Secret backend RBAC, shared by the components
+	if err := f.secretBackendDependencies(managers, f.secretBackendServiceAccounts(components)); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errors.NewAggregate(errs)
 }
 
@@ -374,6 +386,81 @@ func (f *defaultFeature) clusterChecksRunnerDependencies(managers feature.Resour
 	return errors.NewAggregate(errs)
 }
 
+// secretBackendServiceAccounts returns the ServiceAccounts of the enabled components, which run the secret backend.
+func (f *defaultFeature) secretBackendServiceAccounts(components feature.RequiredComponents) []string {
+	var serviceAccounts []string
+	if components.Agent.IsEnabled() && f.agent.serviceAccountName != "" {
+		serviceAccounts = append(serviceAccounts, f.agent.serviceAccountName)
+	}
+	if components.ClusterAgent.IsEnabled() && f.clusterAgent.serviceAccountName != "" {
+		serviceAccounts = append(serviceAccounts, f.clusterAgent.serviceAccountName)
+	}
+	if components.ClusterChecksRunner.IsEnabled() && f.clusterChecksRunner.serviceAccountName != "" {
+		serviceAccounts = append(serviceAccounts, f.clusterChecksRunner.serviceAccountName)
+	}
+	return serviceAccounts
+}
+
+// secretBackendDependencies grants the ServiceAccounts read access to the Secrets used by the secret backend:
+// to all of them with a ClusterRole, or to the listed ones with a Role in their namespace. The rules are added
+// with the first ServiceAccount, the other ones are only bound to the roles.
+func (f *defaultFeature) secretBackendDependencies(managers feature.ResourceManagers, serviceAccountNames []string) error {
+	if f.secretBackend == nil || len(serviceAccountNames) == 0 {
+		return nil
+	}
+
+	var errs []error
+	roleName := component.GetSecretBackendRoleName(f.owner)
+	if apiutils.BoolValue(f.secretBackend.EnableGlobalPermissions) {
+		if err := managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), roleName, serviceAccountNames[0], getSecretBackendPolicyRules(nil)); err != nil {
+			errs = append(errs, err)
+		}
+		roleRef := rbacv1.RoleRef{
+			APIGroup: rbac.RbacAPIGroup,
+			Kind:     rbac.ClusterRoleKind,
+			Name:     roleName,
+		}
+		for _, serviceAccountName := range serviceAccountNames[1:] {
+			if err := managers.RBACManager().AddClusterRoleBinding(f.owner.GetNamespace(), roleName, serviceAccountName, roleRef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, role := range f.secretBackend.Roles {
+		if role == nil || apiutils.StringValue(role.Namespace) == "" || len(role.Secrets) == 0 {
+			continue
+		}
+		if err := managers.RBACManager().AddPolicyRulesForServiceAccount(*role.Namespace, roleName, f.owner.GetNamespace(), serviceAccountNames[0], getSecretBackendPolicyRules(role.Secrets)); err != nil {
+			errs = append(errs, err)
+		}
+		roleRef := rbacv1.RoleRef{
+			APIGroup: rbac.RbacAPIGroup,
+			Kind:     rbac.RoleKind,
+			Name:     roleName,
+		}
+		for _, serviceAccountName := range serviceAccountNames[1:] {
+			if err := managers.RBACManager().AddRoleBinding(*role.Namespace, roleName, f.owner.GetNamespace(), serviceAccountName, roleRef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.NewAggregate(errs)
+}
+
+// getSecretBackendPolicyRules returns the rules allowing to read the given Secrets, or all the Secrets if none is given.
+func getSecretBackendPolicyRules(secrets []string) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{rbac.CoreAPIGroup},
+			Resources:     []string{rbac.SecretsResource},
+			ResourceNames: secrets,
+			Verbs:         []string{rbac.GetVerb},
+		},
+	}
+}
+
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *defaultFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
@@ -416,6 +503,10 @@ func (f *defaultFeature) ManageClusterChecksRunner(managers feature.PodTemplateM
 }
 
 func (f *defaultFeature) addDefaultCommonEnvs(managers feature.PodTemplateManagers) {
+	if f.secretBackend != nil {
+		f.addSecretBackendEnvs(managers)
+	}
+
 	if f.dcaTokenInfo.token.SecretName != "" {
 		tokenEnvVar := component.BuildEnvVarFromSource(apicommon.DDClusterAgentAuthToken, component.BuildEnvVarFromSecret(f.dcaTokenInfo.token.SecretName, f.dcaTokenInfo.token.SecretKey))
 		managers.EnvVar().AddEnvVar(tokenEnvVar)
@@ -432,6 +523,30 @@ func (f *defaultFeature) addDefaultCommonEnvs(managers feature.PodTemplateManage
 	}
 }
 
+// addSecretBackendEnvs configures the secret backend resolving the `ENC[]` handles of the Agent configuration.
+func (f *defaultFeature) addSecretBackendEnvs(managers feature.PodTemplateManagers) {
+	if command := apiutils.StringValue(f.secretBackend.Command); command != "" {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDSecretBackendCommand,
+			Value: command,
+		})
+	}
+
+	if len(f.secretBackend.Args) > 0 {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDSecretBackendArguments,
+			Value: strings.Join(f.secretBackend.Args, " "),
+		})
+	}
+
+	if f.secretBackend.Timeout != nil {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDSecretBackendTimeout,
+			Value: strconv.Itoa(int(*f.secretBackend.Timeout)),
+		})
+	}
+}
+
 func buildInstallInfoConfigMap(dda metav1.Object) *corev1.ConfigMap {
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package enabledefault
+
+import (
+	"strings"
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestDefaultFeatureSecretBackend(t *testing.T) {
+	requiredComponents := feature.RequiredComponents{
+		Agent:        feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+		ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+	}
+	secretBackend := &v2alpha1.SecretBackendConfig{
+		Command: apiutils.NewStringPointer("/readsecret_multiple_providers.sh"),
+		Args:    []string{"--timeout", "10"},
+		Timeout: apiutils.NewInt32Pointer(60),
+		Roles: []*v2alpha1.SecretBackendRolesConfig{
+			{Namespace: apiutils.NewStringPointer("secrets"), Secrets: []string{"db-password", "redis"}},
+		},
+	}
+
+	tests := test.FeatureTestSuite{
+		{
+			Name:               "v2alpha1 no secret backend",
+			DDAv2:              v2alpha1test.NewInitializedDatadogAgentBuilder("default", "datadog").Build(),
+			WantConfigure:      true,
+			RequiredComponents: requiredComponents,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				_, found := store.Get(kubernetes.ClusterRolesKind, "", "datadog-secret-reader")
+				assert.False(t, found, "Shouldn't have created the secret backend ClusterRole")
+			},
+			Agent: testSecretBackendEnvs(nil),
+		},
+		{
+			Name: "v2alpha1 secret backend with roles",
+			DDAv2: v2alpha1test.NewInitializedDatadogAgentBuilder("default", "datadog").
+				WithGlobalSecretBackend(secretBackend).
+				Build(),
+			WantConfigure:      true,
+			RequiredComponents: requiredComponents,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				_, found := store.Get(kubernetes.ClusterRolesKind, "", "datadog-secret-reader")
+				assert.False(t, found, "Shouldn't have created the secret backend ClusterRole")
+
+				obj, found := store.Get(kubernetes.RolesKind, "secrets", "datadog-secret-reader")
+				if !assert.True(t, found, "Should have created the secret backend Role") {
+					return
+				}
+				assert.Equal(t, []rbacv1.PolicyRule{
+					{
+						APIGroups:     []string{""},
+						Resources:     []string{"secrets"},
+						ResourceNames: []string{"db-password", "redis"},
+						Verbs:         []string{"get"},
+					},
+				}, obj.(*rbacv1.Role).Rules)
+
+				obj, found = store.Get(kubernetes.RoleBindingKind, "secrets", "datadog-secret-reader")
+				if !assert.True(t, found, "Should have created the secret backend RoleBinding") {
+					return
+				}
+				assert.ElementsMatch(t, []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "datadog-agent", Namespace: "default"},
+					{Kind: "ServiceAccount", Name: "datadog-cluster-agent", Namespace: "default"},
+				}, obj.(*rbacv1.RoleBinding).Subjects)
+			},
+			Agent: testSecretBackendEnvs([]*corev1.EnvVar{
+				{Name: apicommon.DDSecretBackendCommand, Value: "/readsecret_multiple_providers.sh"},
+				{Name: apicommon.DDSecretBackendArguments, Value: "--timeout 10"},
+				{Name: apicommon.DDSecretBackendTimeout, Value: "60"},
+			}),
+		},
+		{
+			Name: "v2alpha1 secret backend with global permissions",
+			DDAv2: v2alpha1test.NewInitializedDatadogAgentBuilder("default", "datadog").
+				WithGlobalSecretBackend(&v2alpha1.SecretBackendConfig{
+					Command:                 apiutils.NewStringPointer("/readsecret_multiple_providers.sh"),
+					EnableGlobalPermissions: apiutils.NewBoolPointer(true),
+				}).
+				Build(),
+			WantConfigure:      true,
+			RequiredComponents: requiredComponents,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.ClusterRolesKind, "", "datadog-secret-reader")
+				if !assert.True(t, found, "Should have created the secret backend ClusterRole") {
+					return
+				}
+				assert.Equal(t, []rbacv1.PolicyRule{
+					{
+						APIGroups: []string{""},
+						Resources: []string{"secrets"},
+						Verbs:     []string{"get"},
+					},
+				}, obj.(*rbacv1.ClusterRole).Rules)
+
+				obj, found = store.Get(kubernetes.ClusterRoleBindingKind, "", "datadog-secret-reader")
+				if !assert.True(t, found, "Should have created the secret backend ClusterRoleBinding") {
+					return
+				}
+				assert.ElementsMatch(t, []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "datadog-agent", Namespace: "default"},
+					{Kind: "ServiceAccount", Name: "datadog-cluster-agent", Namespace: "default"},
+				}, obj.(*rbacv1.ClusterRoleBinding).Subjects)
+			},
+			Agent: testSecretBackendEnvs([]*corev1.EnvVar{
+				{Name: apicommon.DDSecretBackendCommand, Value: "/readsecret_multiple_providers.sh"},
+			}),
+		},
+	}
+
+	tests.Run(t, buildDefaultFeature)
+}
+
+func testSecretBackendEnvs(want []*corev1.EnvVar) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			var got []*corev1.EnvVar
+			for _, envVar := range mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers] {
+				if strings.HasPrefix(envVar.Name, "DD_SECRET_BACKEND_") {
+					got = append(got, envVar)
+				}
+			}
+			assert.Equal(t, want, got)
+		},
+	)
+}
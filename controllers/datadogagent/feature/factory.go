@@ -31,15 +31,16 @@ func Register(id IDType, buildFunc BuildFunc) error {
 	return nil
 }
 
-// BuildFeatures use to build a list features depending of the v2alpha1.DatadogAgent instance
-func BuildFeatures(dda *v2alpha1.DatadogAgent, options *Options) ([]Feature, RequiredComponents) {
+// RegisteredIDs returns the sorted IDs of the registered Features.
+func RegisteredIDs() []IDType {
 	builderMutex.RLock()
 	defer builderMutex.RUnlock()
 
-	var output []Feature
-	var requiredComponents RequiredComponents
+	return sortedIDs()
+}
 
-	// to always return in feature in the same order we need to sort the map keys
+// sortedIDs returns the sorted keys of featureBuilders, the caller must hold builderMutex.
+func sortedIDs() []IDType {
 	sortedkeys := make([]IDType, 0, len(featureBuilders))
 	for key := range featureBuilders {
 		sortedkeys = append(sortedkeys, key)
@@ -47,8 +48,28 @@ func BuildFeatures(dda *v2alpha1.DatadogAgent, options *Options) ([]Feature, Req
 	sort.Slice(sortedkeys, func(i, j int) bool {
 		return sortedkeys[i] < sortedkeys[j]
 	})
+	return sortedkeys
+}
+
+// BuildFeatures use to build a list features depending of the v2alpha1.DatadogAgent instance
+func BuildFeatures(dda *v2alpha1.DatadogAgent, options *Options) ([]Feature, RequiredComponents) {
+	return BuildFeaturesWithout(dda, options, nil)
+}
+
+// BuildFeaturesWithout use to build a list features depending of the v2alpha1.DatadogAgent instance, ignoring the features
+// whose ID is in disabledIDs. It is used to build the features of a node Agent profile.
+func BuildFeaturesWithout(dda *v2alpha1.DatadogAgent, options *Options, disabledIDs []IDType) ([]Feature, RequiredComponents) {
+	builderMutex.RLock()
+	defer builderMutex.RUnlock()
 
-	for _, id := range sortedkeys {
+	var output []Feature
+	var requiredComponents RequiredComponents
+
+	// to always return in feature in the same order we need to sort the map keys
+	for _, id := range sortedIDs() {
+		if isDisabledID(id, disabledIDs) {
+			continue
+		}
 		feat := featureBuilders[id](options)
 		reqComponents := feat.Configure(dda)
 		// only add feature to the output if one of the components is configured (but not necessarily required)
@@ -72,6 +93,15 @@ func BuildFeatures(dda *v2alpha1.DatadogAgent, options *Options) ([]Feature, Req
 	return output, requiredComponents
 }
 
+func isDisabledID(id IDType, disabledIDs []IDType) bool {
+	for _, disabledID := range disabledIDs {
+		if id == disabledID {
+			return true
+		}
+	}
+	return false
+}
+
 // BuildFeaturesV1 use to build a list features depending of the v1alpha1.DatadogAgent instance
 func BuildFeaturesV1(dda *v1alpha1.DatadogAgent, options *Options) ([]Feature, RequiredComponents) {
 	builderMutex.RLock()
@@ -81,15 +111,7 @@ func BuildFeaturesV1(dda *v1alpha1.DatadogAgent, options *Options) ([]Feature, R
 	var requiredComponents RequiredComponents
 
 	// to always return in feature in the same order we need to sort the map keys
-	sortedkeys := make([]IDType, 0, len(featureBuilders))
-	for key := range featureBuilders {
-		sortedkeys = append(sortedkeys, key)
-	}
-	sort.Slice(sortedkeys, func(i, j int) bool {
-		return sortedkeys[i] < sortedkeys[j]
-	})
-
-	for _, id := range sortedkeys {
+	for _, id := range sortedIDs() {
 		feat := featureBuilders[id](options)
 		// only add feat to the output if the feature is enabled
 		config := feat.ConfigureV1(dda)
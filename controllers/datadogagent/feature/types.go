@@ -153,6 +153,8 @@ type Feature interface {
 // Options option that can be pass to the Interface.Configure function
 type Options struct {
 	SupportExtendedDaemonset bool
+	// OperatorNamespace is the namespace the Operator is running in, empty if it's unknown.
+	OperatorNamespace string
 
 	Logger logr.Logger
 }
@@ -172,6 +174,8 @@ type ResourceManagers interface {
 	CiliumPolicyManager() merger.CiliumPolicyManager
 	ConfigMapManager() merger.ConfigMapManager
 	APIServiceManager() merger.APIServiceManager
+	PodDisruptionBudgetManager() merger.PodDisruptionBudgetManager
+	HorizontalPodAutoscalerManager() merger.HorizontalPodAutoscalerManager
 }
 
 // NewResourceManagers return new instance of the ResourceManagers interface
@@ -186,6 +190,8 @@ func NewResourceManagers(store dependencies.StoreClient) ResourceManagers {
 		cilium:        merger.NewCiliumPolicyManager(store),
 		configMap:     merger.NewConfigMapManager(store),
 		apiService:    merger.NewAPIServiceManager(store),
+		pdb:           merger.NewPodDisruptionBudgetManager(store),
+		hpa:           merger.NewHorizontalPodAutoscalerManager(store),
 	}
 }
 
@@ -199,6 +205,8 @@ type resourceManagersImpl struct {
 	cilium        merger.CiliumPolicyManager
 	configMap     merger.ConfigMapManager
 	apiService    merger.APIServiceManager
+	pdb           merger.PodDisruptionBudgetManager
+	hpa           merger.HorizontalPodAutoscalerManager
 }
 
 func (impl *resourceManagersImpl) Store() dependencies.StoreClient {
@@ -237,6 +245,14 @@ func (impl *resourceManagersImpl) APIServiceManager() merger.APIServiceManager {
 	return impl.apiService
 }
 
+func (impl *resourceManagersImpl) PodDisruptionBudgetManager() merger.PodDisruptionBudgetManager {
+	return impl.pdb
+}
+
+func (impl *resourceManagersImpl) HorizontalPodAutoscalerManager() merger.HorizontalPodAutoscalerManager {
+	return impl.hpa
+}
+
 // PodTemplateManagers used to access the different PodTemplateSpec manager.
 type PodTemplateManagers interface {
 	// PodTemplateSpec used to access directly the PodTemplateSpec.
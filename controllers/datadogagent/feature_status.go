@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/errors"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+const (
+	// featureConfiguredReason the feature dependencies are computed but not applied yet
+	featureConfiguredReason = "Configured"
+	// featureDependenciesAppliedReason the feature dependencies are applied
+	featureDependenciesAppliedReason = "DependenciesApplied"
+	// featureMissingCRDReason a feature dependency kind isn't served by the api-server
+	featureMissingCRDReason = "MissingCRD"
+	// featureMissingRBACReason the operator isn't allowed to manage a feature dependency
+	featureMissingRBACReason = "MissingRBAC"
+	// featureDegradedReason the feature failed for another reason
+	featureDegradedReason = "Degraded"
+)
+
+// featureErrors contains the errors of the enabled features, by feature ID.
+type featureErrors map[feature.IDType][]error
+
+// dependencyApplyErrors is implemented by the dependencies store to report the Apply errors of a feature.
+type dependencyApplyErrors interface {
+	FeatureApplyErrors(featureID string) []error
+}
+
+// updateFeatureStatusConditions sets a condition for each enabled feature, and removes the conditions of the features
+// that aren't enabled anymore. If store is nil, the feature dependencies haven't been applied yet.
+func updateFeatureStatusConditions(newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time, features []feature.Feature,
+	manageErrs featureErrors, store dependencyApplyErrors) {
+	enabled := make(map[string]struct{}, len(features))
+	for _, feat := range features {
+		conditionType := datadoghqv2alpha1.GetFeatureReconcileConditionType(string(feat.ID()))
+		enabled[conditionType] = struct{}{}
+
+		errs := manageErrs[feat.ID()]
+		if len(errs) == 0 && store != nil {
+			errs = store.FeatureApplyErrors(string(feat.ID()))
+		}
+
+		var status metav1.ConditionStatus
+		var reason, message string
+		switch {
+		case len(errs) > 0:
+			status = metav1.ConditionFalse
+			reason = featureErrorReason(errs)
+			message = errors.NewAggregate(errs).Error()
+		case store != nil:
+			status = metav1.ConditionTrue
+			reason = featureDependenciesAppliedReason
+			message = "Feature dependencies applied"
+		default:
+			status = metav1.ConditionTrue
+			reason = featureConfiguredReason
+			message = "Feature configured"
+		}
+		datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, now, conditionType, status, reason, message, true)
+	}
+
+	// iterate on a copy, the conditions are removed from the status
+	conditions := append([]metav1.Condition{}, newStatus.Conditions...)
+	for _, condition := range conditions {
+		if _, found := enabled[condition.Type]; !found && strings.HasPrefix(condition.Type, datadoghqv2alpha1.FeatureReconcileConditionTypePrefix) {
+			datadoghqv2alpha1.DeleteDatadogAgentStatusCondition(newStatus, condition.Type)
+		}
+	}
+}
+
+// featureErrorReason returns the condition reason matching the errors of a feature
+func featureErrorReason(errs []error) string {
+	for _, err := range errs {
+		if apimeta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return featureMissingCRDReason
+		}
+	}
+	for _, err := range errs {
+		if apierrors.IsForbidden(err) {
+			return featureMissingRBACReason
+		}
+	}
+	return featureDegradedReason
+}
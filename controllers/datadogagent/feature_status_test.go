@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+type fakeDependencyApplyErrors map[string][]error
+
+func (f fakeDependencyApplyErrors) FeatureApplyErrors(featureID string) []error {
+	return f[featureID]
+}
+
+func Test_updateFeatureStatusConditions(t *testing.T) {
+	dda := v2alpha1test.NewDatadogAgentBuilder().
+		WithAPMEnabled(true).
+		WithNPMEnabled(true).
+		BuildWithDefaults()
+	features, _ := feature.BuildFeatures(dda, &feature.Options{Logger: logf.Log.WithName(t.Name())})
+
+	apmType := datadoghqv2alpha1.GetFeatureReconcileConditionType(feature.APMIDType)
+	npmType := datadoghqv2alpha1.GetFeatureReconcileConditionType(feature.NPMIDType)
+	cwsType := datadoghqv2alpha1.GetFeatureReconcileConditionType(feature.CWSIDType)
+
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "clusterroles"}, "foo", fmt.Errorf("forbidden"))
+	noMatch := &apimeta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "cilium.io", Kind: "CiliumNetworkPolicy"}}
+
+	tests := []struct {
+		name        string
+		conditions  []metav1.Condition
+		manageErrs  featureErrors
+		store       dependencyApplyErrors
+		wantReasons map[string]string
+		wantAbsent  []string
+	}{
+		{
+			name:        "dependencies not applied yet",
+			wantReasons: map[string]string{apmType: featureConfiguredReason, npmType: featureConfiguredReason},
+		},
+		{
+			name:        "dependencies applied",
+			store:       fakeDependencyApplyErrors{},
+			wantReasons: map[string]string{apmType: featureDependenciesAppliedReason, npmType: featureDependenciesAppliedReason},
+		},
+		{
+			name:        "ManageDependencies error",
+			manageErrs:  featureErrors{feature.APMIDType: {fmt.Errorf("boom")}},
+			store:       fakeDependencyApplyErrors{},
+			wantReasons: map[string]string{apmType: featureDegradedReason, npmType: featureDependenciesAppliedReason},
+		},
+		{
+			name:        "missing CRD and RBAC",
+			store:       fakeDependencyApplyErrors{string(feature.APMIDType): {forbidden}, string(feature.NPMIDType): {noMatch}},
+			wantReasons: map[string]string{apmType: featureMissingRBACReason, npmType: featureMissingCRDReason},
+		},
+		{
+			name:        "condition of a disabled feature is removed",
+			conditions:  []metav1.Condition{{Type: cwsType, Status: metav1.ConditionTrue}, {Type: datadoghqv2alpha1.AgentReconcileConditionType}},
+			store:       fakeDependencyApplyErrors{},
+			wantReasons: map[string]string{apmType: featureDependenciesAppliedReason, npmType: featureDependenciesAppliedReason},
+			wantAbsent:  []string{cwsType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &datadoghqv2alpha1.DatadogAgentStatus{Conditions: tt.conditions}
+			updateFeatureStatusConditions(status, metav1.Now(), features, tt.manageErrs, tt.store)
+
+			reasons := map[string]string{}
+			for _, condition := range status.Conditions {
+				reasons[condition.Type] = condition.Reason
+				if condition.Reason == featureConfiguredReason || condition.Reason == featureDependenciesAppliedReason {
+					assert.Equal(t, metav1.ConditionTrue, condition.Status)
+				} else if _, found := tt.wantReasons[condition.Type]; found {
+					assert.Equal(t, metav1.ConditionFalse, condition.Status)
+				}
+			}
+			for conditionType, reason := range tt.wantReasons {
+				assert.Equal(t, reason, reasons[conditionType], conditionType)
+			}
+			for _, conditionType := range tt.wantAbsent {
+				assert.NotContains(t, reasons, conditionType)
+			}
+			if len(tt.conditions) > 0 {
+				assert.Contains(t, reasons, datadoghqv2alpha1.AgentReconcileConditionType)
+			}
+		})
+	}
+}
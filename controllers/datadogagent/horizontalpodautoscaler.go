@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+const (
+	hpaDefaultMinReplicas                    = 1
+	hpaDefaultTargetCPUUtilizationPercentage = 80
+)
+
+// addHorizontalPodAutoscalerV2 adds the HorizontalPodAutoscaler of a v2alpha1 component Deployment to the dependencies store
+// if autoscaling is enabled in the component override. In that case, the Deployment replicas are unset so that the
+// reconciler keeps the value set by the HorizontalPodAutoscaler.
+// Otherwise, the HorizontalPodAutoscaler isn't added to the store and an existing one is removed by Store.Cleanup.
+func addHorizontalPodAutoscalerV2(manager feature.ResourceManagers, deployment *appsv1.Deployment, hpaName string, componentOverride *datadoghqv2alpha1.DatadogAgentComponentOverride) error {
+	if componentOverride == nil || componentOverride.Autoscaling == nil || !apiutils.BoolValue(componentOverride.Autoscaling.Enabled) {
+		return nil
+	}
+	autoscaling := componentOverride.Autoscaling
+
+	deployment.Spec.Replicas = nil
+
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       deployment.Name,
+		},
+		MinReplicas: apiutils.NewInt32Pointer(hpaDefaultMinReplicas),
+		Metrics:     buildHPAMetrics(autoscaling),
+	}
+	if autoscaling.MinReplicas != nil {
+		spec.MinReplicas = apiutils.NewInt32Pointer(*autoscaling.MinReplicas)
+	}
+	if autoscaling.MaxReplicas != nil {
+		spec.MaxReplicas = *autoscaling.MaxReplicas
+	}
+
+	return manager.HorizontalPodAutoscalerManager().AddHorizontalPodAutoscaler(hpaName, deployment.Namespace, spec)
+}
+
+func buildHPAMetrics(autoscaling *datadoghqv2alpha1.AutoscalingConfig) []autoscalingv2.MetricSpec {
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, buildHPAResourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, buildHPAResourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+
+	if external := autoscaling.ExternalMetric; external != nil {
+		metric := autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: external.Name,
+				},
+			},
+		}
+		if len(external.Labels) > 0 {
+			metric.External.Metric.Selector = &metav1.LabelSelector{MatchLabels: external.Labels}
+		}
+		if external.TargetAverageValue != nil {
+			metric.External.Target = autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: external.TargetAverageValue,
+			}
+		} else {
+			metric.External.Target = autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: external.TargetValue,
+			}
+		}
+		metrics = append(metrics, metric)
+	}
+
+	if len(metrics) == 0 {
+		metrics = append(metrics, buildHPAResourceMetric(corev1.ResourceCPU, hpaDefaultTargetCPUUtilizationPercentage))
+	}
+
+	return metrics
+}
+
+func buildHPAResourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: apiutils.NewInt32Pointer(utilization),
+			},
+		},
+	}
+}
@@ -0,0 +1,135 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_addHorizontalPodAutoscalerV2(t *testing.T) {
+	targetValue := resource.MustParse("10")
+
+	tests := []struct {
+		name            string
+		override        *datadoghqv2alpha1.DatadogAgentComponentOverride
+		wantHPA         bool
+		wantMinReplicas int32
+		wantMaxReplicas int32
+		wantMetrics     []autoscalingv2.MetricSpec
+	}{
+		{
+			name:    "no override",
+			wantHPA: false,
+		},
+		{
+			name: "autoscaling disabled",
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				Autoscaling: &datadoghqv2alpha1.AutoscalingConfig{
+					Enabled:     apiutils.NewBoolPointer(false),
+					MaxReplicas: apiutils.NewInt32Pointer(5),
+				},
+			},
+			wantHPA: false,
+		},
+		{
+			name: "default cpu target",
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				Autoscaling: &datadoghqv2alpha1.AutoscalingConfig{
+					Enabled:     apiutils.NewBoolPointer(true),
+					MaxReplicas: apiutils.NewInt32Pointer(5),
+				},
+			},
+			wantHPA:         true,
+			wantMinReplicas: 1,
+			wantMaxReplicas: 5,
+			wantMetrics: []autoscalingv2.MetricSpec{
+				buildHPAResourceMetric(corev1.ResourceCPU, 80),
+			},
+		},
+		{
+			name: "memory and external metric targets",
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				Autoscaling: &datadoghqv2alpha1.AutoscalingConfig{
+					Enabled:                           apiutils.NewBoolPointer(true),
+					MinReplicas:                       apiutils.NewInt32Pointer(2),
+					MaxReplicas:                       apiutils.NewInt32Pointer(10),
+					TargetMemoryUtilizationPercentage: apiutils.NewInt32Pointer(60),
+					ExternalMetric: &datadoghqv2alpha1.AutoscalingExternalMetricConfig{
+						Name:        "datadog.cluster_checks.pending",
+						Labels:      map[string]string{"cluster": "foo"},
+						TargetValue: &targetValue,
+					},
+				},
+			},
+			wantHPA:         true,
+			wantMinReplicas: 2,
+			wantMaxReplicas: 10,
+			wantMetrics: []autoscalingv2.MetricSpec{
+				buildHPAResourceMetric(corev1.ResourceMemory, 60),
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name:     "datadog.cluster_checks.pending",
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"cluster": "foo"}},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: &targetValue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testScheme := runtime.NewScheme()
+			testScheme.AddKnownTypes(datadoghqv2alpha1.GroupVersion, &datadoghqv2alpha1.DatadogAgent{})
+			owner := &datadoghqv2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}}
+			store := dependencies.NewStore(owner, &dependencies.StoreOptions{Scheme: testScheme})
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo-cluster-checks-runner"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: apiutils.NewInt32Pointer(1),
+				},
+			}
+
+			err := addHorizontalPodAutoscalerV2(feature.NewResourceManagers(store), deployment, "foo-cluster-checks-runner", tt.override)
+			assert.NoError(t, err)
+
+			obj, found := store.Get(kubernetes.HorizontalPodAutoscalersKind, "bar", "foo-cluster-checks-runner")
+			assert.Equal(t, tt.wantHPA, found)
+			if !tt.wantHPA {
+				assert.Equal(t, apiutils.NewInt32Pointer(1), deployment.Spec.Replicas)
+				return
+			}
+			// The replicas are managed by the HorizontalPodAutoscaler.
+			assert.Nil(t, deployment.Spec.Replicas)
+
+			hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+			assert.Equal(t, autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo-cluster-checks-runner"}, hpa.Spec.ScaleTargetRef)
+			assert.Equal(t, &tt.wantMinReplicas, hpa.Spec.MinReplicas)
+			assert.Equal(t, tt.wantMaxReplicas, hpa.Spec.MaxReplicas)
+			assert.Equal(t, tt.wantMetrics, hpa.Spec.Metrics)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// HorizontalPodAutoscalerManager is used to manage HorizontalPodAutoscaler resources.
+type HorizontalPodAutoscalerManager interface {
+	AddHorizontalPodAutoscaler(name, namespace string, spec autoscalingv2.HorizontalPodAutoscalerSpec) error
+}
+
+// NewHorizontalPodAutoscalerManager returns a new HorizontalPodAutoscalerManager instance
+func NewHorizontalPodAutoscalerManager(store dependencies.StoreClient) HorizontalPodAutoscalerManager {
+	manager := &horizontalPodAutoscalerManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// horizontalPodAutoscalerManagerImpl is used to manage HorizontalPodAutoscaler resources.
+type horizontalPodAutoscalerManagerImpl struct {
+	store dependencies.StoreClient
+}
+
+// AddHorizontalPodAutoscaler creates or updates a HorizontalPodAutoscaler.
+// The autoscaling/v2 or autoscaling/v2beta2 version is used depending on the version served by the api-server.
+func (m *horizontalPodAutoscalerManagerImpl) AddHorizontalPodAutoscaler(name, namespace string, spec autoscalingv2.HorizontalPodAutoscalerSpec) error {
+	obj, _ := m.store.GetOrCreate(kubernetes.HorizontalPodAutoscalersKind, namespace, name)
+	switch hpa := obj.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		hpa.Spec = spec
+	case *autoscalingv2beta2.HorizontalPodAutoscaler:
+		// The autoscaling/v2beta2 spec has the same fields as the autoscaling/v2 one.
+		v2beta2Spec := autoscalingv2beta2.HorizontalPodAutoscalerSpec{}
+		if err := convertHPASpec(&spec, &v2beta2Spec); err != nil {
+			return fmt.Errorf("unable to convert the HorizontalPodAutoscaler %s/%s spec to autoscaling/v2beta2: %w", namespace, name, err)
+		}
+		hpa.Spec = v2beta2Spec
+	default:
+		return fmt.Errorf("unable to get from the store the HorizontalPodAutoscaler %s/%s", namespace, name)
+	}
+
+	return m.store.AddOrUpdate(kubernetes.HorizontalPodAutoscalersKind, obj)
+}
+
+func convertHPASpec(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestHorizontalPodAutoscalerManager_AddHorizontalPodAutoscaler(t *testing.T) {
+	ns := "bar"
+	name := "foo"
+
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       name,
+		},
+		MinReplicas: apiutils.NewInt32Pointer(1),
+		MaxReplicas: 5,
+		Metrics: []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: apiutils.NewInt32Pointer(80),
+					},
+				},
+			},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		platformInfo kubernetes.PlatformInfo
+		validateFunc func(*testing.T, *dependencies.Store)
+	}{
+		{
+			name:         "autoscaling/v2",
+			platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{"HorizontalPodAutoscaler": "autoscaling/v2"}, nil),
+			validateFunc: func(t *testing.T, store *dependencies.Store) {
+				obj, found := store.Get(kubernetes.HorizontalPodAutoscalersKind, ns, name)
+				assert.True(t, found)
+				hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+				assert.True(t, ok)
+				assert.Equal(t, spec, hpa.Spec)
+			},
+		},
+		{
+			name:         "autoscaling/v2beta2",
+			platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{"HorizontalPodAutoscaler": "autoscaling/v2beta2"}, nil),
+			validateFunc: func(t *testing.T, store *dependencies.Store) {
+				obj, found := store.Get(kubernetes.HorizontalPodAutoscalersKind, ns, name)
+				assert.True(t, found)
+				hpa, ok := obj.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+				assert.True(t, ok)
+				assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+				assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+				assert.Len(t, hpa.Spec.Metrics, 1)
+				assert.Equal(t, autoscalingv2beta2.ResourceMetricSourceType, hpa.Spec.Metrics[0].Type)
+				assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+				assert.Equal(t, apiutils.NewInt32Pointer(80), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := dependencies.NewStore(owner, &dependencies.StoreOptions{
+				Scheme:       testScheme,
+				PlatformInfo: tt.platformInfo,
+			})
+			m := NewHorizontalPodAutoscalerManager(store)
+
+			err := m.AddHorizontalPodAutoscaler(name, ns, spec)
+			assert.NoError(t, err)
+			tt.validateFunc(t, store)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudgetManager is used to manage PodDisruptionBudget resources.
+type PodDisruptionBudgetManager interface {
+	AddPodDisruptionBudget(name, namespace string, selector *metav1.LabelSelector, minAvailable, maxUnavailable *intstr.IntOrString) error
+}
+
+// NewPodDisruptionBudgetManager returns a new PodDisruptionBudgetManager instance
+func NewPodDisruptionBudgetManager(store dependencies.StoreClient) PodDisruptionBudgetManager {
+	manager := &podDisruptionBudgetManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// podDisruptionBudgetManagerImpl is used to manage PodDisruptionBudget resources.
+type podDisruptionBudgetManagerImpl struct {
+	store dependencies.StoreClient
+}
+
+// AddPodDisruptionBudget creates or updates a PodDisruptionBudget.
+// The policy/v1 or policy/v1beta1 version is used depending on the version served by the api-server.
+func (m *podDisruptionBudgetManagerImpl) AddPodDisruptionBudget(name, namespace string, selector *metav1.LabelSelector, minAvailable, maxUnavailable *intstr.IntOrString) error {
+	obj, _ := m.store.GetOrCreate(kubernetes.PodDisruptionBudgetsKind, namespace, name)
+	switch pdb := obj.(type) {
+	case *policyv1.PodDisruptionBudget:
+		pdb.Spec.Selector = selector
+		pdb.Spec.MinAvailable = minAvailable
+		pdb.Spec.MaxUnavailable = maxUnavailable
+	case *policyv1beta1.PodDisruptionBudget:
+		pdb.Spec.Selector = selector
+		pdb.Spec.MinAvailable = minAvailable
+		pdb.Spec.MaxUnavailable = maxUnavailable
+	default:
+		return fmt.Errorf("unable to get from the store the PodDisruptionBudget %s/%s", namespace, name)
+	}
+
+	return m.store.AddOrUpdate(kubernetes.PodDisruptionBudgetsKind, obj)
+}
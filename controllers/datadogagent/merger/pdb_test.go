@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPodDisruptionBudgetManager_AddPodDisruptionBudget(t *testing.T) {
+	ns := "bar"
+	name := "foo"
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			kubernetes.AppKubernetesInstanceLabelKey: "cluster-agent",
+		},
+	}
+	minAvailable := intstr.FromInt(1)
+	maxUnavailable := intstr.FromString("50%")
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		platformInfo   kubernetes.PlatformInfo
+		minAvailable   *intstr.IntOrString
+		maxUnavailable *intstr.IntOrString
+		validateFunc   func(*testing.T, *dependencies.Store)
+	}{
+		{
+			name:         "policy/v1",
+			platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{"PodDisruptionBudget": "policy/v1"}, nil),
+			minAvailable: &minAvailable,
+			validateFunc: func(t *testing.T, store *dependencies.Store) {
+				obj, found := store.Get(kubernetes.PodDisruptionBudgetsKind, ns, name)
+				assert.True(t, found)
+				pdb, ok := obj.(*policyv1.PodDisruptionBudget)
+				assert.True(t, ok)
+				assert.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+				assert.Nil(t, pdb.Spec.MaxUnavailable)
+				assert.Equal(t, selector, pdb.Spec.Selector)
+			},
+		},
+		{
+			name:           "policy/v1beta1",
+			platformInfo:   kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{"PodDisruptionBudget": "policy/v1beta1"}, nil),
+			maxUnavailable: &maxUnavailable,
+			validateFunc: func(t *testing.T, store *dependencies.Store) {
+				obj, found := store.Get(kubernetes.PodDisruptionBudgetsKind, ns, name)
+				assert.True(t, found)
+				pdb, ok := obj.(*policyv1beta1.PodDisruptionBudget)
+				assert.True(t, ok)
+				assert.Nil(t, pdb.Spec.MinAvailable)
+				assert.Equal(t, &maxUnavailable, pdb.Spec.MaxUnavailable)
+				assert.Equal(t, selector, pdb.Spec.Selector)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := dependencies.NewStore(owner, &dependencies.StoreOptions{
+				Scheme:       testScheme,
+				PlatformInfo: tt.platformInfo,
+			})
+			m := NewPodDisruptionBudgetManager(store)
+
+			err := m.AddPodDisruptionBudget(name, ns, selector, tt.minAvailable, tt.maxUnavailable)
+			assert.NoError(t, err)
+			tt.validateFunc(t, store)
+		})
+	}
+}
@@ -23,6 +23,7 @@ type RBACManager interface {
 	AddServiceAccountByComponent(namespace, name, component string) error
 	AddPolicyRules(namespace string, roleName string, saName string, policies []rbacv1.PolicyRule) error
 	AddPolicyRulesByComponent(namespace string, roleName string, saName string, policies []rbacv1.PolicyRule, component string) error
+	AddPolicyRulesForServiceAccount(roleNamespace, roleName, saNamespace, saName string, policies []rbacv1.PolicyRule) error
 	AddRoleBinding(roleNamespace, roleName, saNamespace, saName string, roleRef rbacv1.RoleRef) error
 	AddClusterPolicyRules(namespace string, roleName string, saName string, policies []rbacv1.PolicyRule) error
 	AddClusterPolicyRulesByComponent(namespace string, roleName string, saName string, policies []rbacv1.PolicyRule, component string) error
@@ -88,10 +89,16 @@ func (m *rbacManagerImpl) DeleteServiceAccountByComponent(component, namespace s
 
 // AddPolicyRules is used to add PolicyRules to a Role. It also creates the RoleBinding.
 func (m *rbacManagerImpl) AddPolicyRules(namespace string, roleName string, saName string, policies []rbacv1.PolicyRule) error {
-	obj, _ := m.store.GetOrCreate(kubernetes.RolesKind, namespace, roleName)
+	return m.AddPolicyRulesForServiceAccount(namespace, roleName, namespace, saName, policies)
+}
+
+// AddPolicyRulesForServiceAccount is used to add PolicyRules to a Role, and to bind it to a ServiceAccount
+// that can be in another namespace.
+func (m *rbacManagerImpl) AddPolicyRulesForServiceAccount(roleNamespace, roleName, saNamespace, saName string, policies []rbacv1.PolicyRule) error {
+	obj, _ := m.store.GetOrCreate(kubernetes.RolesKind, roleNamespace, roleName)
 	role, ok := obj.(*rbacv1.Role)
 	if !ok {
-		return fmt.Errorf("unable to get from the store the ClusterRole %s", roleName)
+		return fmt.Errorf("unable to get from the store the Role %s/%s", roleNamespace, roleName)
 	}
 
 	// TODO: can be improve by checking if the policies don't already existe.
@@ -106,7 +113,7 @@ func (m *rbacManagerImpl) AddPolicyRules(namespace string, roleName string, saNa
 		Name:     roleName,
 	}
 
-	return m.AddRoleBinding(namespace, roleName, namespace, saName, roleRef)
+	return m.AddRoleBinding(roleNamespace, roleName, saNamespace, saName, roleRef)
 }
 
 // AddPolicyRulesByComponent is used to add PolicyRules to a Role, create a RoleBinding, and associate them with a component
@@ -170,6 +170,53 @@ func TestRBACManager_AddPolicyRules(t *testing.T) {
 	}
 }
 
+func TestRBACManager_AddPolicyRulesForServiceAccount(t *testing.T) {
+	ns := "bar"
+	name := "foo"
+	roleNs := "secrets"
+
+	rule := rbacv1.PolicyRule{
+		Verbs:         []string{"get"},
+		Resources:     []string{"secrets"},
+		ResourceNames: []string{"db-password"},
+		APIGroups:     []string{""},
+	}
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+	store := dependencies.NewStore(owner, &dependencies.StoreOptions{Scheme: testScheme})
+
+	m := &rbacManagerImpl{
+		store: store,
+	}
+	if err := m.AddPolicyRulesForServiceAccount(roleNs, name+"role", ns, name+"sa", []rbacv1.PolicyRule{rule}); err != nil {
+		t.Fatalf("RBACManager.AddPolicyRulesForServiceAccount() error = %v", err)
+	}
+
+	obj, found := store.Get(kubernetes.RolesKind, roleNs, name+"role")
+	if !found {
+		t.Fatalf("missing Role %s/%s", roleNs, name+"role")
+	}
+	if role, ok := obj.(*rbacv1.Role); !ok || len(role.Rules) != 1 {
+		t.Errorf("unexpected Rules in Role %s/%s", roleNs, name+"role")
+	}
+
+	obj, found = store.Get(kubernetes.RoleBindingKind, roleNs, name+"role")
+	if !found {
+		t.Fatalf("missing RoleBinding %s/%s", roleNs, name+"role")
+	}
+	roleBinding, ok := obj.(*rbacv1.RoleBinding)
+	if !ok || len(roleBinding.Subjects) != 1 || roleBinding.Subjects[0].Namespace != ns || roleBinding.Subjects[0].Name != name+"sa" {
+		t.Errorf("unexpected Subjects in RoleBinding %s/%s", roleNs, name+"role")
+	}
+}
+
 func TestRBACManager_AddClusterPolicyRules(t *testing.T) {
 	name := "foo"
 	ns := "bar"
@@ -16,6 +16,10 @@ func DaemonSet(daemonSet *v1.DaemonSet, override *v2alpha1.DatadogAgentComponent
 	if override.Name != nil {
 		daemonSet.Name = *override.Name
 	}
+
+	if override.UpdateStrategy != nil {
+		daemonSetUpdateStrategy(&daemonSet.Spec.UpdateStrategy, override.UpdateStrategy)
+	}
 }
 
 // ExtendedDaemonSet overrides an ExtendedDaemonSet according to the given override options
@@ -23,4 +27,119 @@ func ExtendedDaemonSet(eds *edsv1alpha1.ExtendedDaemonSet, override *v2alpha1.Da
 	if override.Name != nil {
 		eds.Name = *override.Name
 	}
+
+	if override.UpdateStrategy != nil {
+		extendedDaemonSetStrategy(&eds.Spec.Strategy, override.UpdateStrategy)
+	}
+}
+
+func daemonSetUpdateStrategy(strategy *v1.DaemonSetUpdateStrategy, override *v2alpha1.UpdateStrategy) {
+	if override.Type != "" {
+		strategy.Type = v1.DaemonSetUpdateStrategyType(override.Type)
+	}
+
+	if strategy.Type == v1.OnDeleteDaemonSetStrategyType {
+		// The rolling update settings are rejected by the API server with the OnDelete strategy.
+		strategy.RollingUpdate = nil
+		return
+	}
+
+	if override.RollingUpdate != nil {
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &v1.RollingUpdateDaemonSet{}
+		}
+		if override.RollingUpdate.MaxUnavailable != nil {
+			strategy.RollingUpdate.MaxUnavailable = override.RollingUpdate.MaxUnavailable
+		}
+		if override.RollingUpdate.MaxSurge != nil {
+			strategy.RollingUpdate.MaxSurge = override.RollingUpdate.MaxSurge
+		}
+	}
+}
+
+// extendedDaemonSetStrategy only overrides the fields set in the override, the other
+// ones keep the values computed from the operator flags.
+func extendedDaemonSetStrategy(strategy *edsv1alpha1.ExtendedDaemonSetSpecStrategy, override *v2alpha1.UpdateStrategy) {
+	if rollingUpdate := override.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxUnavailable != nil {
+			strategy.RollingUpdate.MaxUnavailable = rollingUpdate.MaxUnavailable
+		}
+		if rollingUpdate.MaxPodSchedulerFailure != nil {
+			strategy.RollingUpdate.MaxPodSchedulerFailure = rollingUpdate.MaxPodSchedulerFailure
+		}
+		if rollingUpdate.MaxParallelPodCreation != nil {
+			strategy.RollingUpdate.MaxParallelPodCreation = rollingUpdate.MaxParallelPodCreation
+		}
+		if rollingUpdate.SlowStartIntervalDuration != nil {
+			strategy.RollingUpdate.SlowStartIntervalDuration = rollingUpdate.SlowStartIntervalDuration
+		}
+		if rollingUpdate.SlowStartAdditiveIncrease != nil {
+			strategy.RollingUpdate.SlowStartAdditiveIncrease = rollingUpdate.SlowStartAdditiveIncrease
+		}
+	}
+
+	if override.Canary != nil {
+		if strategy.Canary == nil {
+			strategy.Canary = &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanary{}
+		}
+		extendedDaemonSetCanary(strategy.Canary, override.Canary)
+	}
+
+	if override.ReconcileFrequency != nil {
+		strategy.ReconcileFrequency = override.ReconcileFrequency
+	}
+}
+
+func extendedDaemonSetCanary(canary *edsv1alpha1.ExtendedDaemonSetSpecStrategyCanary, override *edsv1alpha1.ExtendedDaemonSetSpecStrategyCanary) {
+	if override.Replicas != nil {
+		canary.Replicas = override.Replicas
+	}
+	if override.Duration != nil {
+		canary.Duration = override.Duration
+	}
+	if override.NoRestartsDuration != nil {
+		canary.NoRestartsDuration = override.NoRestartsDuration
+	}
+	if override.NodeSelector != nil {
+		canary.NodeSelector = override.NodeSelector
+	}
+	if len(override.NodeAntiAffinityKeys) > 0 {
+		canary.NodeAntiAffinityKeys = override.NodeAntiAffinityKeys
+	}
+	if override.ValidationMode != "" {
+		canary.ValidationMode = override.ValidationMode
+	}
+
+	if override.AutoPause != nil {
+		if canary.AutoPause == nil {
+			canary.AutoPause = &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{}
+		}
+		if override.AutoPause.Enabled != nil {
+			canary.AutoPause.Enabled = override.AutoPause.Enabled
+		}
+		if override.AutoPause.MaxRestarts != nil {
+			canary.AutoPause.MaxRestarts = override.AutoPause.MaxRestarts
+		}
+		if override.AutoPause.MaxSlowStartDuration != nil {
+			canary.AutoPause.MaxSlowStartDuration = override.AutoPause.MaxSlowStartDuration
+		}
+	}
+
+	if override.AutoFail != nil {
+		if canary.AutoFail == nil {
+			canary.AutoFail = &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail{}
+		}
+		if override.AutoFail.Enabled != nil {
+			canary.AutoFail.Enabled = override.AutoFail.Enabled
+		}
+		if override.AutoFail.MaxRestarts != nil {
+			canary.AutoFail.MaxRestarts = override.AutoFail.MaxRestarts
+		}
+		if override.AutoFail.MaxRestartsDuration != nil {
+			canary.AutoFail.MaxRestartsDuration = override.AutoFail.MaxRestartsDuration
+		}
+		if override.AutoFail.CanaryTimeout != nil {
+			canary.AutoFail.CanaryTimeout = override.AutoFail.CanaryTimeout
+		}
+	}
 }
@@ -0,0 +1,132 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDaemonSet(t *testing.T) {
+	maxUnavailable := intstr.FromString("10%")
+	maxSurge := intstr.FromInt(1)
+
+	tests := []struct {
+		name     string
+		override v2alpha1.DatadogAgentComponentOverride
+		want     v1.DaemonSetUpdateStrategy
+	}{
+		{
+			name:     "no update strategy",
+			override: v2alpha1.DatadogAgentComponentOverride{Name: apiutils.NewStringPointer("new-name")},
+			want:     v1.DaemonSetUpdateStrategy{Type: v1.RollingUpdateDaemonSetStrategyType},
+		},
+		{
+			name: "rolling update",
+			override: v2alpha1.DatadogAgentComponentOverride{
+				UpdateStrategy: &v2alpha1.UpdateStrategy{
+					RollingUpdate: &v2alpha1.RollingUpdate{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge},
+				},
+			},
+			want: v1.DaemonSetUpdateStrategy{
+				Type:          v1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &v1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge},
+			},
+		},
+		{
+			name: "on delete",
+			override: v2alpha1.DatadogAgentComponentOverride{
+				UpdateStrategy: &v2alpha1.UpdateStrategy{
+					Type:          string(v1.OnDeleteDaemonSetStrategyType),
+					RollingUpdate: &v2alpha1.RollingUpdate{MaxUnavailable: &maxUnavailable},
+				},
+			},
+			want: v1.DaemonSetUpdateStrategy{Type: v1.OnDeleteDaemonSetStrategyType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemonSet := v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "current-name"},
+				Spec: v1.DaemonSetSpec{
+					UpdateStrategy: v1.DaemonSetUpdateStrategy{Type: v1.RollingUpdateDaemonSetStrategyType},
+				},
+			}
+
+			DaemonSet(&daemonSet, &tt.override)
+
+			assert.Equal(t, tt.want, daemonSet.Spec.UpdateStrategy)
+			if tt.override.Name != nil {
+				assert.Equal(t, *tt.override.Name, daemonSet.Name)
+			}
+		})
+	}
+}
+
+func TestExtendedDaemonSet(t *testing.T) {
+	// Values coming from the operator flags
+	flagMaxUnavailable := intstr.FromInt(1)
+	flagCanaryReplicas := intstr.FromInt(1)
+	flagCanaryDuration := metav1.Duration{Duration: 10 * time.Minute}
+
+	maxUnavailable := intstr.FromString("20%")
+	canaryDuration := metav1.Duration{Duration: time.Hour}
+	reconcileFrequency := metav1.Duration{Duration: time.Minute}
+
+	eds := edsv1alpha1.ExtendedDaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "current-name"},
+		Spec: edsv1alpha1.ExtendedDaemonSetSpec{
+			Strategy: edsv1alpha1.ExtendedDaemonSetSpecStrategy{
+				RollingUpdate: edsv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+					MaxUnavailable:         &flagMaxUnavailable,
+					MaxParallelPodCreation: apiutils.NewInt32Pointer(250),
+				},
+				Canary: &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+					Replicas: &flagCanaryReplicas,
+					Duration: &flagCanaryDuration,
+					AutoPause: &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{
+						Enabled:     apiutils.NewBoolPointer(true),
+						MaxRestarts: apiutils.NewInt32Pointer(2),
+					},
+				},
+			},
+		},
+	}
+
+	override := v2alpha1.DatadogAgentComponentOverride{
+		Name: apiutils.NewStringPointer("new-name"),
+		UpdateStrategy: &v2alpha1.UpdateStrategy{
+			RollingUpdate: &v2alpha1.RollingUpdate{MaxUnavailable: &maxUnavailable},
+			Canary: &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+				Duration: &canaryDuration,
+				AutoPause: &edsv1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+			ReconcileFrequency: &reconcileFrequency,
+		},
+	}
+
+	ExtendedDaemonSet(&eds, &override)
+
+	strategy := eds.Spec.Strategy
+	assert.Equal(t, "new-name", eds.Name)
+	assert.Equal(t, &maxUnavailable, strategy.RollingUpdate.MaxUnavailable)
+	assert.Equal(t, int32(250), *strategy.RollingUpdate.MaxParallelPodCreation)
+	assert.Equal(t, &flagCanaryReplicas, strategy.Canary.Replicas)
+	assert.Equal(t, &canaryDuration, strategy.Canary.Duration)
+	assert.False(t, *strategy.Canary.AutoPause.Enabled)
+	assert.Equal(t, int32(2), *strategy.Canary.AutoPause.MaxRestarts)
+	assert.Equal(t, &reconcileFrequency, strategy.ReconcileFrequency)
+}
@@ -19,4 +19,32 @@ func Deployment(deployment *v1.Deployment, override *v2alpha1.DatadogAgentCompon
 	if override.Name != nil {
 		deployment.Name = *override.Name
 	}
+
+	if override.UpdateStrategy != nil {
+		deploymentStrategy(&deployment.Spec.Strategy, override.UpdateStrategy)
+	}
+}
+
+func deploymentStrategy(strategy *v1.DeploymentStrategy, override *v2alpha1.UpdateStrategy) {
+	if override.Type != "" {
+		strategy.Type = v1.DeploymentStrategyType(override.Type)
+	}
+
+	if strategy.Type == v1.RecreateDeploymentStrategyType {
+		// The rolling update settings are rejected by the API server with the Recreate strategy.
+		strategy.RollingUpdate = nil
+		return
+	}
+
+	if override.RollingUpdate != nil {
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &v1.RollingUpdateDeployment{}
+		}
+		if override.RollingUpdate.MaxUnavailable != nil {
+			strategy.RollingUpdate.MaxUnavailable = override.RollingUpdate.MaxUnavailable
+		}
+		if override.RollingUpdate.MaxSurge != nil {
+			strategy.RollingUpdate.MaxSurge = override.RollingUpdate.MaxSurge
+		}
+	}
 }
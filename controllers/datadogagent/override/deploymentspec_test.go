@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestDeployment(t *testing.T) {
@@ -35,3 +36,54 @@ func TestDeployment(t *testing.T) {
 	assert.Equal(t, "new-name", deployment.Name)
 	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
 }
+
+func TestDeploymentUpdateStrategy(t *testing.T) {
+	maxUnavailable := intstr.FromString("25%")
+	maxSurge := intstr.FromInt(2)
+
+	tests := []struct {
+		name     string
+		strategy v1.DeploymentStrategy
+		override *v2alpha1.UpdateStrategy
+		want     v1.DeploymentStrategy
+	}{
+		{
+			name:     "no override",
+			strategy: v1.DeploymentStrategy{Type: v1.RollingUpdateDeploymentStrategyType},
+			want:     v1.DeploymentStrategy{Type: v1.RollingUpdateDeploymentStrategyType},
+		},
+		{
+			name:     "rolling update",
+			strategy: v1.DeploymentStrategy{},
+			override: &v2alpha1.UpdateStrategy{
+				Type:          string(v1.RollingUpdateDeploymentStrategyType),
+				RollingUpdate: &v2alpha1.RollingUpdate{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge},
+			},
+			want: v1.DeploymentStrategy{
+				Type:          v1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &v1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge},
+			},
+		},
+		{
+			name: "recreate drops rolling update settings",
+			strategy: v1.DeploymentStrategy{
+				Type:          v1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &v1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+			},
+			override: &v2alpha1.UpdateStrategy{
+				Type:          string(v1.RecreateDeploymentStrategyType),
+				RollingUpdate: &v2alpha1.RollingUpdate{MaxUnavailable: &maxUnavailable},
+			},
+			want: v1.DeploymentStrategy{Type: v1.RecreateDeploymentStrategyType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := v1.Deployment{Spec: v1.DeploymentSpec{Strategy: tt.strategy}}
+
+			Deployment(&deployment, &v2alpha1.DatadogAgentComponentOverride{UpdateStrategy: tt.override})
+
+			assert.Equal(t, tt.want, deployment.Spec.Strategy)
+		})
+	}
+}
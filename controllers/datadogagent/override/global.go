@@ -7,8 +7,8 @@ package override
 
 import (
 	"encoding/json"
-	"fmt"
 	"path/filepath"
+	"strings"
 
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
@@ -66,25 +66,35 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 		})
 	}
 
-	// Registry is the image registry to use for all Agent images.
-	if *config.Registry != apicommon.DefaultImageRegistry {
-		image := apicommon.DefaultAgentImageName
-		version := defaulting.AgentLatestVersion
-		if componentName == v2alpha1.ClusterAgentComponentName {
-			image = apicommon.DefaultClusterAgentImageName
-			version = defaulting.ClusterAgentLatestVersion
+	// Proxy configures the proxy used by the Agents to reach Datadog.
+	if config.Proxy != nil {
+		addProxyEnvVar(manager, apicommon.DDProxyHTTP, config.Proxy.HTTP, config.Proxy.HTTPSecret)
+		addProxyEnvVar(manager, apicommon.DDProxyHTTPS, config.Proxy.HTTPS, config.Proxy.HTTPSSecret)
+		if len(config.Proxy.NoProxy) > 0 {
+			manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+				Name:  apicommon.DDProxyNoProxy,
+				Value: strings.Join(config.Proxy.NoProxy, " "),
+			})
 		}
-		fullImage := fmt.Sprintf("%s/%s:%s", *config.Registry, image, version)
+	}
 
-		for idx := range manager.PodTemplateSpec().Spec.InitContainers {
-			manager.PodTemplateSpec().Spec.InitContainers[idx].Image = fullImage
+	// Registry is the image registry to use for all Agent images. The image names and tags are kept.
+	if *config.Registry != apicommon.DefaultImageRegistry {
+		registry := defaulting.WithRegistry(defaulting.ContainerRegistry(*config.Registry))
+		for idx, container := range manager.PodTemplateSpec().Spec.InitContainers {
+			manager.PodTemplateSpec().Spec.InitContainers[idx].Image = defaulting.ParseImage(container.Image).ProcessOptions(registry).String()
 		}
 
-		for idx := range manager.PodTemplateSpec().Spec.Containers {
-			manager.PodTemplateSpec().Spec.Containers[idx].Image = fullImage
+		for idx, container := range manager.PodTemplateSpec().Spec.Containers {
+			manager.PodTemplateSpec().Spec.Containers[idx].Image = defaulting.ParseImage(container.Image).ProcessOptions(registry).String()
 		}
 	}
 
+	// ImagePolicy.PullSecrets are the Secrets used to pull the images. They can be replaced by the component override.
+	if config.ImagePolicy != nil && len(config.ImagePolicy.PullSecrets) > 0 {
+		manager.PodTemplateSpec().Spec.ImagePullSecrets = append([]corev1.LocalObjectReference{}, config.ImagePolicy.PullSecrets...)
+	}
+
 	// LogLevel sets logging verbosity. This can be overridden by container.
 	manager.EnvVar().AddEnvVar(&corev1.EnvVar{
 		Name:  apicommon.DDLogLevel,
@@ -97,7 +107,7 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 			var err error
 			switch config.NetworkPolicy.Flavor {
 			case v2alpha1.NetworkPolicyFlavorKubernetes:
-				err = resourcesManager.NetworkPolicyManager().AddKubernetesNetworkPolicy(component.BuildKubernetesNetworkPolicy(dda, componentName))
+				err = resourcesManager.NetworkPolicyManager().AddKubernetesNetworkPolicy(component.BuildKubernetesNetworkPolicy(dda, componentName, config.Proxy))
 			case v2alpha1.NetworkPolicyFlavorCilium:
 				var ddURL string
 				var dnsSelectorEndpoints []metav1.LabelSelector
@@ -114,6 +124,7 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 						ddURL,
 						v2alpha1.IsHostNetworkEnabled(dda, v2alpha1.ClusterAgentComponentName),
 						dnsSelectorEndpoints,
+						config.Proxy,
 						componentName,
 					),
 				)
@@ -289,3 +300,15 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 
 	return manager.PodTemplateSpec()
 }
+
+// addProxyEnvVar sets a proxy URL from its Secret if it is configured, or from its value.
+func addProxyEnvVar(manager feature.PodTemplateManagers, name string, value *string, secret *apicommonv1.SecretConfig) {
+	if secret != nil {
+		manager.EnvVar().AddEnvVar(component.BuildEnvVarFromSource(name, component.BuildEnvVarFromSecret(secret.SecretName, secret.KeyName)))
+	} else if value != nil && *value != "" {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  name,
+			Value: *value,
+		})
+	}
+}
@@ -73,6 +73,42 @@ func TestNodeAgentComponenGlobalSettings(t *testing.T) {
 			wantVolumes:      getExpectedVolumes(),
 			want:             assertAllAgentSingleContainer,
 		},
+		{
+			name:                           "Proxy configured",
+			singleContainerStrategyEnabled: false,
+			dda: v2alpha1test.NewDatadogAgentBuilder().
+				WithGlobalProxy("http://proxy.example.com:3128", &apicommonv1.SecretConfig{SecretName: "proxy", KeyName: "https"}, []string{"localhost", "169.254.169.254"}).
+				BuildWithDefaults(),
+			wantEnvVars: []*corev1.EnvVar{
+				{
+					Name:  apicommon.DDSite,
+					Value: "datadoghq.com",
+				},
+				{
+					Name:  apicommon.DDProxyHTTP,
+					Value: "http://proxy.example.com:3128",
+				},
+				{
+					Name: apicommon.DDProxyHTTPS,
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+							Key:                  "https",
+						},
+					},
+				},
+				{
+					Name:  apicommon.DDProxyNoProxy,
+					Value: "localhost 169.254.169.254",
+				},
+				{
+					Name:  apicommon.DDLogLevel,
+					Value: "info",
+				},
+			},
+			wantVolumes: []*corev1.Volume{},
+			want:        assertAll,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImagePolicy applies the global image policy to the images of a component: variant selection, repository mapping and
+// digest pinning. It's applied after the component override, so that the image pinned is the one that is deployed.
+// When manifests are listed in the policy, it returns the images of the component that none of them pins to a digest.
+func ImagePolicy(manager feature.PodTemplateManagers, dda *v2alpha1.DatadogAgent, componentName v2alpha1.ComponentName) []string {
+	if dda.Spec.Global == nil || dda.Spec.Global.ImagePolicy == nil {
+		return nil
+	}
+	policy := dda.Spec.Global.ImagePolicy
+
+	digests := make(map[string]string, len(policy.Manifests))
+	for _, manifest := range policy.Manifests {
+		digests[manifest.Image] = manifest.Digest
+	}
+
+	var unpinned []string
+	applyPolicy := func(containers []corev1.Container) {
+		for i := range containers {
+			containers[i].Image = applyImagePolicy(containers[i].Image, policy, digests, componentName)
+			if len(digests) > 0 && defaulting.ParseImage(containers[i].Image).Digest() == "" && !utils.ContainsString(unpinned, containers[i].Image) {
+				unpinned = append(unpinned, containers[i].Image)
+			}
+		}
+	}
+	applyPolicy(manager.PodTemplateSpec().Spec.InitContainers)
+	applyPolicy(manager.PodTemplateSpec().Spec.Containers)
+	return unpinned
+}
+
+// applyImagePolicy returns the image to use for a container of the component.
+// The variants and repository are only applied to the default image of the component, which is the Cluster Agent image
+// for the Cluster Agent and the Agent image otherwise. Images already pinned to a digest are left unchanged.
+func applyImagePolicy(fullImage string, policy *v2alpha1.ImagePolicyConfig, digests map[string]string, componentName v2alpha1.ComponentName) string {
+	image := defaulting.ParseImage(fullImage)
+	if image.Digest() != "" {
+		return fullImage
+	}
+
+	defaultImageName := apicommon.DefaultAgentImageName
+	if componentName == v2alpha1.ClusterAgentComponentName {
+		defaultImageName = apicommon.DefaultClusterAgentImageName
+	}
+
+	if image.Name() == defaultImageName {
+		if apiutils.BoolValue(policy.FIPSEnabled) {
+			image.ProcessOptions(defaulting.WithFIPS(true))
+		}
+		if apiutils.BoolValue(policy.JMXEnabled) && componentName != v2alpha1.ClusterAgentComponentName {
+			image.ProcessOptions(defaulting.WithJMX(true))
+		}
+		if repository, found := policy.Repositories[componentName]; found && repository != "" {
+			image.ProcessOptions(defaulting.WithRepository(repository))
+		}
+	}
+
+	if digest, found := digests[image.String()]; found {
+		image.ProcessOptions(defaulting.WithDigest(digest))
+	}
+
+	return image.String()
+}
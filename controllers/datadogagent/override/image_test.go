@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	agentDigest        = "sha256:" + strings.Repeat("a", 64)
+	clusterAgentDigest = "sha256:" + strings.Repeat("b", 64)
+)
+
+func newImageTestPodTemplate(images ...string) corev1.PodTemplateSpec {
+	podTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init-volume", Image: images[0]}},
+		},
+	}
+	for _, image := range images {
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, corev1.Container{Image: image})
+	}
+	return podTemplate
+}
+
+func TestImagePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *v2alpha1.ImagePolicyConfig
+		componentName v2alpha1.ComponentName
+		images        []string
+		want          []string
+		wantUnpinned  []string
+	}{
+		{
+			name:          "no image policy",
+			componentName: v2alpha1.NodeAgentComponentName,
+			images:        []string{"gcr.io/datadoghq/agent:7.50.3"},
+			want:          []string{"gcr.io/datadoghq/agent:7.50.3"},
+		},
+		{
+			name: "node agent variants",
+			policy: &v2alpha1.ImagePolicyConfig{
+				JMXEnabled:  apiutils.NewBoolPointer(true),
+				FIPSEnabled: apiutils.NewBoolPointer(true),
+			},
+			componentName: v2alpha1.NodeAgentComponentName,
+			images:        []string{"gcr.io/datadoghq/agent:7.50.3", "gcr.io/datadoghq/agent:7.50.3-jmx", "datadog/dogstatsd:7.50.3"},
+			want:          []string{"gcr.io/datadoghq/agent:7.50.3-fips-jmx", "gcr.io/datadoghq/agent:7.50.3-fips-jmx", "datadog/dogstatsd:7.50.3"},
+		},
+		{
+			name: "cluster agent has no JMX variant",
+			policy: &v2alpha1.ImagePolicyConfig{
+				JMXEnabled:  apiutils.NewBoolPointer(true),
+				FIPSEnabled: apiutils.NewBoolPointer(true),
+			},
+			componentName: v2alpha1.ClusterAgentComponentName,
+			images:        []string{"gcr.io/datadoghq/cluster-agent:7.50.3"},
+			want:          []string{"gcr.io/datadoghq/cluster-agent:7.50.3-fips"},
+		},
+		{
+			name: "mirrored and pinned images",
+			policy: &v2alpha1.ImagePolicyConfig{
+				Repositories: map[v2alpha1.ComponentName]string{
+					v2alpha1.NodeAgentComponentName:    "registry.example.com/mirror/agent",
+					v2alpha1.ClusterAgentComponentName: "registry.example.com/mirror/cluster-agent",
+				},
+				Manifests: []v2alpha1.ImageManifest{
+					{Image: "registry.example.com/mirror/agent:7.50.3", Digest: agentDigest},
+					{Image: "registry.example.com/mirror/cluster-agent:7.50.3", Digest: clusterAgentDigest},
+				},
+			},
+			componentName: v2alpha1.NodeAgentComponentName,
+			images:        []string{"gcr.io/datadoghq/agent:7.50.3", "gcr.io/datadoghq/agent:7.49.0"},
+			want:          []string{"registry.example.com/mirror/agent:7.50.3@" + agentDigest, "registry.example.com/mirror/agent:7.49.0"},
+			wantUnpinned:  []string{"registry.example.com/mirror/agent:7.49.0"},
+		},
+		{
+			name: "component without repository",
+			policy: &v2alpha1.ImagePolicyConfig{
+				Repositories: map[v2alpha1.ComponentName]string{
+					v2alpha1.NodeAgentComponentName: "registry.example.com/mirror/agent",
+				},
+				Manifests: []v2alpha1.ImageManifest{
+					{Image: "gcr.io/datadoghq/agent:7.50.3", Digest: agentDigest},
+				},
+			},
+			componentName: v2alpha1.ClusterChecksRunnerComponentName,
+			images:        []string{"gcr.io/datadoghq/agent:7.50.3"},
+			want:          []string{"gcr.io/datadoghq/agent:7.50.3@" + agentDigest},
+		},
+		{
+			name: "image already pinned",
+			policy: &v2alpha1.ImagePolicyConfig{
+				FIPSEnabled: apiutils.NewBoolPointer(true),
+				Repositories: map[v2alpha1.ComponentName]string{
+					v2alpha1.NodeAgentComponentName: "registry.example.com/mirror/agent",
+				},
+			},
+			componentName: v2alpha1.NodeAgentComponentName,
+			images:        []string{"gcr.io/datadoghq/agent:7.50.3@" + clusterAgentDigest},
+			want:          []string{"gcr.io/datadoghq/agent:7.50.3@" + clusterAgentDigest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := v2alpha1test.NewDatadogAgentBuilder().WithGlobalImagePolicy(tt.policy).Build()
+			manager := fake.NewPodTemplateManagers(t, newImageTestPodTemplate(tt.images...))
+
+			unpinned := ImagePolicy(manager, dda, tt.componentName)
+
+			var got []string
+			for _, container := range manager.PodTemplateSpec().Spec.Containers {
+				got = append(got, container.Image)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want[0], manager.PodTemplateSpec().Spec.InitContainers[0].Image)
+			assert.Equal(t, tt.wantUnpinned, unpinned)
+		})
+	}
+}
+
+func TestGlobalImageSettings(t *testing.T) {
+	logger := logf.Log.WithName("TestGlobalImageSettings")
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	storeOptions := &dependencies.StoreOptions{
+		Scheme: testScheme,
+	}
+
+	dda := v2alpha1test.NewDatadogAgentBuilder().
+		WithGlobalRegistry("registry.example.com/datadog").
+		WithGlobalImagePolicy(&v2alpha1.ImagePolicyConfig{
+			PullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+		}).
+		BuildWithDefaults()
+	manager := fake.NewPodTemplateManagers(t, newImageTestPodTemplate("gcr.io/datadoghq/cluster-agent:7.49.1"))
+	resourcesManager := feature.NewResourceManagers(dependencies.NewStore(dda, storeOptions))
+
+	podTemplate := ApplyGlobalSettingsClusterAgent(logger, manager, dda, resourcesManager)
+
+	// The registry is replaced, but the tag set for the component is kept.
+	assert.Equal(t, "registry.example.com/datadog/cluster-agent:7.49.1", podTemplate.Spec.Containers[0].Image)
+	assert.Equal(t, "registry.example.com/datadog/cluster-agent:7.49.1", podTemplate.Spec.InitContainers[0].Image)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-credentials"}}, podTemplate.Spec.ImagePullSecrets)
+}
@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"path/filepath"
+	"strings"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ProviderDefaults applies the defaults of the provider registry to the node Agent pod template.
+// It's applied before the component override, so users can still override these defaults.
+func ProviderDefaults(manager feature.PodTemplateManagers, provider string) {
+	definition, found := kubernetes.GetProviderDefinition(provider)
+	if !found {
+		return
+	}
+	defaults := definition.Defaults
+	podTemplate := manager.PodTemplateSpec()
+
+	for _, hostPath := range defaults.UnavailableHostPaths {
+		removeHostPathVolume(podTemplate, hostPath)
+	}
+
+	if defaults.CriSocketPath != "" && !hasVolume(podTemplate, apicommon.CriSocketVolumeName) {
+		criSocketMountPath := filepath.Join(apicommon.HostCriSocketPathPrefix, defaults.CriSocketPath)
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDCriSocketPath,
+			Value: criSocketMountPath,
+		})
+		runtimeVol, runtimeVolMount := volume.GetVolumes(apicommon.CriSocketVolumeName, defaults.CriSocketPath, criSocketMountPath, true)
+		manager.VolumeMount().AddVolumeMountToContainers(
+			&runtimeVolMount,
+			[]apicommonv1.AgentContainerName{
+				apicommonv1.CoreAgentContainerName,
+				apicommonv1.ProcessAgentContainerName,
+				apicommonv1.TraceAgentContainerName,
+				apicommonv1.SecurityAgentContainerName,
+				apicommonv1.UnprivilegedSingleAgentContainerName,
+			},
+		)
+		manager.Volume().AddVolume(&runtimeVol)
+	}
+
+	for _, envVar := range defaults.EnvVars {
+		addEnvVarIfUnset(podTemplate, envVar)
+	}
+
+	if defaults.DisableAppArmor {
+		for key := range podTemplate.Annotations {
+			if strings.HasPrefix(key, apicommon.AppArmorAnnotationKey+"/") {
+				delete(podTemplate.Annotations, key)
+			}
+		}
+	}
+
+	if defaults.SeccompProfile != nil {
+		replaceLocalhostSeccompProfiles(podTemplate, defaults.SeccompProfile)
+	}
+}
+
+// replaceLocalhostSeccompProfiles replaces the Localhost seccomp profiles of the containers,
+// and removes the init container installing them
+func replaceLocalhostSeccompProfiles(podTemplate *corev1.PodTemplateSpec, profile *corev1.SeccompProfile) {
+	initContainers := podTemplate.Spec.InitContainers[:0]
+	for _, container := range podTemplate.Spec.InitContainers {
+		if container.Name != string(apicommonv1.SeccompSetupContainerName) {
+			initContainers = append(initContainers, container)
+		}
+	}
+	podTemplate.Spec.InitContainers = initContainers
+
+	for i := range podTemplate.Spec.Containers {
+		securityContext := podTemplate.Spec.Containers[i].SecurityContext
+		if securityContext != nil && securityContext.SeccompProfile != nil && securityContext.SeccompProfile.Type == corev1.SeccompProfileTypeLocalhost {
+			securityContext.SeccompProfile = profile.DeepCopy()
+		}
+	}
+}
+
+// removeHostPathVolume removes the volumes using the host path, and their volume mounts
+func removeHostPathVolume(podTemplate *corev1.PodTemplateSpec, hostPath string) {
+	removed := map[string]struct{}{}
+	volumes := podTemplate.Spec.Volumes[:0]
+	for _, vol := range podTemplate.Spec.Volumes {
+		if vol.HostPath != nil && filepath.Clean(vol.HostPath.Path) == filepath.Clean(hostPath) {
+			removed[vol.Name] = struct{}{}
+			continue
+		}
+		volumes = append(volumes, vol)
+	}
+	podTemplate.Spec.Volumes = volumes
+	if len(removed) == 0 {
+		return
+	}
+
+	removeMounts := func(containers []corev1.Container) {
+		for i := range containers {
+			mounts := containers[i].VolumeMounts[:0]
+			for _, mount := range containers[i].VolumeMounts {
+				if _, found := removed[mount.Name]; !found {
+					mounts = append(mounts, mount)
+				}
+			}
+			containers[i].VolumeMounts = mounts
+		}
+	}
+	removeMounts(podTemplate.Spec.InitContainers)
+	removeMounts(podTemplate.Spec.Containers)
+}
+
+func hasVolume(podTemplate *corev1.PodTemplateSpec, name string) bool {
+	for _, vol := range podTemplate.Spec.Volumes {
+		if vol.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addEnvVarIfUnset adds the env var to the containers that don't set it yet
+func addEnvVarIfUnset(podTemplate *corev1.PodTemplateSpec, envVar corev1.EnvVar) {
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		found := false
+		for _, env := range container.Env {
+			if env.Name == envVar.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+}
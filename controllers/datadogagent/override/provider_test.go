@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newProviderTestPodTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: string(apicommonv1.SeccompSetupContainerName)},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: string(apicommonv1.CoreAgentContainerName),
+					Env: []corev1.EnvVar{
+						{Name: apicommon.DDKubeletTLSVerify, Value: "true"},
+					},
+				},
+				{
+					Name: string(apicommonv1.SystemProbeContainerName),
+					SecurityContext: &corev1.SecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type:             corev1.SeccompProfileTypeLocalhost,
+							LocalhostProfile: apiutils.NewStringPointer(apicommon.SystemProbeSeccompProfileName),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: apicommon.SrcVolumeName, MountPath: apicommon.SrcVolumePath},
+						{Name: apicommon.ProcdirVolumeName, MountPath: apicommon.ProcdirMountPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name:         apicommon.SrcVolumeName,
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: apicommon.SrcVolumePath}},
+				},
+				{
+					Name:         apicommon.ProcdirVolumeName,
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: apicommon.ProcdirHostPath}},
+				},
+			},
+		},
+	}
+}
+
+func TestProviderDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		want     func(t *testing.T, podTemplate *corev1.PodTemplateSpec)
+	}{
+		{
+			name:     "default provider, no change",
+			provider: kubernetes.DefaultProvider,
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, newProviderTestPodTemplate(), podTemplate)
+			},
+		},
+		{
+			name:     "gke cos provider, /usr/src is removed",
+			provider: kubernetes.GKECloudProvider + "-" + kubernetes.GKECosType,
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Len(t, podTemplate.Spec.Volumes, 1)
+				assert.Equal(t, apicommon.ProcdirVolumeName, podTemplate.Spec.Volumes[0].Name)
+				assert.Equal(t, []corev1.VolumeMount{{Name: apicommon.ProcdirVolumeName, MountPath: apicommon.ProcdirMountPath}}, podTemplate.Spec.Containers[1].VolumeMounts)
+			},
+		},
+		{
+			name:     "gke autopilot provider, localhost seccomp profiles are replaced",
+			provider: kubernetes.GKECloudProvider + "-true",
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Empty(t, podTemplate.Spec.InitContainers)
+				assert.Equal(t, &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}, podTemplate.Spec.Containers[1].SecurityContext.SeccompProfile)
+			},
+		},
+		{
+			name:     "eks bottlerocket provider, runtime socket is added",
+			provider: kubernetes.EKSCloudProvider + "-" + kubernetes.EKSBottlerocketType,
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				volumeNames := []string{}
+				for _, vol := range podTemplate.Spec.Volumes {
+					volumeNames = append(volumeNames, vol.Name)
+				}
+				assert.ElementsMatch(t, []string{apicommon.ProcdirVolumeName, apicommon.CriSocketVolumeName}, volumeNames)
+				assert.Contains(t, podTemplate.Spec.Containers[0].Env, corev1.EnvVar{Name: apicommon.DDCriSocketPath, Value: "/host/run/dockershim.sock"})
+				assert.Contains(t, podTemplate.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: apicommon.CriSocketVolumeName, MountPath: "/host/run/dockershim.sock", ReadOnly: true})
+			},
+		},
+		{
+			name:     "aks provider, env var is only added when unset",
+			provider: "aks-ubuntu",
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDKubeletTLSVerify, Value: "true"}}, podTemplate.Spec.Containers[0].Env)
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDKubeletTLSVerify, Value: "false"}}, podTemplate.Spec.Containers[1].Env)
+			},
+		},
+		{
+			name:     "openshift rhcos provider, apparmor annotations are removed",
+			provider: kubernetes.OpenShiftCloudProvider + "-" + kubernetes.OpenShiftRHCOSType,
+			want: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, map[string]string{"foo": "bar"}, podTemplate.Annotations)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podTemplate := newProviderTestPodTemplate()
+			if tt.provider == kubernetes.OpenShiftCloudProvider+"-"+kubernetes.OpenShiftRHCOSType {
+				podTemplate.Annotations = map[string]string{
+					"foo": "bar",
+					apicommon.SystemProbeAppArmorAnnotationKey: apicommon.SystemProbeAppArmorAnnotationValue,
+				}
+			}
+			manager := feature.NewPodTemplateManagers(podTemplate)
+
+			ProviderDefaults(manager, tt.provider)
+
+			tt.want(t, podTemplate)
+		})
+	}
+}
@@ -15,9 +15,13 @@ import (
 
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -279,3 +283,32 @@ func buildPDBV1Beta1(metadata metav1.ObjectMeta, matchLabels map[string]string,
 
 	return pdb
 }
+
+// addPodDisruptionBudgetV2 adds the PodDisruptionBudget of a v2alpha1 component Deployment to the dependencies store.
+// The PodDisruptionBudget is created if it is configured in the component override, or if the Deployment has more
+// than one replica. Otherwise, it isn't added to the store and an existing one is removed by Store.Cleanup.
+func addPodDisruptionBudgetV2(manager feature.ResourceManagers, deployment *appsv1.Deployment, pdbName string, componentOverride *datadoghqv2alpha1.DatadogAgentComponentOverride) error {
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	if componentOverride != nil && componentOverride.PodDisruptionBudget != nil {
+		minAvailable = componentOverride.PodDisruptionBudget.MinAvailable
+		maxUnavailable = componentOverride.PodDisruptionBudget.MaxUnavailable
+	} else if replicas := maxDeploymentReplicas(deployment, componentOverride); replicas == nil || *replicas <= 1 {
+		return nil
+	}
+
+	if minAvailable == nil && maxUnavailable == nil {
+		defaultMinAvailable := intstr.FromInt(pdbMinAvailableInstances)
+		minAvailable = &defaultMinAvailable
+	}
+
+	return manager.PodDisruptionBudgetManager().AddPodDisruptionBudget(pdbName, deployment.Namespace, deployment.Spec.Selector.DeepCopy(), minAvailable, maxUnavailable)
+}
+
+// maxDeploymentReplicas returns the maximum number of replicas of a component Deployment:
+// the HorizontalPodAutoscaler maxReplicas if autoscaling is enabled, the Deployment replicas otherwise.
+func maxDeploymentReplicas(deployment *appsv1.Deployment, componentOverride *datadoghqv2alpha1.DatadogAgentComponentOverride) *int32 {
+	if componentOverride != nil && componentOverride.Autoscaling != nil && apiutils.BoolValue(componentOverride.Autoscaling.Enabled) {
+		return componentOverride.Autoscaling.MaxReplicas
+	}
+	return deployment.Spec.Replicas
+}
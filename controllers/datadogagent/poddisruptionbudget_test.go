@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_addPodDisruptionBudgetV2(t *testing.T) {
+	defaultMinAvailable := intstr.FromInt(1)
+	maxUnavailable := intstr.FromString("25%")
+
+	tests := []struct {
+		name               string
+		replicas           int32
+		override           *datadoghqv2alpha1.DatadogAgentComponentOverride
+		wantPDB            bool
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:     "single replica without override",
+			replicas: 1,
+			wantPDB:  false,
+		},
+		{
+			name:             "several replicas without override",
+			replicas:         2,
+			wantPDB:          true,
+			wantMinAvailable: &defaultMinAvailable,
+		},
+		{
+			name:     "single replica with pdb override",
+			replicas: 1,
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				PodDisruptionBudget: &datadoghqv2alpha1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable},
+			},
+			wantPDB:            true,
+			wantMaxUnavailable: &maxUnavailable,
+		},
+		{
+			name:     "autoscaling enabled",
+			replicas: 1,
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				Autoscaling: &datadoghqv2alpha1.AutoscalingConfig{
+					Enabled:     apiutils.NewBoolPointer(true),
+					MaxReplicas: apiutils.NewInt32Pointer(3),
+				},
+			},
+			wantPDB:          true,
+			wantMinAvailable: &defaultMinAvailable,
+		},
+		{
+			name:     "empty pdb override",
+			replicas: 3,
+			override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+				PodDisruptionBudget: &datadoghqv2alpha1.PodDisruptionBudgetConfig{},
+			},
+			wantPDB:          true,
+			wantMinAvailable: &defaultMinAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testScheme := runtime.NewScheme()
+			testScheme.AddKnownTypes(datadoghqv2alpha1.GroupVersion, &datadoghqv2alpha1.DatadogAgent{})
+			owner := &datadoghqv2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}}
+			store := dependencies.NewStore(owner, &dependencies.StoreOptions{Scheme: testScheme})
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo-cluster-agent"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: apiutils.NewInt32Pointer(tt.replicas),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+				},
+			}
+
+			err := addPodDisruptionBudgetV2(feature.NewResourceManagers(store), deployment, "foo-cluster-agent", tt.override)
+			assert.NoError(t, err)
+
+			obj, found := store.Get(kubernetes.PodDisruptionBudgetsKind, "bar", "foo-cluster-agent")
+			assert.Equal(t, tt.wantPDB, found)
+			if !tt.wantPDB {
+				return
+			}
+			pdb := obj.(*policyv1.PodDisruptionBudget)
+			assert.Equal(t, tt.wantMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, tt.wantMaxUnavailable, pdb.Spec.MaxUnavailable)
+			assert.Equal(t, deployment.Spec.Selector, pdb.Spec.Selector)
+		})
+	}
+}
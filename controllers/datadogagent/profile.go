@@ -0,0 +1,212 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// nodeAgentProfiles returns the profiles to reconcile a node Agent DaemonSet for.
+// The first one is nil and corresponds to the nodes that aren't part of any profile.
+func nodeAgentProfiles(dda *datadoghqv2alpha1.DatadogAgent) []*datadoghqv2alpha1.NodeAgentProfile {
+	profiles := []*datadoghqv2alpha1.NodeAgentProfile{nil}
+	for i := range dda.Spec.Profiles {
+		profiles = append(profiles, &dda.Spec.Profiles[i])
+	}
+	return profiles
+}
+
+// nodeAgentFeatures returns the features and required components of the node Agents of a profile and a provider,
+// the features disabled by the profile or by the provider defaults are left out.
+func nodeAgentFeatures(dda *datadoghqv2alpha1.DatadogAgent, options *feature.Options, features []feature.Feature, requiredComponents feature.RequiredComponents,
+	profile *datadoghqv2alpha1.NodeAgentProfile, provider string) ([]feature.Feature, feature.RequiredComponents) {
+	var disabledIDs []feature.IDType
+	if profile != nil {
+		for _, id := range profile.DisabledFeatures {
+			disabledIDs = append(disabledIDs, feature.IDType(id))
+		}
+	}
+	if definition, found := kubernetes.GetProviderDefinition(provider); found {
+		for _, id := range definition.Defaults.DisabledFeatures {
+			disabledIDs = append(disabledIDs, feature.IDType(id))
+		}
+	}
+	if len(disabledIDs) == 0 {
+		return features, requiredComponents
+	}
+	return feature.BuildFeaturesWithout(dda, options, disabledIDs)
+}
+
+// profileComponentOverride returns the override of the node Agent DaemonSet of a profile.
+// The DaemonSet name is the name of the node Agent DaemonSet suffixed by the profile name.
+func profileComponentOverride(dsName string, profile *datadoghqv2alpha1.NodeAgentProfile) *datadoghqv2alpha1.DatadogAgentComponentOverride {
+	profileOverride := &datadoghqv2alpha1.DatadogAgentComponentOverride{}
+	if profile.Override != nil {
+		profileOverride = profile.Override.DeepCopy()
+	}
+	profileOverride.Name = apiutils.NewStringPointer(dsName + "-" + profile.Name)
+	return profileOverride
+}
+
+// profileNodeSelectorTerms returns the node selector terms matching the nodes of a profile: the nodes matching
+// the profile requirements and none of the previous profiles. If profile is nil, it returns the node selector terms
+// matching the nodes that aren't part of any profile.
+// The result is a disjunction of terms, it returns nil if the nodes don't need to be restricted.
+func profileNodeSelectorTerms(profiles []datadoghqv2alpha1.NodeAgentProfile, profile *datadoghqv2alpha1.NodeAgentProfile) []corev1.NodeSelectorTerm {
+	terms := []corev1.NodeSelectorTerm{{}}
+	if profile != nil {
+		terms[0].MatchExpressions = append(terms[0].MatchExpressions, profile.NodeSelectorRequirements...)
+	}
+
+	for i := range profiles {
+		if profile != nil && profiles[i].Name == profile.Name {
+			break
+		}
+		// The negation of ANDed requirements is the disjunction of the negated requirements,
+		// so each term is split into one term per negated requirement.
+		var excludingTerms []corev1.NodeSelectorTerm
+		for _, term := range terms {
+			for _, requirement := range profiles[i].NodeSelectorRequirements {
+				excludingTerm := corev1.NodeSelectorTerm{
+					MatchExpressions: make([]corev1.NodeSelectorRequirement, 0, len(term.MatchExpressions)+1),
+				}
+				excludingTerm.MatchExpressions = append(excludingTerm.MatchExpressions, term.MatchExpressions...)
+				excludingTerm.MatchExpressions = append(excludingTerm.MatchExpressions, negateNodeSelectorRequirement(requirement))
+				excludingTerms = append(excludingTerms, excludingTerm)
+			}
+		}
+		terms = excludingTerms
+	}
+
+	if len(terms) == 1 && len(terms[0].MatchExpressions) == 0 {
+		return nil
+	}
+	return terms
+}
+
+// negateNodeSelectorRequirement returns the node selector requirement matching the nodes that don't match the given one.
+func negateNodeSelectorRequirement(requirement corev1.NodeSelectorRequirement) corev1.NodeSelectorRequirement {
+	negated := *requirement.DeepCopy()
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		negated.Operator = corev1.NodeSelectorOpNotIn
+	case corev1.NodeSelectorOpNotIn:
+		negated.Operator = corev1.NodeSelectorOpIn
+	case corev1.NodeSelectorOpExists:
+		negated.Operator = corev1.NodeSelectorOpDoesNotExist
+	case corev1.NodeSelectorOpDoesNotExist:
+		negated.Operator = corev1.NodeSelectorOpExists
+	}
+	return negated
+}
+
+// addProfileNodeAffinity restricts an affinity to the nodes matching the given node selector terms.
+func addProfileNodeAffinity(affinity *corev1.Affinity, terms []corev1.NodeSelectorTerm) *corev1.Affinity {
+	if len(terms) == 0 {
+		return affinity
+	}
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	nodeSelector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = terms
+		return affinity
+	}
+
+	// NodeSelectorTerms are ORed, MatchExpressions are ANDed
+	mergedTerms := make([]corev1.NodeSelectorTerm, 0, len(nodeSelector.NodeSelectorTerms)*len(terms))
+	for _, existingTerm := range nodeSelector.NodeSelectorTerms {
+		for _, term := range terms {
+			mergedTerm := corev1.NodeSelectorTerm{
+				MatchExpressions: make([]corev1.NodeSelectorRequirement, 0, len(existingTerm.MatchExpressions)+len(term.MatchExpressions)),
+				MatchFields:      existingTerm.MatchFields,
+			}
+			mergedTerm.MatchExpressions = append(mergedTerm.MatchExpressions, existingTerm.MatchExpressions...)
+			mergedTerm.MatchExpressions = append(mergedTerm.MatchExpressions, term.MatchExpressions...)
+			mergedTerms = append(mergedTerms, mergedTerm)
+		}
+	}
+	nodeSelector.NodeSelectorTerms = mergedTerms
+
+	return affinity
+}
+
+// cleanupDaemonSetsForProfilesThatNoLongerApply deletes the ds/eds of the profiles
+// that were removed from the DatadogAgent.
+func (r *Reconciler) cleanupDaemonSetsForProfilesThatNoLongerApply(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, ddaStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	profiles := make(map[string]struct{}, len(dda.Spec.Profiles))
+	for _, profile := range dda.Spec.Profiles {
+		profiles[profile.Name] = struct{}{}
+	}
+	listOptions := []client.ListOption{
+		client.InNamespace(dda.Namespace),
+		client.MatchingLabels{apicommon.AgentDeploymentNameLabelKey: dda.Name},
+		client.HasLabels{apicommon.AgentDeploymentProfileLabelKey},
+	}
+
+	if r.options.ExtendedDaemonsetOptions.Enabled {
+		edsList := edsv1alpha1.ExtendedDaemonSetList{}
+		if err := r.client.List(ctx, &edsList, listOptions...); err != nil {
+			return err
+		}
+
+		for _, eds := range edsList.Items {
+			if _, found := profiles[eds.Labels[apicommon.AgentDeploymentProfileLabelKey]]; found {
+				continue
+			}
+			if err := r.client.Delete(ctx, &eds); err != nil {
+				return err
+			}
+			r.log.Info("Deleted ExtendedDaemonSet", "extendedDaemonSet.Namespace", eds.Namespace, "extendedDaemonSet.Name", eds.Name)
+			event := buildEventInfo(eds.Name, eds.Namespace, extendedDaemonSetKind, datadog.DeletionEvent)
+			r.recordEvent(dda, event)
+
+			removeStaleStatus(ddaStatus, eds.Name)
+		}
+
+		return nil
+	}
+
+	daemonSetList := appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, &daemonSetList, listOptions...); err != nil {
+		return err
+	}
+
+	for _, ds := range daemonSetList.Items {
+		if _, found := profiles[ds.Labels[apicommon.AgentDeploymentProfileLabelKey]]; found {
+			continue
+		}
+		if err := r.client.Delete(ctx, &ds); err != nil {
+			return err
+		}
+		r.log.Info("Deleted DaemonSet", "daemonSet.Namespace", ds.Namespace, "daemonSet.Name", ds.Name)
+		event := buildEventInfo(ds.Name, ds.Namespace, daemonSetKind, datadog.DeletionEvent)
+		r.recordEvent(dda, event)
+
+		removeStaleStatus(ddaStatus, ds.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_profileNodeSelectorTerms(t *testing.T) {
+	gpu := corev1.NodeSelectorRequirement{Key: "gpu", Operator: corev1.NodeSelectorOpExists}
+	noGPU := corev1.NodeSelectorRequirement{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist}
+	bigMemory := corev1.NodeSelectorRequirement{Key: "instance-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"r5"}}
+	notBigMemory := corev1.NodeSelectorRequirement{Key: "instance-type", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"r5"}}
+	zone := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}}
+	notZone := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+
+	profiles := []datadoghqv2alpha1.NodeAgentProfile{
+		{Name: "gpu", NodeSelectorRequirements: []corev1.NodeSelectorRequirement{gpu}},
+		{Name: "big-memory", NodeSelectorRequirements: []corev1.NodeSelectorRequirement{bigMemory, zone}},
+	}
+
+	tests := []struct {
+		name     string
+		profiles []datadoghqv2alpha1.NodeAgentProfile
+		profile  *datadoghqv2alpha1.NodeAgentProfile
+		want     []corev1.NodeSelectorTerm
+	}{
+		{
+			name: "no profiles",
+			want: nil,
+		},
+		{
+			name:     "first profile",
+			profiles: profiles,
+			profile:  &profiles[0],
+			want: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}},
+			},
+		},
+		{
+			name:     "second profile excludes the first one",
+			profiles: profiles,
+			profile:  &profiles[1],
+			want: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{bigMemory, zone, noGPU}},
+			},
+		},
+		{
+			name:     "nodes without profile exclude all profiles",
+			profiles: profiles,
+			want: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{noGPU, notBigMemory}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{noGPU, notZone}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, profileNodeSelectorTerms(tt.profiles, tt.profile))
+		})
+	}
+}
+
+func Test_addProfileNodeAffinity(t *testing.T) {
+	gpu := corev1.NodeSelectorRequirement{Key: "gpu", Operator: corev1.NodeSelectorOpExists}
+	linux := corev1.NodeSelectorRequirement{Key: "os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}
+	arm := corev1.NodeSelectorRequirement{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}
+	amd := corev1.NodeSelectorRequirement{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}
+	podAntiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "zone"}},
+	}
+
+	tests := []struct {
+		name     string
+		affinity *corev1.Affinity
+		terms    []corev1.NodeSelectorTerm
+		want     *corev1.Affinity
+	}{
+		{
+			name:     "no terms",
+			affinity: &corev1.Affinity{PodAntiAffinity: podAntiAffinity},
+			want:     &corev1.Affinity{PodAntiAffinity: podAntiAffinity},
+		},
+		{
+			name:  "no affinity",
+			terms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+					},
+				},
+			},
+		},
+		{
+			name: "existing node affinity",
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{arm}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{amd}},
+						},
+					},
+				},
+				PodAntiAffinity: podAntiAffinity,
+			},
+			terms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{linux}},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{arm, gpu}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{arm, linux}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{amd, gpu}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{amd, linux}},
+						},
+					},
+				},
+				PodAntiAffinity: podAntiAffinity,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, addProfileNodeAffinity(tt.affinity, tt.terms))
+		})
+	}
+}
+
+func Test_profileComponentOverride(t *testing.T) {
+	profile := &datadoghqv2alpha1.NodeAgentProfile{
+		Name: "gpu",
+		Override: &datadoghqv2alpha1.DatadogAgentComponentOverride{
+			PriorityClassName: apiutils.NewStringPointer("gpu"),
+		},
+	}
+
+	got := profileComponentOverride("foo-agent", profile)
+	assert.Equal(t, apiutils.NewStringPointer("foo-agent-gpu"), got.Name)
+	assert.Equal(t, apiutils.NewStringPointer("gpu"), got.PriorityClassName)
+	// The profile override must not be modified.
+	assert.Nil(t, profile.Override.Name)
+}
+
+func Test_nodeAgentFeatures(t *testing.T) {
+	dda := v2alpha1test.NewDatadogAgentBuilder().
+		WithAPMEnabled(true).
+		WithNPMEnabled(true).
+		BuildWithDefaults()
+	options := &feature.Options{Logger: logf.Log.WithName(t.Name())}
+	features, requiredComponents := feature.BuildFeatures(dda, options)
+
+	featureIDs := func(features []feature.Feature) []feature.IDType {
+		ids := []feature.IDType{}
+		for _, feat := range features {
+			ids = append(ids, feat.ID())
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name            string
+		profile         *datadoghqv2alpha1.NodeAgentProfile
+		provider        string
+		wantContains    []feature.IDType
+		wantNotContains []feature.IDType
+	}{
+		{
+			name:         "no profile, default provider",
+			provider:     kubernetes.DefaultProvider,
+			wantContains: []feature.IDType{feature.APMIDType, feature.NPMIDType},
+		},
+		{
+			name:            "profile disabling apm",
+			profile:         &datadoghqv2alpha1.NodeAgentProfile{Name: "no-apm", DisabledFeatures: []string{string(feature.APMIDType)}},
+			provider:        kubernetes.DefaultProvider,
+			wantContains:    []feature.IDType{feature.NPMIDType},
+			wantNotContains: []feature.IDType{feature.APMIDType},
+		},
+		{
+			name:            "gke autopilot provider disables npm",
+			provider:        kubernetes.GKECloudProvider + "-true",
+			wantContains:    []feature.IDType{feature.APMIDType},
+			wantNotContains: []feature.IDType{feature.NPMIDType},
+		},
+		{
+			name:            "profile and provider disabled features are combined",
+			profile:         &datadoghqv2alpha1.NodeAgentProfile{Name: "no-apm", DisabledFeatures: []string{string(feature.APMIDType)}},
+			provider:        kubernetes.GKECloudProvider + "-true",
+			wantNotContains: []feature.IDType{feature.APMIDType, feature.NPMIDType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFeatures, _ := nodeAgentFeatures(dda, options, features, requiredComponents, tt.profile, tt.provider)
+			ids := featureIDs(gotFeatures)
+			for _, id := range tt.wantContains {
+				assert.Contains(t, ids, id)
+			}
+			for _, id := range tt.wantNotContains {
+				assert.NotContains(t, ids, id)
+			}
+		})
+	}
+}
+
+func Test_supportedProfileDisabledFeatures(t *testing.T) {
+	// All the features are registered by the imports of the controller, the default and dummy features can't be disabled.
+	var registered []string
+	for _, id := range feature.RegisteredIDs() {
+		if id == feature.DefaultIDType || id == feature.DummyIDType {
+			continue
+		}
+		registered = append(registered, string(id))
+	}
+
+	assert.ElementsMatch(t, registered, datadoghqv2alpha1.SupportedProfileDisabledFeatures())
+}
@@ -0,0 +1,225 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/override"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// RenderOptions contains the options used to render the manifests of a DatadogAgent.
+type RenderOptions struct {
+	// ReconcilerOptions are the operator options, the v2 reconcile loop is always used.
+	ReconcilerOptions ReconcilerOptions
+	// PlatformInfo describes the target cluster.
+	PlatformInfo kubernetes.PlatformInfo
+	// Nodes are used to compute the providers when introspection is enabled.
+	Nodes  []corev1.Node
+	Logger logr.Logger
+}
+
+// Render runs the v2 reconcile pipeline of a DatadogAgent without a Kubernetes client, and returns every object
+// it would create: the node Agent DaemonSets, the Deployments and their dependencies from the dependencies store.
+// The objects are sorted by kind, namespace and name.
+func Render(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, options RenderOptions) ([]client.Object, error) {
+	rendered, _, err := render(ctx, dda, options)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(rendered))
+	for _, r := range rendered {
+		objs = append(objs, r.obj)
+	}
+	return objs, nil
+}
+
+// renderedObject is a rendered object and its kind in the dependencies store, the kind is empty for the workloads.
+type renderedObject struct {
+	kind kubernetes.ObjectKind
+	obj  client.Object
+}
+
+// render returns the rendered objects, and the defaulted DatadogAgent they were rendered from.
+// It runs the same steps as reconcileInstanceV2, but the workloads and the dependencies store are returned
+// instead of being applied.
+func render(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, options RenderOptions) ([]renderedObject, *datadoghqv2alpha1.DatadogAgent, error) {
+	s := renderScheme()
+	logger := options.Logger
+	instance := dda.DeepCopy()
+	if instance.Namespace == "" {
+		instance.Namespace = corev1.NamespaceDefault
+	}
+
+	if err := datadoghqv2alpha1.IsValidDatadogAgent(instance); err != nil {
+		return nil, nil, err
+	}
+	datadoghqv2alpha1.DefaultDatadogAgent(instance)
+
+	reconcilerOptions := options.ReconcilerOptions
+	reconcilerOptions.V2Enabled = true
+	providerStore := kubernetes.NewProviderStore(logger)
+	r := &Reconciler{
+		options:       reconcilerOptions,
+		versionInfo:   options.PlatformInfo.GetVersionInfo(),
+		platformInfo:  options.PlatformInfo,
+		providerStore: &providerStore,
+		scheme:        s,
+		log:           logger,
+	}
+
+	featureOptions := reconcilerOptionsToFeatureOptions(&reconcilerOptions, logger)
+	features, requiredComponents := feature.BuildFeatures(instance, featureOptions)
+
+	depsStore := dependencies.NewStore(instance, &dependencies.StoreOptions{
+		SupportCilium: reconcilerOptions.SupportCilium,
+		VersionInfo:   r.versionInfo,
+		PlatformInfo:  r.platformInfo,
+		Logger:        logger,
+		Scheme:        s,
+	})
+	resourceManagers := feature.NewResourceManagers(depsStore)
+
+	var errs []error
+	for _, feat := range features {
+		depsStore.SetCurrentFeature(string(feat.ID()))
+		if err := feat.ManageDependencies(resourceManagers, requiredComponents); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	depsStore.SetCurrentFeature("")
+	errs = append(errs, override.Dependencies(logger, resourceManagers, instance)...)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("unable to render DatadogAgent %s/%s: %w", instance.Namespace, instance.Name, errors.NewAggregate(errs))
+	}
+
+	// The status isn't written, the conditions set by the build functions are ignored.
+	status := instance.Status.DeepCopy()
+	var workloads []client.Object
+
+	dca, disabled, err := r.buildV2ClusterAgent(logger, requiredComponents, features, instance, resourceManagers, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !disabled {
+		workloads = append(workloads, dca)
+	}
+
+	providers := map[string]struct{}{kubernetes.LegacyProvider: {}}
+	if reconcilerOptions.IntrospectionEnabled && len(options.Nodes) > 0 {
+		providers = map[string]struct{}{}
+		for i := range options.Nodes {
+			providers[kubernetes.DetermineNodeProvider(&options.Nodes[i])] = struct{}{}
+		}
+		providerStore.Reset(providers)
+	}
+	for _, profile := range nodeAgentProfiles(instance) {
+		for provider := range providers {
+			agentFeatures, agentRequiredComponents := nodeAgentFeatures(instance, featureOptions, features, requiredComponents, profile, provider)
+			eds, ds, disabled, err := r.buildV2Agent(logger, agentRequiredComponents, agentFeatures, instance, resourceManagers, status, provider, profile)
+			if err != nil {
+				return nil, nil, err
+			}
+			if disabled {
+				continue
+			}
+			if eds != nil {
+				workloads = append(workloads, eds)
+			} else {
+				workloads = append(workloads, ds)
+			}
+		}
+	}
+
+	ccr, disabled, err := r.buildV2ClusterChecksRunner(logger, requiredComponents, features, instance, resourceManagers, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !disabled {
+		workloads = append(workloads, ccr)
+	}
+
+	var rendered []renderedObject
+	for _, obj := range workloads {
+		// Set the owner reference and the hash annotation like createOrUpdateDeployment and createOrUpdateDaemonset.
+		if err = controllerutil.SetControllerReference(instance, obj, s); err != nil {
+			return nil, nil, err
+		}
+		if _, err = setWorkloadSpecHash(obj); err != nil {
+			return nil, nil, err
+		}
+		rendered = append(rendered, renderedObject{obj: obj})
+	}
+	for _, kind := range r.platformInfo.GetAgentResourcesKind(reconcilerOptions.SupportCilium) {
+		for _, obj := range depsStore.Objects(kind) {
+			rendered = append(rendered, renderedObject{kind: kind, obj: obj})
+		}
+	}
+
+	for _, r := range rendered {
+		if gvk, err := apiutil.GVKForObject(r.obj, s); err == nil {
+			r.obj.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+	}
+	sort.SliceStable(rendered, func(i, j int) bool {
+		return lessObject(rendered[i].obj, rendered[j].obj)
+	})
+	return rendered, instance, nil
+}
+
+// setWorkloadSpecHash sets the annotation with the hash of the spec of a workload
+func setWorkloadSpecHash(obj client.Object) (string, error) {
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		return comparison.SetMD5DatadogAgentGenerationAnnotation(&workload.ObjectMeta, workload.Spec)
+	case *appsv1.DaemonSet:
+		return comparison.SetMD5DatadogAgentGenerationAnnotation(&workload.ObjectMeta, workload.Spec)
+	case *edsv1alpha1.ExtendedDaemonSet:
+		return comparison.SetMD5DatadogAgentGenerationAnnotation(&workload.ObjectMeta, workload.Spec)
+	}
+	return "", fmt.Errorf("unsupported workload type %T", obj)
+}
+
+func renderScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(apiregistrationv1.AddToScheme(s))
+	utilruntime.Must(edsv1alpha1.AddToScheme(s))
+	utilruntime.Must(datadoghqv2alpha1.AddToScheme(s))
+	return s
+}
+
+// lessObject sorts the objects by kind, namespace and name
+func lessObject(a, b client.Object) bool {
+	ka, kb := a.GetObjectKind().GroupVersionKind().Kind, b.GetObjectKind().GroupVersionKind().Kind
+	if ka != kb {
+		return ka < kb
+	}
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
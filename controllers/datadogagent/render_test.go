@@ -0,0 +1,159 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	v2alpha1test "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1/test"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	componentagent "github.com/DataDog/datadog-operator/controllers/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name        string
+		dda         *v2alpha1.DatadogAgent
+		kubeVersion string
+		options     ReconcilerOptions
+		nodes       []corev1.Node
+		want        []string
+		wantAbsent  []string
+		wantErr     bool
+	}{
+		{
+			name: "kubernetes 1.25",
+			dda: v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").
+				WithAPMEnabled(true).
+				WithClusterChecksUseCLCEnabled(true).
+				Build(),
+			kubeVersion: "v1.25.3",
+			want: []string{
+				"DaemonSet/default/foo-agent",
+				"Deployment/default/foo-cluster-agent",
+				"Deployment/default/foo-cluster-checks-runner",
+				"ServiceAccount/default/foo-agent",
+				"ClusterRole//foo-agent",
+				"Secret/default/foo-secret",
+				"Service/default/foo-cluster-agent",
+			},
+			wantAbsent: []string{"DatadogAgent/default/foo"},
+		},
+		{
+			name: "cluster agent disabled by override",
+			dda: func() *v2alpha1.DatadogAgent {
+				dda := v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build()
+				dda.Spec.Override = map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+					v2alpha1.ClusterAgentComponentName: {Disabled: apiutils.NewBoolPointer(true)},
+				}
+				return dda
+			}(),
+			kubeVersion: "v1.25.3",
+			want:        []string{"DaemonSet/default/foo-agent"},
+			wantAbsent:  []string{"Deployment/default/foo-cluster-agent", "Deployment/default/foo-cluster-checks-runner"},
+		},
+		{
+			name:        "extended daemonset",
+			dda:         v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build(),
+			kubeVersion: "v1.25.3",
+			options:     ReconcilerOptions{ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{Enabled: true}},
+			want:        []string{"ExtendedDaemonSet/default/foo-agent", "Deployment/default/foo-cluster-agent"},
+			wantAbsent:  []string{"DaemonSet/default/foo-agent"},
+		},
+		{
+			name:        "introspection, one node Agent per provider",
+			dda:         v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build(),
+			kubeVersion: "v1.25.3",
+			options:     ReconcilerOptions{IntrospectionEnabled: true},
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{kubernetes.GKEProviderLabel: kubernetes.GKECosType}}},
+			},
+			want:       []string{"DaemonSet/default/foo-agent-default", "DaemonSet/default/foo-agent-gke-cos"},
+			wantAbsent: []string{"DaemonSet/default/foo-agent"},
+		},
+		{
+			name: "invalid spec",
+			dda: func() *v2alpha1.DatadogAgent {
+				dda := v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build()
+				dda.Spec.Global.Credentials = nil
+				return dda
+			}(),
+			kubeVersion: "v1.25.3",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platformInfo, err := kubernetes.NewPlatformInfoFromVersion(tt.kubeVersion)
+			require.NoError(t, err)
+
+			objs, err := Render(context.TODO(), tt.dda, RenderOptions{
+				ReconcilerOptions: tt.options,
+				PlatformInfo:      platformInfo,
+				Nodes:             tt.nodes,
+				Logger:            logf.Log.WithName(t.Name()),
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := []string{}
+			for _, obj := range objs {
+				assert.Empty(t, obj.GetResourceVersion())
+				got = append(got, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetNamespace()+"/"+obj.GetName())
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, got, want)
+			}
+			for _, absent := range tt.wantAbsent {
+				assert.NotContains(t, got, absent)
+			}
+			for i := 1; i < len(objs); i++ {
+				assert.False(t, lessObject(objs[i], objs[i-1]), "objects are sorted by kind, namespace and name")
+			}
+		})
+	}
+}
+
+func TestRender_workloads(t *testing.T) {
+	platformInfo, err := kubernetes.NewPlatformInfoFromVersion("v1.25.3")
+	require.NoError(t, err)
+	dda := v2alpha1test.NewInitializedDatadogAgentBuilder("", "foo").Build()
+
+	objs, err := Render(context.TODO(), dda, RenderOptions{
+		PlatformInfo: platformInfo,
+		Logger:       logf.Log.WithName(t.Name()),
+	})
+	require.NoError(t, err)
+
+	found := 0
+	for _, obj := range objs {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if kind != "DaemonSet" && kind != "Deployment" {
+			continue
+		}
+		found++
+		// The workloads are owned by the DatadogAgent and carry the hash of their spec, like the reconciled ones.
+		require.Len(t, obj.GetOwnerReferences(), 1)
+		assert.Equal(t, "foo", obj.GetOwnerReferences()[0].Name)
+		assert.NotEmpty(t, obj.GetAnnotations()[apicommon.MD5AgentDeploymentAnnotationKey])
+	}
+	assert.Equal(t, 2, found)
+}
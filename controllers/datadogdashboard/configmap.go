@@ -0,0 +1,50 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// DefinitionConfigMapField is the field index of the DatadogDashboards by the name of their definition ConfigMap
+const DefinitionConfigMapField = "spec.definitionConfigMap.name"
+
+// IndexDefinitionConfigMap returns the name of the ConfigMap holding the definition of a DatadogDashboard, to index them
+func IndexDefinitionConfigMap(obj client.Object) []string {
+	dashboard, ok := obj.(*v1alpha1.DatadogDashboard)
+	if !ok || dashboard.Spec.DefinitionConfigMap == nil {
+		return nil
+	}
+	return []string{dashboard.Spec.DefinitionConfigMap.Name}
+}
+
+// DashboardsUsingConfigMap returns the requests of the DatadogDashboards reading their definition from a ConfigMap,
+// so that the dashboards are updated when the ConfigMap changes.
+func (r *Reconciler) DashboardsUsingConfigMap(obj client.Object) []reconcile.Request {
+	dashboards := &v1alpha1.DatadogDashboardList{}
+	if err := r.client.List(context.TODO(), dashboards, client.InNamespace(obj.GetNamespace()), client.MatchingFields{DefinitionConfigMapField: obj.GetName()}); err != nil {
+		r.log.Error(err, "unable to list the DatadogDashboards")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range dashboards.Items {
+		dashboard := &dashboards.Items[i]
+		// The list is filtered again for the clients not supporting the field index
+		for _, name := range IndexDefinitionConfigMap(dashboard) {
+			if name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dashboard.Namespace, Name: dashboard.Name}})
+			}
+		}
+	}
+	return requests
+}
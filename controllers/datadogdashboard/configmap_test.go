@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func TestReconciler_DashboardsUsingConfigMap(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	newDashboard := func(namespace, name, configMap string) *v1alpha1.DatadogDashboard {
+		dashboard := &v1alpha1.DatadogDashboard{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if configMap != "" {
+			dashboard.Spec.DefinitionConfigMap = &v1alpha1.DatadogDashboardConfigMapKeySelector{Name: configMap, Key: "dashboard.json"}
+		}
+		return dashboard
+	}
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			newDashboard(resourceNamespace, "foo", "dashboards"),
+			newDashboard(resourceNamespace, "bar", "dashboards"),
+			newDashboard(resourceNamespace, "baz", "other"),
+			newDashboard(resourceNamespace, "inline", ""),
+			newDashboard("other", "foo", "dashboards"),
+		).Build(),
+		log: zap.New(zap.UseDevMode(true)),
+	}
+
+	got := r.DashboardsUsingConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: resourceNamespace, Name: "dashboards"}})
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: resourceNamespace, Name: "foo"}},
+		{NamespacedName: types.NamespacedName{Namespace: resourceNamespace, Name: "bar"}},
+	}, got)
+}
+
+func TestIndexDefinitionConfigMap(t *testing.T) {
+	assert.Nil(t, IndexDefinitionConfigMap(&v1alpha1.DatadogDashboard{}))
+	assert.Equal(t, []string{"dashboards"}, IndexDefinitionConfigMap(&v1alpha1.DatadogDashboard{
+		Spec: v1alpha1.DatadogDashboardSpec{DefinitionConfigMap: &v1alpha1.DatadogDashboardConfigMapKeySelector{Name: "dashboards", Key: "dashboard.json"}},
+	}))
+	assert.Nil(t, IndexDefinitionConfigMap(&corev1.ConfigMap{}))
+}
@@ -0,0 +1,288 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/finalizer"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	defaultRequeuePeriod      = 60 * time.Second
+	defaultErrRequeuePeriod   = 5 * time.Second
+	defaultForceSyncPeriod    = 60 * time.Minute
+	datadogDashboardKind      = "DatadogDashboard"
+	datadogDashboardFinalizer = "finalizer.dashboard.datadoghq.com"
+)
+
+type Reconciler struct {
+	client        client.Client
+	datadogClient *datadogV1.DashboardsApi
+	datadogAuth   context.Context
+	versionInfo   *version.Info
+	log           logr.Logger
+	recorder      record.EventRecorder
+}
+
+func NewReconciler(client client.Client, ddClient datadogclient.DatadogDashboardClient, versionInfo *version.Info, log logr.Logger, recorder record.EventRecorder) *Reconciler {
+	return &Reconciler{
+		client:        client,
+		datadogClient: ddClient.Client,
+		datadogAuth:   ddClient.Auth,
+		versionInfo:   versionInfo,
+		log:           log,
+		recorder:      recorder,
+	}
+}
+
+var _ reconcile.Reconciler = (*Reconciler)(nil)
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, req)
+}
+
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogdashboard", req.NamespacedName)
+	logger.Info("Reconciling Datadog Dashboard", "version", r.versionInfo.String())
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &v1alpha1.DatadogDashboard{}
+	var result ctrl.Result
+	var err error
+	if err = r.client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	final := finalizer.NewFinalizer(
+		logger,
+		r.client,
+		r.deleteResource(logger, instance),
+		defaultRequeuePeriod,
+		defaultErrRequeuePeriod,
+	)
+	if result, err = final.HandleFinalizer(ctx, instance, instance.Status.ID, datadogDashboardFinalizer); ctrutils.ShouldReturn(result, err) {
+		return result, err
+	}
+	if !instance.GetDeletionTimestamp().IsZero() {
+		// The dashboard was deleted, the object is being deleted
+		return result, nil
+	}
+
+	status := instance.Status.DeepCopy()
+	statusSpecHash := instance.Status.CurrentHash
+
+	// Validate the dashboard spec, and build the dashboard from its definition
+	if err = v1alpha1.IsValidDatadogDashboard(&instance.Spec); err != nil {
+		logger.Error(err, "invalid dashboard")
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusValidateError, "ValidatingDashboard", err)
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+	definition, err := r.getDefinition(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error getting dashboard definition")
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusValidateError, "GettingDashboardDefinition", err)
+		return r.updateStatusIfNeeded(logger, instance, status, ctrl.Result{RequeueAfter: defaultErrRequeuePeriod})
+	}
+	dashboard, err := buildDashboard(instance, definition)
+	if err != nil {
+		logger.Error(err, "invalid dashboard definition")
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusValidateError, "ValidatingDashboard", err)
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+
+	// The hash covers the definition, which can be read from a ConfigMap
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(dashboard)
+	if err != nil {
+		logger.Error(err, "error generating hash")
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusUpdateError, "GeneratingDashboardSpecHash", err)
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+
+	shouldCreate := false
+	shouldUpdate := false
+
+	if instance.Status.ID == "" {
+		shouldCreate = true
+	} else {
+		if instanceSpecHash != statusSpecHash {
+			shouldUpdate = true
+		} else if instance.Status.LastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0 {
+			// Periodically force a sync with the API dashboard to revert the changes made in the Datadog UI
+			// Get dashboard to make sure it exists before trying any updates. If it doesn't, set shouldCreate
+			_, err = getDashboard(r.datadogAuth, r.datadogClient, instance.Status.ID)
+			if err != nil {
+				logger.Error(err, "error getting dashboard", "Dashboard ID", instance.Status.ID)
+				if strings.Contains(err.Error(), ctrutils.NotFoundString) {
+					shouldCreate = true
+				}
+			} else {
+				shouldUpdate = true
+			}
+			status.LastForceSyncTime = &now
+		}
+	}
+
+	if shouldCreate {
+		err = r.create(logger, instance, dashboard, status, now, instanceSpecHash)
+		if err != nil {
+			result.RequeueAfter = defaultErrRequeuePeriod
+		}
+	} else if shouldUpdate {
+		err = r.update(logger, instance, dashboard, status, now, instanceSpecHash)
+		if err != nil {
+			result.RequeueAfter = defaultErrRequeuePeriod
+		}
+	}
+
+	// If reconcile was successful, requeue with period defaultRequeuePeriod
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result.RequeueAfter = defaultRequeuePeriod
+	}
+
+	return r.updateStatusIfNeeded(logger, instance, status, result)
+}
+
+// getDefinition returns the JSON definition of the dashboard, from the spec or from the referenced ConfigMap
+func (r *Reconciler) getDefinition(ctx context.Context, instance *v1alpha1.DatadogDashboard) (string, error) {
+	ref := instance.Spec.DefinitionConfigMap
+	if ref == nil {
+		return instance.Spec.Definition, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: ref.Name}, configMap); err != nil {
+		return "", err
+	}
+	definition, found := configMap.Data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("key %s not found in ConfigMap %s", ref.Key, ref.Name)
+	}
+	return definition, nil
+}
+
+func updateErrStatus(status *v1alpha1.DatadogDashboardStatus, now metav1.Time, syncStatus v1alpha1.DatadogDashboardSyncStatus, reason string, err error) {
+	condition.UpdateFailureStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeError, reason, err)
+	status.SyncStatus = syncStatus
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1.DatadogDashboard, status *v1alpha1.DatadogDashboardStatus, result ctrl.Result) (ctrl.Result, error) {
+	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
+		instance.Status = *status
+		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogDashboard status due to update conflict")
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogDashboard status")
+			return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, err
+		}
+	}
+	return result, nil
+}
+
+func (r *Reconciler) create(logger logr.Logger, instance *v1alpha1.DatadogDashboard, dashboard *datadogV1.Dashboard, status *v1alpha1.DatadogDashboardStatus, now metav1.Time, hash string) error {
+	logger.V(1).Info("Dashboard ID is not set; creating dashboard in Datadog")
+
+	// Create dashboard in Datadog
+	createdDashboard, err := createDashboard(r.datadogAuth, r.datadogClient, dashboard)
+	if err != nil {
+		logger.Error(err, "error creating dashboard")
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusCreateError, "CreatingDashboard", err)
+		return err
+	}
+
+	// Set condition and status
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeCreated, metav1.ConditionTrue, "CreatingDashboard", "DatadogDashboard Created")
+	status.SyncStatus = v1alpha1.DatadogDashboardSyncStatusOK
+	status.ID = createdDashboard.GetId()
+	status.URL = createdDashboard.GetUrl()
+	status.Creator = createdDashboard.GetAuthorHandle()
+	if createdTime, ok := createdDashboard.GetCreatedAtOk(); ok {
+		created := metav1.NewTime(*createdTime)
+		status.Created = &created
+	}
+	status.CurrentHash = hash
+
+	logger.Info("Created a new DatadogDashboard", "Dashboard ID", status.ID)
+	r.recordEvent(instance, buildEventInfo(instance.Name, instance.Namespace, datadog.CreationEvent))
+
+	return nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, instance *v1alpha1.DatadogDashboard, dashboard *datadogV1.Dashboard, status *v1alpha1.DatadogDashboardStatus, now metav1.Time, hash string) error {
+	updatedDashboard, err := updateDashboard(r.datadogAuth, r.datadogClient, instance.Status.ID, dashboard)
+	if err != nil {
+		logger.Error(err, "error updating dashboard", "Dashboard ID", instance.Status.ID)
+		updateErrStatus(status, now, v1alpha1.DatadogDashboardSyncStatusUpdateError, "UpdatingDashboard", err)
+		return err
+	}
+	r.recordEvent(instance, buildEventInfo(instance.Name, instance.Namespace, datadog.UpdateEvent))
+
+	// Set condition and status
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeUpdated, metav1.ConditionTrue, "UpdatingDashboard", "DatadogDashboard Updated")
+	status.SyncStatus = v1alpha1.DatadogDashboardSyncStatusOK
+	if dashboardURL := updatedDashboard.GetUrl(); dashboardURL != "" {
+		status.URL = dashboardURL
+	}
+	status.CurrentHash = hash
+
+	logger.Info("Updated DatadogDashboard", "Dashboard ID", instance.Status.ID)
+	return nil
+}
+
+func (r *Reconciler) deleteResource(logger logr.Logger, instance *v1alpha1.DatadogDashboard) finalizer.ResourceDeleteFunc {
+	return func(ctx context.Context, k8sObj client.Object, datadogID string) error {
+		if datadogID != "" {
+			kind := k8sObj.GetObjectKind().GroupVersionKind().Kind
+			if err := deleteDashboard(r.datadogAuth, r.datadogClient, datadogID); err != nil && !strings.Contains(err.Error(), ctrutils.NotFoundString) {
+				logger.Error(err, "error deleting dashboard", "kind", kind, "ID", datadogID)
+				return err
+			}
+			logger.Info("Successfully deleted object", "kind", kind, "ID", datadogID)
+		}
+		r.recordEvent(instance, buildEventInfo(k8sObj.GetName(), k8sObj.GetNamespace(), datadog.DeletionEvent))
+		return nil
+	}
+}
+
+// buildEventInfo creates a new EventInfo instance.
+func buildEventInfo(name, ns string, eventType datadog.EventType) utils.EventInfo {
+	return utils.BuildEventInfo(name, ns, datadogDashboardKind, eventType)
+}
+
+// recordEvent wraps the manager event recorder.
+func (r *Reconciler) recordEvent(dashboard runtime.Object, info utils.EventInfo) {
+	r.recorder.Event(dashboard, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
+}
@@ -0,0 +1,278 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const (
+	resourceNamespace = "default"
+	resourceName      = "dashboard"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	testLogger := zap.New(zap.UseDevMode(true))
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	request := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: resourceNamespace,
+			Name:      resourceName,
+		},
+	}
+	configMapDashboard := defaultDashboard()
+	configMapDashboard.Spec.Definition = ""
+	configMapDashboard.Spec.DefinitionConfigMap = &v1alpha1.DatadogDashboardConfigMapKeySelector{Name: "dashboards", Key: "foo.json"}
+
+	tests := []struct {
+		name                 string
+		objects              []client.Object
+		deleted              bool
+		datadogClientHandler func(t *testing.T) http.HandlerFunc
+		expectedResult       ctrl.Result
+		expectedStatus       v1alpha1.DatadogDashboardStatus
+	}{
+		{
+			name: "Create dashboard from the inline definition",
+			objects: []client.Object{
+				defaultDashboard(),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					body := datadogV1.Dashboard{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "Foo", body.GetTitle())
+					assert.Empty(t, body.GetTags())
+
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(defaultDatadogDashboardResponse())
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDashboardStatus{
+				ID:         "abc-def-ghi",
+				URL:        "/dashboard/abc-def-ghi/foo",
+				SyncStatus: v1alpha1.DatadogDashboardSyncStatusOK,
+			},
+		},
+		{
+			name: "Create dashboard from a ConfigMap",
+			objects: []client.Object{
+				configMapDashboard.DeepCopy(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: resourceNamespace, Name: "dashboards"},
+					Data:       map[string]string{"foo.json": `{"title": "Foo from ConfigMap", "layout_type": "ordered", "widgets": []}`},
+				},
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					body := datadogV1.Dashboard{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "Foo from ConfigMap", body.GetTitle())
+
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(defaultDatadogDashboardResponse())
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDashboardStatus{
+				ID:         "abc-def-ghi",
+				URL:        "/dashboard/abc-def-ghi/foo",
+				SyncStatus: v1alpha1.DatadogDashboardSyncStatusOK,
+			},
+		},
+		{
+			name: "Requeue when the ConfigMap is missing",
+			objects: []client.Object{
+				configMapDashboard.DeepCopy(),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultErrRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDashboardStatus{
+				SyncStatus: v1alpha1.DatadogDashboardSyncStatusValidateError,
+			},
+		},
+		{
+			name: "Update dashboard when the definition changed",
+			objects: []client.Object{
+				func() client.Object {
+					dashboard := defaultDashboard()
+					dashboard.Status.ID = "abc-def-ghi"
+					dashboard.Status.CurrentHash = "outdated"
+					return dashboard
+				}(),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPut, r.Method)
+					assert.Equal(t, "/api/v1/dashboard/abc-def-ghi", r.URL.Path)
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(defaultDatadogDashboardResponse())
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDashboardStatus{
+				ID:         "abc-def-ghi",
+				URL:        "/dashboard/abc-def-ghi/foo",
+				SyncStatus: v1alpha1.DatadogDashboardSyncStatusOK,
+			},
+		},
+		{
+			name: "Return error status when creating dashboard failed",
+			objects: []client.Object{
+				defaultDashboard(),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					http.Error(w, "invalid data", http.StatusBadRequest)
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultErrRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDashboardStatus{
+				SyncStatus: v1alpha1.DatadogDashboardSyncStatusCreateError,
+			},
+		},
+		{
+			name: "Delete dashboard when deleted",
+			objects: []client.Object{
+				func() client.Object {
+					dashboard := defaultDashboard()
+					dashboard.Status.ID = "abc-def-ghi"
+					return dashboard
+				}(),
+			},
+			deleted: true,
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodDelete, r.Method)
+					assert.Equal(t, "/api/v1/dashboard/abc-def-ghi", r.URL.Path)
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"deleted_dashboard_id": "abc-def-ghi"}`))
+				}
+			},
+			expectedResult: ctrl.Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := httptest.NewServer(tt.datadogClientHandler(t))
+			defer httpServer.Close()
+
+			testConfig := datadogapi.NewConfiguration()
+			testConfig.HTTPClient = httpServer.Client()
+			apiClient := datadogapi.NewAPIClient(testConfig)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+			if tt.deleted {
+				require.NoError(t, k8sClient.Delete(ctx, &v1alpha1.DatadogDashboard{ObjectMeta: metav1.ObjectMeta{Namespace: resourceNamespace, Name: resourceName}}))
+			}
+			r := &Reconciler{
+				client:        k8sClient,
+				datadogClient: datadogV1.NewDashboardsApi(apiClient),
+				datadogAuth:   setupTestAuth(httpServer.URL),
+				recorder:      record.NewFakeRecorder(5),
+				log:           testLogger,
+				versionInfo:   &version.Info{},
+			}
+
+			res, _ := r.Reconcile(ctx, request)
+			assert.Equal(t, tt.expectedResult, res)
+
+			dashboard := &v1alpha1.DatadogDashboard{}
+			err := k8sClient.Get(ctx, request.NamespacedName, dashboard)
+			if tt.deleted {
+				assert.Empty(t, dashboard.Finalizers)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus.ID, dashboard.Status.ID)
+			assert.Equal(t, tt.expectedStatus.URL, dashboard.Status.URL)
+			assert.Equal(t, tt.expectedStatus.SyncStatus, dashboard.Status.SyncStatus)
+		})
+	}
+}
+
+func defaultDashboard() *v1alpha1.DatadogDashboard {
+	return &v1alpha1.DatadogDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  resourceNamespace,
+			Name:       resourceName,
+			Finalizers: []string{datadogDashboardFinalizer},
+		},
+		Spec: v1alpha1.DatadogDashboardSpec{
+			Definition: `{"title": "Foo", "layout_type": "ordered", "widgets": []}`,
+		},
+	}
+}
+
+func defaultDatadogDashboardResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            "abc-def-ghi",
+		"url":           "/dashboard/abc-def-ghi/foo",
+		"author_handle": "foo@example.com",
+		"created_at":    "2023-05-01T00:00:00Z",
+		"title":         "Foo",
+		"layout_type":   "ordered",
+		"widgets":       []interface{}{},
+	}
+}
+
+func setupTestAuth(apiURL string) context.Context {
+	testAuth := context.WithValue(
+		context.Background(),
+		datadogapi.ContextAPIKeys,
+		map[string]datadogapi.APIKey{
+			"apiKeyAuth": {
+				Key: "DUMMY_API_KEY",
+			},
+			"appKeyAuth": {
+				Key: "DUMMY_APP_KEY",
+			},
+		},
+	)
+	parsedAPIURL, _ := url.Parse(apiURL)
+	testAuth = context.WithValue(testAuth, datadogapi.ContextServerIndex, 1)
+	testAuth = context.WithValue(testAuth, datadogapi.ContextServerVariables, map[string]string{
+		"name":     parsedAPIURL.Host,
+		"protocol": parsedAPIURL.Scheme,
+	})
+
+	return testAuth
+}
@@ -0,0 +1,125 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+)
+
+// generatedFields are the fields of an exported dashboard set by Datadog, they are not sent to the API
+var generatedFields = []string{"id", "url", "author_handle", "author_name", "created_at", "modified_at"}
+
+// buildDashboard converts a DatadogDashboard and its JSON definition to a Datadog API dashboard.
+// The fields of the spec override the ones of the definition.
+func buildDashboard(crdDashboard *v1alpha1.DatadogDashboard, definition string) (*datadogV1.Dashboard, error) {
+	spec := crdDashboard.Spec
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(definition), &obj); err != nil {
+		return nil, fmt.Errorf("invalid dashboard definition: %w", err)
+	}
+	for _, field := range generatedFields {
+		delete(obj, field)
+	}
+
+	if spec.Title != "" {
+		obj["title"] = spec.Title
+	}
+	if spec.Description != "" {
+		obj["description"] = spec.Description
+	}
+	if len(spec.Tags) > 0 {
+		obj["tags"] = spec.Tags
+	}
+	if len(spec.TemplateVariables) > 0 {
+		obj["template_variables"] = mergeTemplateVariables(obj["template_variables"], spec.TemplateVariables)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	dashboard := &datadogV1.Dashboard{}
+	if err = json.Unmarshal(data, dashboard); err != nil {
+		return nil, fmt.Errorf("invalid dashboard definition: %w", err)
+	}
+	if dashboard.UnparsedObject != nil {
+		return nil, errors.New("invalid dashboard definition: unsupported layout_type or reflow_type")
+	}
+	return dashboard, nil
+}
+
+// mergeTemplateVariables replaces the template variables of the definition with the ones of the spec having the same name,
+// and appends the other ones
+func mergeTemplateVariables(definitionVariables interface{}, specVariables []v1alpha1.DatadogDashboardTemplateVariable) []interface{} {
+	variables, _ := definitionVariables.([]interface{})
+	indexes := map[string]int{}
+	for i, variable := range variables {
+		if obj, ok := variable.(map[string]interface{}); ok {
+			if name, ok := obj["name"].(string); ok {
+				indexes[name] = i
+			}
+		}
+	}
+
+	for _, specVariable := range specVariables {
+		variable := map[string]interface{}{"name": specVariable.Name}
+		if specVariable.Prefix != "" {
+			variable["prefix"] = specVariable.Prefix
+		}
+		if len(specVariable.Defaults) > 0 {
+			variable["defaults"] = specVariable.Defaults
+		}
+		if len(specVariable.AvailableValues) > 0 {
+			variable["available_values"] = specVariable.AvailableValues
+		}
+
+		if i, found := indexes[specVariable.Name]; found {
+			variables[i] = variable
+		} else {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
+func createDashboard(auth context.Context, client *datadogV1.DashboardsApi, dashboard *datadogV1.Dashboard) (datadogV1.Dashboard, error) {
+	created, _, err := client.CreateDashboard(auth, *dashboard)
+	if err != nil {
+		return datadogV1.Dashboard{}, utils.TranslateClientError(err, "error creating dashboard")
+	}
+	return created, nil
+}
+
+func getDashboard(auth context.Context, client *datadogV1.DashboardsApi, dashboardID string) (datadogV1.Dashboard, error) {
+	dashboard, _, err := client.GetDashboard(auth, dashboardID)
+	if err != nil {
+		return datadogV1.Dashboard{}, utils.TranslateClientError(err, "error getting dashboard")
+	}
+	return dashboard, nil
+}
+
+func updateDashboard(auth context.Context, client *datadogV1.DashboardsApi, dashboardID string, dashboard *datadogV1.Dashboard) (datadogV1.Dashboard, error) {
+	updated, _, err := client.UpdateDashboard(auth, dashboardID, *dashboard)
+	if err != nil {
+		return datadogV1.Dashboard{}, utils.TranslateClientError(err, "error updating dashboard")
+	}
+	return updated, nil
+}
+
+func deleteDashboard(auth context.Context, client *datadogV1.DashboardsApi, dashboardID string) error {
+	if _, _, err := client.DeleteDashboard(auth, dashboardID); err != nil {
+		return utils.TranslateClientError(err, "error deleting dashboard")
+	}
+	return nil
+}
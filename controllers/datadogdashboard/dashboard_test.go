@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const exportedDefinition = `{
+  "id": "abc-def-ghi",
+  "url": "/dashboard/abc-def-ghi/foo",
+  "author_handle": "foo@example.com",
+  "title": "Foo",
+  "description": "Foo service",
+  "layout_type": "ordered",
+  "template_variables": [
+    {"name": "env", "prefix": "env", "defaults": ["staging"]},
+    {"name": "service", "prefix": "service"}
+  ],
+  "widgets": [
+    {"definition": {"type": "note", "content": "foo"}}
+  ]
+}`
+
+func Test_buildDashboard(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       v1alpha1.DatadogDashboardSpec
+		definition string
+		check      func(t *testing.T, dashboard *datadogV1.Dashboard)
+		wantErr    string
+	}{
+		{
+			name:       "Exported definition",
+			definition: exportedDefinition,
+			check: func(t *testing.T, dashboard *datadogV1.Dashboard) {
+				assert.Equal(t, "Foo", dashboard.GetTitle())
+				assert.Equal(t, "Foo service", dashboard.GetDescription())
+				assert.Empty(t, dashboard.GetTags())
+				assert.Equal(t, datadogV1.DASHBOARDLAYOUTTYPE_ORDERED, dashboard.GetLayoutType())
+				_, hasID := dashboard.GetIdOk()
+				assert.False(t, hasID)
+				_, hasURL := dashboard.GetUrlOk()
+				assert.False(t, hasURL)
+				_, hasAuthor := dashboard.GetAuthorHandleOk()
+				assert.False(t, hasAuthor)
+				assert.Len(t, dashboard.GetWidgets(), 1)
+			},
+		},
+		{
+			name: "Spec overrides",
+			spec: v1alpha1.DatadogDashboardSpec{
+				Title:       "Bar",
+				Description: "Bar service",
+				Tags:        []string{"team:bar"},
+				TemplateVariables: []v1alpha1.DatadogDashboardTemplateVariable{
+					{Name: "env", Prefix: "env", Defaults: []string{"prod"}},
+					{Name: "region", Prefix: "region", AvailableValues: []string{"us1", "eu1"}},
+				},
+			},
+			definition: exportedDefinition,
+			check: func(t *testing.T, dashboard *datadogV1.Dashboard) {
+				assert.Equal(t, "Bar", dashboard.GetTitle())
+				assert.Equal(t, "Bar service", dashboard.GetDescription())
+				assert.Equal(t, []string{"team:bar"}, dashboard.GetTags())
+
+				variables := dashboard.GetTemplateVariables()
+				require.Len(t, variables, 3)
+				assert.Equal(t, "env", variables[0].Name)
+				assert.Equal(t, []string{"prod"}, variables[0].Defaults)
+				assert.Equal(t, "service", variables[1].Name)
+				assert.Equal(t, "region", variables[2].Name)
+				assert.Equal(t, []string{"us1", "eu1"}, variables[2].GetAvailableValues())
+			},
+		},
+		{
+			name:       "Title set in the spec only",
+			spec:       v1alpha1.DatadogDashboardSpec{Title: "Baz"},
+			definition: `{"layout_type": "free", "widgets": []}`,
+			check: func(t *testing.T, dashboard *datadogV1.Dashboard) {
+				assert.Equal(t, "Baz", dashboard.GetTitle())
+				assert.Empty(t, dashboard.GetDescription())
+				assert.Empty(t, dashboard.GetTags())
+			},
+		},
+		{
+			name:       "Tags of the definition",
+			definition: `{"title": "Foo", "layout_type": "free", "widgets": [], "tags": ["team:foo"]}`,
+			check: func(t *testing.T, dashboard *datadogV1.Dashboard) {
+				assert.Equal(t, []string{"team:foo"}, dashboard.GetTags())
+			},
+		},
+		{
+			name:       "Missing layout type",
+			definition: `{"title": "Foo", "widgets": []}`,
+			wantErr:    "invalid dashboard definition: required field layout_type missing",
+		},
+		{
+			name:       "Invalid layout type",
+			definition: `{"title": "Foo", "layout_type": "foo", "widgets": []}`,
+			wantErr:    "invalid dashboard definition: unsupported layout_type or reflow_type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboard, err := buildDashboard(&v1alpha1.DatadogDashboard{Spec: tt.spec}, tt.definition)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, dashboard)
+		})
+	}
+}
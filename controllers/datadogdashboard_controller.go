@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogdashboard"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type DatadogDashboardReconciler struct {
+	Client      client.Client
+	DDClient    datadogclient.DatadogDashboardClient
+	VersionInfo *version.Info
+	Log         logr.Logger
+	Scheme      *runtime.Scheme
+	Recorder    record.EventRecorder
+	internal    *datadogdashboard.Reconciler
+}
+
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogdashboards,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogdashboards/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogdashboards/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile loop for Datadog Dashboard
+func (r *DatadogDashboardReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return r.internal.Reconcile(ctx, req)
+}
+
+func (r *DatadogDashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.internal = datadogdashboard.NewReconciler(r.Client, r.DDClient, r.VersionInfo, r.Log, r.Recorder)
+
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DatadogDashboard{}, datadogdashboard.DefinitionConfigMapField, datadogdashboard.IndexDefinitionConfigMap)
+	if err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DatadogDashboard{}).
+		// The dashboards are updated when the ConfigMap holding their definition changes.
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.internal.DashboardsUsingConfigMap))
+
+	err = builder.Complete(r)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = (*DatadogDashboardReconciler)(nil)
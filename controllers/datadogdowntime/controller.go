@@ -0,0 +1,317 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/finalizer"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	defaultRequeuePeriod     = 60 * time.Second
+	defaultErrRequeuePeriod  = 5 * time.Second
+	defaultForceSyncPeriod   = 60 * time.Minute
+	datadogDowntimeKind      = "DatadogDowntime"
+	datadogDowntimeFinalizer = "finalizer.downtime.datadoghq.com"
+)
+
+type Reconciler struct {
+	client        client.Client
+	datadogClient *datadogV1.DowntimesApi
+	datadogAuth   context.Context
+	versionInfo   *version.Info
+	log           logr.Logger
+	recorder      record.EventRecorder
+}
+
+func NewReconciler(client client.Client, ddClient datadogclient.DatadogDowntimeClient, versionInfo *version.Info, log logr.Logger, recorder record.EventRecorder) *Reconciler {
+	return &Reconciler{
+		client:        client,
+		datadogClient: ddClient.Client,
+		datadogAuth:   ddClient.Auth,
+		versionInfo:   versionInfo,
+		log:           log,
+		recorder:      recorder,
+	}
+}
+
+var _ reconcile.Reconciler = (*Reconciler)(nil)
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, req)
+}
+
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogdowntime", req.NamespacedName)
+	logger.Info("Reconciling Datadog Downtime", "version", r.versionInfo.String())
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &v1alpha1.DatadogDowntime{}
+	var result ctrl.Result
+	var err error
+	if err = r.client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	final := finalizer.NewFinalizer(
+		logger,
+		r.client,
+		r.deleteResource(logger, instance),
+		defaultRequeuePeriod,
+		defaultErrRequeuePeriod,
+	)
+	if result, err = final.HandleFinalizer(ctx, instance, downtimeIDString(instance.Status.ID), datadogDowntimeFinalizer); ctrutils.ShouldReturn(result, err) {
+		return result, err
+	}
+	if !instance.GetDeletionTimestamp().IsZero() {
+		// The downtime was canceled, the object is being deleted
+		return result, nil
+	}
+
+	status := instance.Status.DeepCopy()
+	statusSpecHash := instance.Status.CurrentHash
+
+	// Validate the downtime spec
+	if err = v1alpha1.IsValidDatadogDowntime(&instance.Spec); err != nil {
+		logger.Error(err, "invalid downtime")
+		updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusValidateError, "ValidatingDowntime", err)
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	if err != nil {
+		logger.Error(err, "error generating hash")
+		updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusUpdateError, "GeneratingDowntimeSpecHash", err)
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+
+	shouldCreate := false
+	shouldUpdate := false
+
+	if instance.Status.ID == 0 {
+		shouldCreate = true
+	} else {
+		if instanceSpecHash != statusSpecHash {
+			shouldUpdate = true
+		} else if instance.Status.LastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0 {
+			// Periodically force a sync with the API downtime to ensure parity
+			// Get downtime to make sure it exists before trying any updates. If it doesn't, set shouldCreate
+			shouldCreate, err = r.refreshState(logger, instance, status, now)
+			if err == nil && !shouldCreate {
+				shouldUpdate = true
+			}
+			status.LastForceSyncTime = &now
+		} else if instance.Status.StateLastUpdateTime == nil || (defaultRequeuePeriod-now.Sub(instance.Status.StateLastUpdateTime.Time)) <= 0 {
+			// Refresh the downtime state, downtimes start and end on their own
+			shouldCreate, _ = r.refreshState(logger, instance, status, now)
+		}
+	}
+
+	if shouldCreate || shouldUpdate {
+		var monitorID *int64
+		if monitorID, err = r.getMonitorID(ctx, instance); err != nil {
+			logger.Error(err, "error getting the monitor selected by the downtime")
+			updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusValidateError, "GettingDowntimeMonitor", err)
+			result.RequeueAfter = defaultErrRequeuePeriod
+			return r.updateStatusIfNeeded(logger, instance, status, result)
+		}
+		if shouldCreate {
+			err = r.create(logger, instance, status, now, instanceSpecHash, monitorID)
+		} else {
+			err = r.update(logger, instance, status, now, instanceSpecHash, monitorID)
+		}
+		if err != nil {
+			result.RequeueAfter = defaultErrRequeuePeriod
+		}
+	}
+
+	// Report the downtime on the monitors it silences
+	if err = r.updateMonitorsDowntimeStatus(ctx, logger, instance, status, false); err != nil {
+		result.RequeueAfter = defaultErrRequeuePeriod
+	}
+
+	// If reconcile was successful, requeue with period defaultRequeuePeriod
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result.RequeueAfter = defaultRequeuePeriod
+	}
+
+	return r.updateStatusIfNeeded(logger, instance, status, result)
+}
+
+// refreshState gets the downtime from the API and updates its state in the status. It returns true when the downtime
+// doesn't exist anymore, or was canceled outside of the operator, and has to be created again.
+func (r *Reconciler) refreshState(logger logr.Logger, instance *v1alpha1.DatadogDowntime, status *v1alpha1.DatadogDowntimeStatus, now metav1.Time) (bool, error) {
+	downtime, err := getDowntime(r.datadogAuth, r.datadogClient, instance.Status.ID)
+	if err != nil {
+		logger.Error(err, "error getting downtime", "Downtime ID", instance.Status.ID)
+		if strings.Contains(err.Error(), ctrutils.NotFoundString) {
+			return true, nil
+		}
+		updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusGetError, "GettingDowntime", err)
+		return false, err
+	}
+	if _, canceled := downtime.GetCanceledOk(); canceled {
+		logger.Info("Downtime was canceled outside of the operator", "Downtime ID", instance.Status.ID)
+		return true, nil
+	}
+
+	status.Active = isActive(downtime)
+	status.StateLastUpdateTime = &now
+	return false, nil
+}
+
+// getMonitorID returns the ID of the monitor selected by the downtime, or nil when the downtime doesn't select a single monitor
+func (r *Reconciler) getMonitorID(ctx context.Context, instance *v1alpha1.DatadogDowntime) (*int64, error) {
+	selector := instance.Spec.MonitorSelector
+	if selector == nil {
+		return nil, nil
+	}
+	if selector.ID != nil {
+		return selector.ID, nil
+	}
+	if selector.DatadogMonitorName == "" {
+		return nil, nil
+	}
+
+	monitor := &v1alpha1.DatadogMonitor{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: selector.DatadogMonitorName}, monitor); err != nil {
+		return nil, err
+	}
+	if monitor.Status.ID == 0 {
+		return nil, errMonitorNotCreated(monitor.Name)
+	}
+	id := int64(monitor.Status.ID)
+	return &id, nil
+}
+
+func updateErrStatus(status *v1alpha1.DatadogDowntimeStatus, now metav1.Time, syncStatus v1alpha1.DatadogDowntimeSyncStatus, reason string, err error) {
+	condition.UpdateFailureStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeError, reason, err)
+	status.SyncStatus = syncStatus
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1.DatadogDowntime, status *v1alpha1.DatadogDowntimeStatus, result ctrl.Result) (ctrl.Result, error) {
+	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
+		instance.Status = *status
+		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogDowntime status due to update conflict")
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogDowntime status")
+			return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, err
+		}
+	}
+	return result, nil
+}
+
+func (r *Reconciler) create(logger logr.Logger, instance *v1alpha1.DatadogDowntime, status *v1alpha1.DatadogDowntimeStatus, now metav1.Time, hash string, monitorID *int64) error {
+	logger.V(1).Info("Downtime ID is not set; creating downtime in Datadog")
+
+	// Create downtime in Datadog
+	createdDowntime, err := createDowntime(r.datadogAuth, r.datadogClient, instance, monitorID)
+	if err != nil {
+		logger.Error(err, "error creating downtime")
+		updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusCreateError, "CreatingDowntime", err)
+		return err
+	}
+
+	// Set condition and status
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeCreated, metav1.ConditionTrue, "CreatingDowntime", "DatadogDowntime Created")
+	status.SyncStatus = v1alpha1.DatadogDowntimeSyncStatusOK
+	status.ID = int(createdDowntime.GetId())
+	status.Active = isActive(createdDowntime)
+	status.StateLastUpdateTime = &now
+	status.CurrentHash = hash
+
+	logger.Info("Created a new DatadogDowntime", "Downtime ID", status.ID)
+	r.recordEvent(instance, buildEventInfo(instance.Name, instance.Namespace, datadog.CreationEvent))
+
+	return nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, instance *v1alpha1.DatadogDowntime, status *v1alpha1.DatadogDowntimeStatus, now metav1.Time, hash string, monitorID *int64) error {
+	updatedDowntime, err := updateDowntime(r.datadogAuth, r.datadogClient, instance, monitorID)
+	if err != nil {
+		logger.Error(err, "error updating downtime", "Downtime ID", instance.Status.ID)
+		updateErrStatus(status, now, v1alpha1.DatadogDowntimeSyncStatusUpdateError, "UpdatingDowntime", err)
+		return err
+	}
+	r.recordEvent(instance, buildEventInfo(instance.Name, instance.Namespace, datadog.UpdateEvent))
+
+	// Set condition and status
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeUpdated, metav1.ConditionTrue, "UpdatingDowntime", "DatadogDowntime Updated")
+	status.SyncStatus = v1alpha1.DatadogDowntimeSyncStatusOK
+	status.Active = isActive(updatedDowntime)
+	status.StateLastUpdateTime = &now
+	status.CurrentHash = hash
+
+	logger.Info("Updated DatadogDowntime", "Downtime ID", instance.Status.ID)
+	return nil
+}
+
+func (r *Reconciler) deleteResource(logger logr.Logger, instance *v1alpha1.DatadogDowntime) finalizer.ResourceDeleteFunc {
+	return func(ctx context.Context, k8sObj client.Object, datadogID string) error {
+		if datadogID != "" {
+			kind := k8sObj.GetObjectKind().GroupVersionKind().Kind
+			if err := cancelDowntime(r.datadogAuth, r.datadogClient, instance.Status.ID); err != nil && !strings.Contains(err.Error(), ctrutils.NotFoundString) {
+				logger.Error(err, "error canceling downtime", "kind", kind, "ID", datadogID)
+				return err
+			}
+			logger.Info("Successfully deleted object", "kind", kind, "ID", datadogID)
+		}
+		if err := r.updateMonitorsDowntimeStatus(ctx, logger, instance, &instance.Status, true); err != nil {
+			return err
+		}
+		r.recordEvent(instance, buildEventInfo(k8sObj.GetName(), k8sObj.GetNamespace(), datadog.DeletionEvent))
+		return nil
+	}
+}
+
+func downtimeIDString(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// buildEventInfo creates a new EventInfo instance.
+func buildEventInfo(name, ns string, eventType datadog.EventType) utils.EventInfo {
+	return utils.BuildEventInfo(name, ns, datadogDowntimeKind, eventType)
+}
+
+// recordEvent wraps the manager event recorder.
+func (r *Reconciler) recordEvent(downtime runtime.Object, info utils.EventInfo) {
+	r.recorder.Event(downtime, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
+}
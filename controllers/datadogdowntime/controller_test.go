@@ -0,0 +1,336 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const (
+	resourceNamespace = "default"
+	resourceName      = "downtime"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	testLogger := zap.New(zap.UseDevMode(true))
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	request := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: resourceNamespace,
+			Name:      resourceName,
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		objects              []client.Object
+		deleted              bool
+		datadogClientHandler func(t *testing.T) http.HandlerFunc
+		expectedResult       ctrl.Result
+		expectedStatus       v1alpha1.DatadogDowntimeStatus
+		expectedMonitors     map[string]v1alpha1.DatadogMonitorDowntimeStatus
+	}{
+		{
+			name:    "Return empty result when downtime is not found",
+			objects: []client.Object{},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			},
+			expectedResult: ctrl.Result{},
+		},
+		{
+			name: "Create downtime when not exists",
+			objects: []client.Object{
+				defaultDowntime(),
+				newMonitor("foo", 42, v1alpha1.DatadogMonitorDowntimeStatus{}),
+				newMonitor("bar", 43, v1alpha1.DatadogMonitorDowntimeStatus{}),
+				newMonitor("baz", 44, v1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 123}),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					body := datadogV1.Downtime{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, int64(42), body.GetMonitorId())
+					assert.Equal(t, "Upgrading the database", body.GetMessage())
+
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(datadogV1.Downtime{Id: int64Ptr(123), Active: boolPtr(true)})
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDowntimeStatus{
+				ID:         123,
+				Active:     true,
+				SyncStatus: v1alpha1.DatadogDowntimeSyncStatusOK,
+			},
+			expectedMonitors: map[string]v1alpha1.DatadogMonitorDowntimeStatus{
+				"foo": {IsDowntimed: true, DowntimeID: 123},
+				"bar": {},
+				"baz": {},
+			},
+		},
+		{
+			name: "Don't replace an active downtime with an inactive one",
+			objects: []client.Object{
+				defaultDowntime(),
+				newMonitor("foo", 42, v1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 100}),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(datadogV1.Downtime{Id: int64Ptr(123), Active: boolPtr(false)})
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDowntimeStatus{
+				ID:         123,
+				SyncStatus: v1alpha1.DatadogDowntimeSyncStatusOK,
+			},
+			expectedMonitors: map[string]v1alpha1.DatadogMonitorDowntimeStatus{
+				"foo": {IsDowntimed: true, DowntimeID: 100},
+			},
+		},
+		{
+			name: "Requeue when the selected monitor is not created yet",
+			objects: []client.Object{
+				defaultDowntime(),
+				newMonitor("foo", 0, v1alpha1.DatadogMonitorDowntimeStatus{}),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultErrRequeuePeriod},
+			expectedStatus: v1alpha1.DatadogDowntimeStatus{
+				SyncStatus: v1alpha1.DatadogDowntimeSyncStatusValidateError,
+			},
+			expectedMonitors: map[string]v1alpha1.DatadogMonitorDowntimeStatus{
+				"foo": {},
+			},
+		},
+		{
+			name: "Invalid downtime",
+			objects: []client.Object{
+				func() client.Object {
+					downtime := defaultDowntime()
+					downtime.Spec.Scope = nil
+					return downtime
+				}(),
+			},
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			},
+			expectedResult: ctrl.Result{},
+			expectedStatus: v1alpha1.DatadogDowntimeStatus{
+				SyncStatus: v1alpha1.DatadogDowntimeSyncStatusValidateError,
+			},
+		},
+		{
+			name: "Cancel downtime when deleted",
+			objects: []client.Object{
+				func() client.Object {
+					downtime := defaultDowntime()
+					downtime.Status.ID = 123
+					downtime.Status.Active = true
+					return downtime
+				}(),
+				newMonitor("foo", 42, v1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 123}),
+			},
+			deleted: true,
+			datadogClientHandler: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodDelete, r.Method)
+					assert.Equal(t, "/api/v1/downtime/123", r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}
+			},
+			expectedResult: ctrl.Result{},
+			expectedMonitors: map[string]v1alpha1.DatadogMonitorDowntimeStatus{
+				"foo": {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := httptest.NewServer(tt.datadogClientHandler(t))
+			defer httpServer.Close()
+
+			testConfig := datadogapi.NewConfiguration()
+			testConfig.HTTPClient = httpServer.Client()
+			apiClient := datadogapi.NewAPIClient(testConfig)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+			if tt.deleted {
+				require.NoError(t, k8sClient.Delete(ctx, &v1alpha1.DatadogDowntime{ObjectMeta: metav1.ObjectMeta{Namespace: resourceNamespace, Name: resourceName}}))
+			}
+			r := &Reconciler{
+				client:        k8sClient,
+				datadogClient: datadogV1.NewDowntimesApi(apiClient),
+				datadogAuth:   setupTestAuth(httpServer.URL),
+				recorder:      record.NewFakeRecorder(5),
+				log:           testLogger,
+				versionInfo:   &version.Info{},
+			}
+
+			res, _ := r.Reconcile(ctx, request)
+			assert.Equal(t, tt.expectedResult, res)
+
+			downtime := &v1alpha1.DatadogDowntime{}
+			if err := k8sClient.Get(ctx, request.NamespacedName, downtime); err == nil && downtime.DeletionTimestamp.IsZero() {
+				assert.Equal(t, tt.expectedStatus.ID, downtime.Status.ID)
+				assert.Equal(t, tt.expectedStatus.Active, downtime.Status.Active)
+				assert.Equal(t, tt.expectedStatus.SyncStatus, downtime.Status.SyncStatus)
+			}
+
+			for name, expected := range tt.expectedMonitors {
+				monitor := &v1alpha1.DatadogMonitor{}
+				require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: resourceNamespace, Name: name}, monitor))
+				assert.Equal(t, expected, monitor.Status.DowntimeStatus, name)
+			}
+		})
+	}
+}
+
+func Test_isMonitorSelected(t *testing.T) {
+	monitor := newMonitor("foo", 42, v1alpha1.DatadogMonitorDowntimeStatus{})
+	monitor.Spec.Tags = []string{"env:prod", "team:foo"}
+
+	tests := []struct {
+		name     string
+		selector *v1alpha1.DatadogDowntimeMonitorSelector
+		expected bool
+	}{
+		{
+			name:     "No selector",
+			selector: nil,
+			expected: true,
+		},
+		{
+			name:     "Same name",
+			selector: &v1alpha1.DatadogDowntimeMonitorSelector{DatadogMonitorName: "foo"},
+			expected: true,
+		},
+		{
+			name:     "Other name",
+			selector: &v1alpha1.DatadogDowntimeMonitorSelector{DatadogMonitorName: "bar"},
+			expected: false,
+		},
+		{
+			name:     "Same ID",
+			selector: &v1alpha1.DatadogDowntimeMonitorSelector{ID: int64Ptr(42)},
+			expected: true,
+		},
+		{
+			name:     "Subset of the tags",
+			selector: &v1alpha1.DatadogDowntimeMonitorSelector{Tags: []string{"team:foo"}},
+			expected: true,
+		},
+		{
+			name:     "Missing tag",
+			selector: &v1alpha1.DatadogDowntimeMonitorSelector{Tags: []string{"team:foo", "env:staging"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isMonitorSelected(tt.selector, monitor))
+		})
+	}
+}
+
+func defaultDowntime() *v1alpha1.DatadogDowntime {
+	return &v1alpha1.DatadogDowntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  resourceNamespace,
+			Name:       resourceName,
+			Finalizers: []string{datadogDowntimeFinalizer},
+		},
+		Spec: v1alpha1.DatadogDowntimeSpec{
+			Scope:           []string{"env:prod"},
+			MonitorSelector: &v1alpha1.DatadogDowntimeMonitorSelector{DatadogMonitorName: "foo"},
+			Message:         "Upgrading the database",
+		},
+	}
+}
+
+func newMonitor(name string, id int, downtimeStatus v1alpha1.DatadogMonitorDowntimeStatus) *v1alpha1.DatadogMonitor {
+	return &v1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourceNamespace,
+			Name:      name,
+		},
+		Status: v1alpha1.DatadogMonitorStatus{
+			ID:             id,
+			DowntimeStatus: downtimeStatus,
+		},
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func setupTestAuth(apiURL string) context.Context {
+	testAuth := context.WithValue(
+		context.Background(),
+		datadogapi.ContextAPIKeys,
+		map[string]datadogapi.APIKey{
+			"apiKeyAuth": {
+				Key: "DUMMY_API_KEY",
+			},
+			"appKeyAuth": {
+				Key: "DUMMY_APP_KEY",
+			},
+		},
+	)
+	parsedAPIURL, _ := url.Parse(apiURL)
+	testAuth = context.WithValue(testAuth, datadogapi.ContextServerIndex, 1)
+	testAuth = context.WithValue(testAuth, datadogapi.ContextServerVariables, map[string]string{
+		"name":     parsedAPIURL.Host,
+		"protocol": parsedAPIURL.Scheme,
+	})
+
+	return testAuth
+}
@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+)
+
+// buildDowntime converts a DatadogDowntime to a Datadog API downtime. monitorID is the ID of the silenced monitor,
+// it is resolved by the controller when the downtime selects a monitor.
+func buildDowntime(crdDowntime *v1alpha1.DatadogDowntime, monitorID *int64) *datadogV1.Downtime {
+	spec := crdDowntime.Spec
+	downtime := datadogV1.NewDowntime()
+	downtime.SetScope(spec.Scope)
+
+	if monitorID != nil {
+		downtime.SetMonitorId(*monitorID)
+	} else {
+		downtime.SetMonitorIdNil()
+	}
+	if spec.MonitorSelector != nil && len(spec.MonitorSelector.Tags) > 0 {
+		downtime.SetMonitorTags(spec.MonitorSelector.Tags)
+	} else {
+		downtime.SetMonitorTags([]string{"*"})
+	}
+
+	if spec.Start != nil {
+		downtime.SetStart(spec.Start.Unix())
+	}
+	if spec.End != nil {
+		downtime.SetEnd(spec.End.Unix())
+	} else {
+		downtime.SetEndNil()
+	}
+	if spec.Timezone != "" {
+		downtime.SetTimezone(spec.Timezone)
+	}
+
+	if spec.Recurrence != nil {
+		downtime.SetRecurrence(buildRecurrence(spec.Recurrence))
+	} else {
+		downtime.SetRecurrenceNil()
+	}
+
+	downtime.SetMessage(spec.Message)
+	if spec.MuteFirstRecoveryNotification != nil {
+		downtime.SetMuteFirstRecoveryNotification(*spec.MuteFirstRecoveryNotification)
+	}
+
+	return downtime
+}
+
+func buildRecurrence(crdRecurrence *v1alpha1.DatadogDowntimeRecurrence) datadogV1.DowntimeRecurrence {
+	recurrence := datadogV1.NewDowntimeRecurrence()
+	recurrence.SetType(string(crdRecurrence.Type))
+	if crdRecurrence.Type == v1alpha1.DatadogDowntimeRecurrenceTypeRRule {
+		recurrence.SetRrule(crdRecurrence.RRule)
+	} else {
+		recurrence.SetPeriod(crdRecurrence.Period)
+	}
+	if len(crdRecurrence.WeekDays) > 0 {
+		recurrence.SetWeekDays(crdRecurrence.WeekDays)
+	}
+	if crdRecurrence.UntilDate != nil {
+		recurrence.SetUntilDate(crdRecurrence.UntilDate.Unix())
+	}
+	if crdRecurrence.UntilOccurrences != nil {
+		recurrence.SetUntilOccurrences(*crdRecurrence.UntilOccurrences)
+	}
+	return *recurrence
+}
+
+func createDowntime(auth context.Context, client *datadogV1.DowntimesApi, crdDowntime *v1alpha1.DatadogDowntime, monitorID *int64) (datadogV1.Downtime, error) {
+	downtime := buildDowntime(crdDowntime, monitorID)
+	created, _, err := client.CreateDowntime(auth, *downtime)
+	if err != nil {
+		return datadogV1.Downtime{}, utils.TranslateClientError(err, "error creating downtime")
+	}
+	return created, nil
+}
+
+func getDowntime(auth context.Context, client *datadogV1.DowntimesApi, downtimeID int) (datadogV1.Downtime, error) {
+	downtime, _, err := client.GetDowntime(auth, int64(downtimeID))
+	if err != nil {
+		return datadogV1.Downtime{}, utils.TranslateClientError(err, "error getting downtime")
+	}
+	return downtime, nil
+}
+
+func updateDowntime(auth context.Context, client *datadogV1.DowntimesApi, crdDowntime *v1alpha1.DatadogDowntime, monitorID *int64) (datadogV1.Downtime, error) {
+	downtime := buildDowntime(crdDowntime, monitorID)
+	updated, _, err := client.UpdateDowntime(auth, int64(crdDowntime.Status.ID), *downtime)
+	if err != nil {
+		return datadogV1.Downtime{}, utils.TranslateClientError(err, "error updating downtime")
+	}
+	return updated, nil
+}
+
+func cancelDowntime(auth context.Context, client *datadogV1.DowntimesApi, downtimeID int) error {
+	if _, err := client.CancelDowntime(auth, int64(downtimeID)); err != nil {
+		return utils.TranslateClientError(err, "error canceling downtime")
+	}
+	return nil
+}
+
+// isActive returns true when the downtime is currently silencing monitors
+func isActive(downtime datadogV1.Downtime) bool {
+	return downtime.GetActive() && !downtime.GetDisabled()
+}
@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func Test_buildDowntime(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 1, 7, 22, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(2 * time.Hour))
+	occurrences := int32(4)
+
+	downtime := &v1alpha1.DatadogDowntime{
+		Spec: v1alpha1.DatadogDowntimeSpec{
+			Scope:           []string{"env:prod", "service:db"},
+			MonitorSelector: &v1alpha1.DatadogDowntimeMonitorSelector{Tags: []string{"team:db"}},
+			Start:           &start,
+			End:             &end,
+			Timezone:        "Europe/Paris",
+			Recurrence: &v1alpha1.DatadogDowntimeRecurrence{
+				Type:             v1alpha1.DatadogDowntimeRecurrenceTypeWeeks,
+				Period:           1,
+				WeekDays:         []string{"Sat"},
+				UntilOccurrences: &occurrences,
+			},
+			Message:                       "Upgrading the database",
+			MuteFirstRecoveryNotification: apiutils.NewBoolPointer(true),
+		},
+	}
+
+	result := buildDowntime(downtime, nil)
+	assert.Equal(t, []string{"env:prod", "service:db"}, result.GetScope())
+	assert.Equal(t, []string{"team:db"}, result.GetMonitorTags())
+	monitorID, _ := result.GetMonitorIdOk()
+	assert.Nil(t, monitorID)
+	assert.Equal(t, start.Unix(), result.GetStart())
+	assert.Equal(t, end.Unix(), result.GetEnd())
+	assert.Equal(t, "Europe/Paris", result.GetTimezone())
+	assert.Equal(t, "Upgrading the database", result.GetMessage())
+	assert.True(t, result.GetMuteFirstRecoveryNotification())
+
+	recurrence := result.GetRecurrence()
+	assert.Equal(t, "weeks", recurrence.GetType())
+	assert.Equal(t, int32(1), recurrence.GetPeriod())
+	assert.Equal(t, []string{"Sat"}, recurrence.GetWeekDays())
+	assert.Equal(t, int32(4), recurrence.GetUntilOccurrences())
+}
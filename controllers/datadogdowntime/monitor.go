@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func errMonitorNotCreated(name string) error {
+	return fmt.Errorf("DatadogMonitor %s is not created in Datadog yet", name)
+}
+
+// updateMonitorsDowntimeStatus reports the downtime in the status of the DatadogMonitors of its namespace it silences,
+// and removes it from the ones it doesn't silence anymore. When the downtime is deleted, it is removed from every DatadogMonitor.
+// An inactive downtime doesn't replace another downtime, and an active downtime doesn't replace another active downtime.
+func (r *Reconciler) updateMonitorsDowntimeStatus(ctx context.Context, logger logr.Logger, instance *v1alpha1.DatadogDowntime, status *v1alpha1.DatadogDowntimeStatus, deleted bool) error {
+	if status.ID == 0 {
+		return nil
+	}
+
+	monitors := &v1alpha1.DatadogMonitorList{}
+	if err := r.client.List(ctx, monitors, client.InNamespace(instance.Namespace)); err != nil {
+		logger.Error(err, "error listing DatadogMonitors")
+		return err
+	}
+
+	for i := range monitors.Items {
+		monitor := &monitors.Items[i]
+		current := monitor.Status.DowntimeStatus
+		desired := current
+		if !deleted && isMonitorSelected(instance.Spec.MonitorSelector, monitor) {
+			if current.DowntimeID == status.ID || (status.Active && !current.IsDowntimed) || current.DowntimeID == 0 {
+				desired = v1alpha1.DatadogMonitorDowntimeStatus{
+					IsDowntimed: status.Active,
+					DowntimeID:  status.ID,
+				}
+			}
+		} else if current.DowntimeID == status.ID {
+			desired = v1alpha1.DatadogMonitorDowntimeStatus{}
+		}
+
+		if desired == current {
+			continue
+		}
+		monitor.Status.DowntimeStatus = desired
+		if err := r.client.Status().Update(ctx, monitor); err != nil {
+			logger.Error(err, "error updating DatadogMonitor downtime status", "DatadogMonitor", monitor.Name)
+			return err
+		}
+		logger.V(1).Info("Updated DatadogMonitor downtime status", "DatadogMonitor", monitor.Name, "Downtime ID", status.ID, "Downtimed", desired.IsDowntimed)
+	}
+	return nil
+}
+
+// isMonitorSelected returns true if the DatadogMonitor is silenced by a downtime using the selector.
+// All the monitors are silenced when the selector is nil.
+func isMonitorSelected(selector *v1alpha1.DatadogDowntimeMonitorSelector, monitor *v1alpha1.DatadogMonitor) bool {
+	switch {
+	case selector == nil:
+		return true
+	case selector.DatadogMonitorName != "":
+		return selector.DatadogMonitorName == monitor.Name
+	case selector.ID != nil:
+		return monitor.Status.ID != 0 && int64(monitor.Status.ID) == *selector.ID
+	}
+
+	monitorTags := make(map[string]bool, len(monitor.Spec.Tags))
+	for _, tag := range monitor.Spec.Tags {
+		monitorTags[tag] = true
+	}
+	for _, tag := range selector.Tags {
+		if !monitorTags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// DowntimesSelecting returns the requests of the downtimes of the namespace of a DatadogMonitor which may silence it,
+// so that its downtime status is updated when it is created, deleted, or when its ID or tags change.
+func (r *Reconciler) DowntimesSelecting(obj client.Object) []reconcile.Request {
+	downtimes := &v1alpha1.DatadogDowntimeList{}
+	if err := r.client.List(context.TODO(), downtimes, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list the DatadogDowntimes")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, downtime := range downtimes.Items {
+		// The downtimes selecting another monitor by name also clear the status of a monitor they don't select anymore,
+		// but they are already reconciled when their own selector changes.
+		selector := downtime.Spec.MonitorSelector
+		if selector != nil && selector.DatadogMonitorName != "" && selector.DatadogMonitorName != obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: downtime.Namespace, Name: downtime.Name}})
+	}
+	return requests
+}
+
+// MonitorSelectionChanged filters the DatadogMonitor updates to the ones changing how downtimes select them:
+// their ID, once created in Datadog, and their tags. The downtime status updates are ignored.
+var MonitorSelectionChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldMonitor, okOld := e.ObjectOld.(*v1alpha1.DatadogMonitor)
+		newMonitor, okNew := e.ObjectNew.(*v1alpha1.DatadogMonitor)
+		if !okOld || !okNew {
+			return false
+		}
+		return oldMonitor.Status.ID != newMonitor.Status.ID || !apiequality.Semantic.DeepEqual(oldMonitor.Spec.Tags, newMonitor.Spec.Tags)
+	},
+}
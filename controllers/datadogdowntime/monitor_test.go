@@ -0,0 +1,70 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func TestReconciler_DowntimesSelecting(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	newDowntime := func(namespace, name string, selector *v1alpha1.DatadogDowntimeMonitorSelector) *v1alpha1.DatadogDowntime {
+		return &v1alpha1.DatadogDowntime{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.DatadogDowntimeSpec{MonitorSelector: selector},
+		}
+	}
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			newDowntime(resourceNamespace, "all", nil),
+			newDowntime(resourceNamespace, "by-name", &v1alpha1.DatadogDowntimeMonitorSelector{DatadogMonitorName: "foo"}),
+			newDowntime(resourceNamespace, "other-name", &v1alpha1.DatadogDowntimeMonitorSelector{DatadogMonitorName: "bar"}),
+			newDowntime(resourceNamespace, "by-tags", &v1alpha1.DatadogDowntimeMonitorSelector{Tags: []string{"env:prod"}}),
+			newDowntime("other", "all", nil),
+		).Build(),
+		log: zap.New(zap.UseDevMode(true)),
+	}
+
+	got := r.DowntimesSelecting(newMonitor("foo", 42, v1alpha1.DatadogMonitorDowntimeStatus{}))
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: resourceNamespace, Name: "all"}},
+		{NamespacedName: types.NamespacedName{Namespace: resourceNamespace, Name: "by-name"}},
+		{NamespacedName: types.NamespacedName{Namespace: resourceNamespace, Name: "by-tags"}},
+	}, got)
+}
+
+func TestMonitorSelectionChanged(t *testing.T) {
+	oldMonitor := newMonitor("foo", 0, v1alpha1.DatadogMonitorDowntimeStatus{})
+	oldMonitor.Spec.Tags = []string{"env:prod"}
+
+	created := oldMonitor.DeepCopy()
+	created.Status.ID = 42
+	retagged := oldMonitor.DeepCopy()
+	retagged.Spec.Tags = []string{"env:staging"}
+	downtimed := oldMonitor.DeepCopy()
+	downtimed.Status.DowntimeStatus = v1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 123}
+
+	assert.True(t, MonitorSelectionChanged.Update(event.UpdateEvent{ObjectOld: oldMonitor, ObjectNew: created}))
+	assert.True(t, MonitorSelectionChanged.Update(event.UpdateEvent{ObjectOld: oldMonitor, ObjectNew: retagged}))
+	assert.False(t, MonitorSelectionChanged.Update(event.UpdateEvent{ObjectOld: oldMonitor, ObjectNew: downtimed}))
+	assert.True(t, MonitorSelectionChanged.Create(event.CreateEvent{Object: created}))
+	assert.True(t, MonitorSelectionChanged.Delete(event.DeleteEvent{Object: created}))
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+// getAdoptedMonitorID returns the ID of the existing monitor to adopt, it returns 0 when the annotation isn't set
+func getAdoptedMonitorID(datadogMonitor *datadoghqv1alpha1.DatadogMonitor) (int, error) {
+	value, found := datadogMonitor.GetAnnotations()[datadoghqv1alpha1.DatadogMonitorIDAnnotationKey]
+	if !found {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, it must be a monitor ID", datadoghqv1alpha1.DatadogMonitorIDAnnotationKey, value)
+	}
+	return id, nil
+}
+
+// isAdoptedMonitorPrimary returns true if the adopted monitor is deleted with the DatadogMonitor, the user opts in with an annotation
+func isAdoptedMonitorPrimary(datadogMonitor *datadoghqv1alpha1.DatadogMonitor) bool {
+	return datadogMonitor.GetAnnotations()[datadoghqv1alpha1.DatadogMonitorPrimaryAnnotationKey] == "true"
+}
+
+// adopt makes the DatadogMonitor manage an existing monitor instead of creating a new one. The monitor is updated
+// afterwards to match the spec, the fields the update will change are reported in an event.
+func (r *Reconciler) adopt(ctx context.Context, logger logr.Logger, ddClient datadogclient.DatadogMonitorClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, monitorID int, desired *datadoghqv1alpha1.DatadogMonitorSpec, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
+	monitors := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err := r.client.List(ctx, monitors); err != nil {
+		return fmt.Errorf("unable to list the DatadogMonitors: %w", err)
+	}
+	for _, dm := range monitors.Items {
+		if dm.Status.ID == monitorID && (dm.Namespace != datadogMonitor.Namespace || dm.Name != datadogMonitor.Name) {
+			return fmt.Errorf("monitor %d is already managed by the DatadogMonitor %s/%s", monitorID, dm.Namespace, dm.Name)
+		}
+	}
+
+	m, err := getMonitor(ddClient.Auth, ddClient.Client, monitorID)
+	if err != nil {
+		status.MonitorStateSyncStatus = datadoghqv1alpha1.MonitorStateSyncStatusGetError
+		return err
+	}
+	// The type of a monitor can't be updated
+	if string(m.GetType()) != string(desired.Type) {
+		return fmt.Errorf("monitor %d has the type %s, it can't be adopted by a DatadogMonitor of type %s", monitorID, m.GetType(), desired.Type)
+	}
+
+	status.ID = monitorID
+	creator := m.GetCreator()
+	status.Creator = creator.GetEmail()
+	createdTime := metav1.NewTime(m.GetCreated())
+	status.Created = &createdTime
+	status.Primary = isAdoptedMonitorPrimary(datadogMonitor)
+	status.CredentialsSecretRef = datadogMonitor.Spec.ControllerOptions.CredentialsSecretRef.DeepCopy()
+
+	diff := diffDatadogMonitorSpec(*desired, BuildDatadogMonitorSpec(m))
+	if len(diff) == 0 {
+		r.recorder.Eventf(datadogMonitor, corev1.EventTypeNormal, "AdoptedMonitor", "Adopted monitor %d, it already matches the spec", monitorID)
+	} else {
+		r.recorder.Eventf(datadogMonitor, corev1.EventTypeNormal, "AdoptedMonitor", "Adopted monitor %d, updating %s", monitorID, strings.Join(diff, ", "))
+	}
+
+	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeCreated, corev1.ConditionTrue, "DatadogMonitor Adopted")
+	logger.Info("Adopted an existing monitor", "Monitor Namespace", datadogMonitor.Namespace, "Monitor Name", datadogMonitor.Name, "Monitor ID", monitorID, "Changed Fields", diff)
+
+	return nil
+}
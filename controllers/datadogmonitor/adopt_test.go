@@ -0,0 +1,153 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_getAdoptedMonitorID(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantID      int
+		wantErr     string
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "monitor ID",
+			annotations: map[string]string{datadoghqv1alpha1.DatadogMonitorIDAnnotationKey: "12345"},
+			wantID:      12345,
+		},
+		{
+			name:        "invalid monitor ID",
+			annotations: map[string]string{datadoghqv1alpha1.DatadogMonitorIDAnnotationKey: "foo"},
+			wantErr:     `invalid monitor.datadoghq.com/id annotation "foo", it must be a monitor ID`,
+		},
+		{
+			name:        "negative monitor ID",
+			annotations: map[string]string{datadoghqv1alpha1.DatadogMonitorIDAnnotationKey: "-1"},
+			wantErr:     `invalid monitor.datadoghq.com/id annotation "-1", it must be a monitor ID`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := &datadoghqv1alpha1.DatadogMonitor{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			id, err := getAdoptedMonitorID(dm)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func Test_adopt(t *testing.T) {
+	existing := datadogV1.Monitor{
+		Id:      datadogapi.PtrInt64(12345),
+		Name:    datadogapi.PtrString("test monitor"),
+		Message: datadogapi.PtrString("something is wrong"),
+		Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+		Type:    datadogV1.MONITORTYPE_METRIC_ALERT,
+		Created: datadogapi.PtrTime(time.Unix(1600000000, 0)),
+		Creator: &datadogV1.Creator{Email: datadogapi.PtrString("user@example.com")},
+	}
+	fakeAPI := testutils.NewFakeDatadogAPI()
+	defer fakeAPI.Close()
+	fakeAPI.SetMonitor(existing)
+	ddClient := datadogclient.DatadogMonitorClient{
+		Client: datadogV1.NewMonitorsApi(fakeAPI.APIClient()),
+		Auth:   fakeAPI.Auth(),
+	}
+
+	managed := newTestMonitor("bar", "managed", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_10m):avg:system.cpu.user{*} > 0.5", 54321)
+
+	tests := []struct {
+		name        string
+		monitorID   int
+		monitorType datadoghqv1alpha1.DatadogMonitorType
+		annotations map[string]string
+		wantEvent   string
+		wantPrimary bool
+		wantErr     string
+	}{
+		{
+			name:        "monitor adopted",
+			monitorID:   12345,
+			monitorType: datadoghqv1alpha1.DatadogMonitorTypeMetric,
+			wantEvent:   "Normal AdoptedMonitor Adopted monitor 12345, updating query",
+		},
+		{
+			name:        "primary monitor adopted",
+			monitorID:   12345,
+			monitorType: datadoghqv1alpha1.DatadogMonitorTypeMetric,
+			annotations: map[string]string{datadoghqv1alpha1.DatadogMonitorPrimaryAnnotationKey: "true"},
+			wantEvent:   "Normal AdoptedMonitor Adopted monitor 12345, updating query",
+			wantPrimary: true,
+		},
+		{
+			name:        "monitor not found",
+			monitorID:   11111,
+			monitorType: datadoghqv1alpha1.DatadogMonitorTypeMetric,
+			wantErr:     "error getting monitor",
+		},
+		{
+			name:        "monitor already managed",
+			monitorID:   54321,
+			monitorType: datadoghqv1alpha1.DatadogMonitorTypeMetric,
+			wantErr:     "monitor 54321 is already managed by the DatadogMonitor bar/managed",
+		},
+		{
+			name:        "different monitor type",
+			monitorID:   12345,
+			monitorType: datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			wantErr:     "monitor 12345 has the type metric alert, it can't be adopted by a DatadogMonitor of type query alert",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := newCompositeTestReconciler(managed)
+			r.recorder = recorder
+
+			dm := genericDatadogMonitor()
+			dm.Spec.Type = tt.monitorType
+			dm.Annotations = tt.annotations
+			status := &datadoghqv1alpha1.DatadogMonitorStatus{}
+			err := r.adopt(context.TODO(), logf.Log.WithName(tt.name), ddClient, dm, tt.monitorID, &dm.Spec, status, metav1.Now())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, 0, status.ID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.monitorID, status.ID)
+			assert.Equal(t, "user@example.com", status.Creator)
+			assert.Equal(t, tt.wantPrimary, status.Primary)
+			assert.Equal(t, tt.wantEvent, <-recorder.Events)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// resolveQuery returns the query of the monitor, where the references of a composite monitor to other
+// DatadogMonitors are replaced with their monitor IDs. It fails until all the referenced monitors are created.
+func (r *Reconciler) resolveQuery(ctx context.Context, datadogMonitor *datadoghqv1alpha1.DatadogMonitor) (string, error) {
+	self := types.NamespacedName{Namespace: datadogMonitor.Namespace, Name: datadogMonitor.Name}
+	return datadoghqv1alpha1.ReplaceDatadogMonitorReferences(&datadogMonitor.Spec, datadogMonitor.Namespace, func(ref types.NamespacedName) (string, error) {
+		if ref == self {
+			return "", fmt.Errorf("DatadogMonitor %s cannot reference itself", ref)
+		}
+		referenced := &datadoghqv1alpha1.DatadogMonitor{}
+		if err := r.client.Get(ctx, ref, referenced); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("waiting for the referenced DatadogMonitor %s to exist", ref)
+			}
+			return "", err
+		}
+		if referenced.Status.ID == 0 {
+			return "", fmt.Errorf("waiting for the referenced DatadogMonitor %s to be created", ref)
+		}
+		return strconv.Itoa(referenced.Status.ID), nil
+	})
+}
+
+// withQuery returns a copy of the DatadogMonitor with the given query
+func withQuery(datadogMonitor *datadoghqv1alpha1.DatadogMonitor, query string) *datadoghqv1alpha1.DatadogMonitor {
+	dm := datadogMonitor.DeepCopy()
+	dm.Spec.Query = query
+	return dm
+}
+
+// ReferencedMonitorsField is the field index of the composite DatadogMonitors by the names of the DatadogMonitors they reference
+const ReferencedMonitorsField = "spec.query.references"
+
+// IndexReferencedMonitors returns the names of the DatadogMonitors referenced by a composite monitor, to index them
+func IndexReferencedMonitors(obj client.Object) []string {
+	dm, ok := obj.(*datadoghqv1alpha1.DatadogMonitor)
+	if !ok {
+		return nil
+	}
+	refs, err := datadoghqv1alpha1.GetDatadogMonitorReferences(&dm.Spec, dm.Namespace)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// CompositeMonitorsReferencing returns the requests of the composite monitors referencing a DatadogMonitor,
+// so that they are updated when the referenced monitor is created or recreated with a new ID.
+func (r *Reconciler) CompositeMonitorsReferencing(obj client.Object) []reconcile.Request {
+	monitors := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err := r.client.List(context.TODO(), monitors, client.InNamespace(obj.GetNamespace()), client.MatchingFields{ReferencedMonitorsField: obj.GetName()}); err != nil {
+		r.log.Error(err, "unable to list the DatadogMonitors")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range monitors.Items {
+		dm := &monitors.Items[i]
+		// The list is filtered again for the clients not supporting the field index
+		for _, name := range IndexReferencedMonitors(dm) {
+			if name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
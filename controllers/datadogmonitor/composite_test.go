@@ -0,0 +1,129 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func newTestMonitor(namespace, name string, monitorType datadoghqv1alpha1.DatadogMonitorType, query string, id int) *datadoghqv1alpha1.DatadogMonitor {
+	return &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Type:  monitorType,
+			Query: query,
+		},
+		Status: datadoghqv1alpha1.DatadogMonitorStatus{ID: id},
+	}
+}
+
+func newCompositeTestReconciler(objs ...client.Object) *Reconciler {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(datadoghqv1alpha1.AddToScheme(s))
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	return &Reconciler{
+		client:       c,
+		secretReader: c,
+		scheme:       s,
+		log:          logf.Log.WithName("composite"),
+	}
+}
+
+func Test_resolveQuery(t *testing.T) {
+	cpu := newTestMonitor("bar", "cpu", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_5m):avg:system.cpu.user{*} > 90", 1)
+	disk := newTestMonitor("bar", "disk", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_5m):avg:system.disk.in_use{*} > 0.9", 2)
+	pending := newTestMonitor("bar", "pending", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_5m):avg:system.mem.used{*} > 90", 0)
+
+	tests := []struct {
+		name      string
+		monitor   *datadoghqv1alpha1.DatadogMonitor
+		wantQuery string
+		wantErr   string
+	}{
+		{
+			name:      "not a composite monitor",
+			monitor:   cpu,
+			wantQuery: "avg(last_5m):avg:system.cpu.user{*} > 90",
+		},
+		{
+			name:      "composite monitor",
+			monitor:   newTestMonitor("bar", "foo", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && (${disk} || 3)", 0),
+			wantQuery: "1 && (2 || 3)",
+		},
+		{
+			name:    "referenced monitor not found",
+			monitor: newTestMonitor("bar", "foo", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && ${memory}", 0),
+			wantErr: "waiting for the referenced DatadogMonitor bar/memory to exist",
+		},
+		{
+			name:    "referenced monitor in another namespace",
+			monitor: newTestMonitor("monitoring", "foo", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${bar/cpu} && 2", 0),
+			wantErr: "spec.Query: invalid DatadogMonitor reference ${bar/cpu}, it must be ${name} of a DatadogMonitor in the same namespace",
+		},
+		{
+			name:    "referenced monitor not created yet",
+			monitor: newTestMonitor("bar", "foo", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && ${pending}", 0),
+			wantErr: "waiting for the referenced DatadogMonitor bar/pending to be created",
+		},
+		{
+			name:    "self reference",
+			monitor: newTestMonitor("bar", "foo", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && ${foo}", 0),
+			wantErr: "DatadogMonitor bar/foo cannot reference itself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newCompositeTestReconciler(cpu, disk, pending)
+
+			query, err := r.resolveQuery(context.TODO(), tt.monitor)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestReconciler_CompositeMonitorsReferencing(t *testing.T) {
+	r := newCompositeTestReconciler(
+		newTestMonitor("bar", "cpu", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_5m):avg:system.cpu.user{*} > 90", 1),
+		newTestMonitor("bar", "composite", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && 2", 0),
+		newTestMonitor("bar", "other", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${disk} || ${cpu}", 0),
+		newTestMonitor("bar", "unrelated", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${disk} && 2", 0),
+		newTestMonitor("monitoring", "other", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && 2", 0),
+	)
+
+	got := r.CompositeMonitorsReferencing(newTestMonitor("bar", "cpu", datadoghqv1alpha1.DatadogMonitorTypeMetric, "", 0))
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "composite"}},
+		{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "other"}},
+	}, got)
+
+	assert.Empty(t, r.CompositeMonitorsReferencing(newTestMonitor("monitoring", "disk2", datadoghqv1alpha1.DatadogMonitorTypeMetric, "", 0)))
+}
+
+func TestIndexReferencedMonitors(t *testing.T) {
+	assert.Equal(t, []string{"cpu", "disk"}, IndexReferencedMonitors(newTestMonitor("bar", "composite", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${cpu} && (${disk} || 3)", 0)))
+	assert.Empty(t, IndexReferencedMonitors(newTestMonitor("bar", "cpu", datadoghqv1alpha1.DatadogMonitorTypeMetric, "avg(last_5m):avg:system.cpu.user{*} > 90", 0)))
+	assert.Empty(t, IndexReferencedMonitors(newTestMonitor("bar", "composite", datadoghqv1alpha1.DatadogMonitorTypeComposite, "${foo/cpu} && 2", 0)))
+}
@@ -39,6 +39,8 @@ const (
 	defaultRequeuePeriod    = 60 * time.Second
 	defaultErrRequeuePeriod = 5 * time.Second
 	defaultForceSyncPeriod  = 60 * time.Minute
+	// monitorStatesMaxAge is how long the monitors listed to update their state are reused
+	monitorStatesMaxAge     = defaultRequeuePeriod / 2
 	maxTriggeredStateGroups = 10
 )
 
@@ -54,6 +56,7 @@ var supportedMonitorTypes = map[string]bool{
 	string(datadogV1.MONITORTYPE_SLO_ALERT):             true,
 	string(datadogV1.MONITORTYPE_EVENT_V2_ALERT):        true,
 	string(datadogV1.MONITORTYPE_AUDIT_ALERT):           true,
+	string(datadogV1.MONITORTYPE_COMPOSITE):             true,
 }
 
 const requiredTag = "generated:kubernetes"
@@ -63,6 +66,9 @@ type Reconciler struct {
 	client        client.Client
 	datadogClient *datadogV1.MonitorsApi
 	datadogAuth   context.Context
+	clientCache   *datadogclient.ClientCache
+	secretReader  client.Reader
+	monitorStates *datadogclient.MonitorStateReader
 	versionInfo   *version.Info
 	log           logr.Logger
 	scheme        *runtime.Scheme
@@ -70,11 +76,14 @@ type Reconciler struct {
 }
 
 // NewReconciler returns a new Reconciler object
-func NewReconciler(client client.Client, ddClient datadogclient.DatadogMonitorClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+func NewReconciler(client client.Client, secretReader client.Reader, ddClient datadogclient.DatadogMonitorClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
 	return &Reconciler{
 		client:        client,
 		datadogClient: ddClient.Client,
 		datadogAuth:   ddClient.Auth,
+		clientCache:   datadogclient.NewClientCache(log),
+		secretReader:  secretReader,
+		monitorStates: datadogclient.NewMonitorStateReader(log, requiredTag, monitorStatesMaxAge),
 		versionInfo:   versionInfo,
 		scheme:        scheme,
 		log:           log,
@@ -122,7 +131,37 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 	}
 
-	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	// The monitor can't be moved to the Datadog organization of other credentials
+	if instance.Status.ID != 0 {
+		if err = datadogclient.CheckCredentialsSecretRefUnchanged(instance.Spec.ControllerOptions.CredentialsSecretRef, instance.Status.CredentialsSecretRef); err != nil {
+			logger.Error(err, "invalid DatadogMonitor credentials")
+
+			return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+		}
+	}
+
+	// Get the Datadog client using the credentials of the DatadogMonitor
+	ddClient, err := r.getDatadogClient(ctx, instance.Namespace, instance.Spec.ControllerOptions.CredentialsSecretRef)
+	if err != nil {
+		logger.Error(err, "error getting the Datadog credentials")
+		result.RequeueAfter = defaultErrRequeuePeriod
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Resolve the references of a composite monitor to other DatadogMonitors, the resolved query is part of the hash
+	// so that the monitor is updated when a referenced monitor is recreated with a new ID.
+	query, err := r.resolveQuery(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error resolving the monitor query")
+		result.RequeueAfter = defaultErrRequeuePeriod
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+	resolvedSpec := instance.Spec.DeepCopy()
+	resolvedSpec.Query = query
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(resolvedSpec)
 	if err != nil {
 		logger.Error(err, "error generating hash")
 
@@ -134,10 +173,27 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 	shouldCreate := false
 	shouldUpdate := false
 
-	// Check if we need to create the monitor, update the monitor definition, or update monitor state
+	// Check if we need to create or adopt the monitor, update the monitor definition, or update monitor state
 	if instance.Status.ID == 0 {
-		shouldCreate = true
+		adoptedID, adoptErr := getAdoptedMonitorID(instance)
+		if adoptErr == nil && adoptedID != 0 {
+			adoptErr = r.adopt(ctx, logger, ddClient, instance, adoptedID, resolvedSpec, newStatus, now)
+		}
+		if adoptErr != nil {
+			logger.Error(adoptErr, "error adopting monitor")
+			result.RequeueAfter = defaultErrRequeuePeriod
+
+			return r.updateStatusIfNeeded(logger, instance, now, newStatus, adoptErr, result)
+		}
+		// An adopted monitor is updated to match the spec
+		shouldCreate = adoptedID == 0
+		shouldUpdate = adoptedID != 0
 	} else {
+		// The deletion of an adopted monitor can be opted in or out after its adoption
+		if adoptedID, _ := getAdoptedMonitorID(instance); adoptedID != 0 && adoptedID == instance.Status.ID {
+			newStatus.Primary = isAdoptedMonitorPrimary(instance)
+		}
+
 		var m datadogV1.Monitor
 		if instanceSpecHash != statusSpecHash {
 			// Custom resource manifest has changed, need to update the API
@@ -146,19 +202,30 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		} else if instance.Status.MonitorLastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.MonitorLastForceSyncTime.Time)) <= 0 {
 			// Periodically force a sync with the API monitor to ensure parity
 			// Get monitor to make sure it exists before trying any updates. If it doesn't, set shouldCreate
-			m, err = r.get(instance, newStatus)
+			m, err = r.get(ddClient, instance, newStatus)
 			if err != nil {
 				logger.Error(err, "error getting monitor", "Monitor ID", instance.Status.ID)
 				if strings.Contains(err.Error(), ctrutils.NotFoundString) {
 					shouldCreate = true
 				}
 			} else {
-				shouldUpdate = true
+				// The drift policy decides whether the edits made outside Kubernetes are overwritten
+				switch getDriftPolicy(instance) {
+				case datadoghqv1alpha1.DatadogDriftPolicyReport:
+					r.reportDrift(logger, instance, resolvedSpec, m, newStatus, now)
+					newStatus.MonitorLastForceSyncTime = &now
+					updateMonitorState(m, now, newStatus)
+				case datadoghqv1alpha1.DatadogDriftPolicyIgnore:
+					newStatus.MonitorLastForceSyncTime = &now
+					updateMonitorState(m, now, newStatus)
+				default:
+					shouldUpdate = true
+				}
 			}
 		} else if instance.Status.MonitorStateLastUpdateTime == nil || (defaultRequeuePeriod-now.Sub(instance.Status.MonitorStateLastUpdateTime.Time)) <= 0 {
 			// If other conditions aren't met, and we have passed the defaultRequeuePeriod, then update monitor state
 			// Get monitor to make sure it exists before trying any updates. If it doesn't, set shouldCreate
-			m, err = r.get(instance, newStatus)
+			m, err = r.getState(ddClient, instance, newStatus)
 			if err != nil {
 				logger.Error(err, "error getting monitor", "Monitor ID", instance.Status.ID)
 				if strings.Contains(err.Error(), ctrutils.NotFoundString) {
@@ -171,7 +238,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 
 	// Create and update actions
 	if shouldCreate {
-		if isSupportedMonitorType(instance.Spec.Type) {
+		if IsSupportedMonitorType(instance.Spec.Type) {
 			logger.V(1).Info("Creating monitor in Datadog")
 			// Make sure required tags are present
 			if !apiutils.BoolValue(instance.Spec.ControllerOptions.DisableRequiredTags) {
@@ -179,7 +246,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 					return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 				}
 			}
-			if err = r.create(logger, instance, newStatus, now, instanceSpecHash); err != nil {
+			if err = r.create(logger, ddClient, withQuery(instance, query), newStatus, now, instanceSpecHash); err != nil {
 				logger.Error(err, "error creating monitor")
 			}
 		} else {
@@ -194,8 +261,10 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 				return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 			}
 		}
-		if err = r.update(logger, instance, newStatus, now, instanceSpecHash); err != nil {
-			logger.Error(err, "error updating monitor", "Monitor ID", instance.Status.ID)
+		dm := withQuery(instance, query)
+		dm.Status.ID = newStatus.ID
+		if err = r.update(logger, ddClient, dm, newStatus, now, instanceSpecHash); err != nil {
+			logger.Error(err, "error updating monitor", "Monitor ID", newStatus.ID)
 		}
 	}
 
@@ -208,14 +277,14 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 	return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 }
 
-func (r *Reconciler) create(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time, instanceSpecHash string) error {
+func (r *Reconciler) create(logger logr.Logger, ddClient datadogclient.DatadogMonitorClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time, instanceSpecHash string) error {
 	// Validate monitor in Datadog
-	if err := validateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if err := validateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		return err
 	}
 
 	// Create monitor in Datadog
-	m, err := createMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor)
+	m, err := createMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor)
 	if err != nil {
 		return err
 	}
@@ -231,6 +300,7 @@ func (r *Reconciler) create(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 	status.Primary = true
 	status.MonitorStateSyncStatus = ""
 	status.CurrentHash = instanceSpecHash
+	status.CredentialsSecretRef = datadogMonitor.Spec.ControllerOptions.CredentialsSecretRef.DeepCopy()
 
 	// Set Created Condition
 	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeCreated, corev1.ConditionTrue, "DatadogMonitor Created")
@@ -239,15 +309,15 @@ func (r *Reconciler) create(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 	return nil
 }
 
-func (r *Reconciler) update(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time, instanceSpecHash string) error {
+func (r *Reconciler) update(logger logr.Logger, ddClient datadogclient.DatadogMonitorClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time, instanceSpecHash string) error {
 	// Validate monitor in Datadog
-	if err := validateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if err := validateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		status.MonitorStateSyncStatus = datadoghqv1alpha1.MonitorStateSyncStatusValidateError
 		return err
 	}
 
 	// Update monitor in Datadog
-	if _, err := updateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if _, err := updateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		status.MonitorStateSyncStatus = datadoghqv1alpha1.MonitorStateSyncStatusUpdateError
 		return err
 	}
@@ -257,6 +327,8 @@ func (r *Reconciler) update(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 
 	// Set Updated Condition
 	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeUpdated, corev1.ConditionTrue, "DatadogMonitor Updated")
+	// The monitor matches the spec again
+	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, corev1.ConditionFalse, "")
 	status.MonitorStateSyncStatus = datadoghqv1alpha1.MonitorStateSyncStatusOK
 	status.MonitorLastForceSyncTime = &now
 	status.CurrentHash = instanceSpecHash
@@ -265,9 +337,9 @@ func (r *Reconciler) update(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 	return nil
 }
 
-func (r *Reconciler) get(datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus) (datadogV1.Monitor, error) {
+func (r *Reconciler) get(ddClient datadogclient.DatadogMonitorClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus) (datadogV1.Monitor, error) {
 	// Get monitor from Datadog and update resource status if needed
-	m, err := getMonitor(r.datadogAuth, r.datadogClient, datadogMonitor.Status.ID)
+	m, err := getMonitor(ddClient.Auth, ddClient.Client, datadogMonitor.Status.ID)
 	if err != nil {
 		status.MonitorStateSyncStatus = datadoghqv1alpha1.MonitorStateSyncStatusGetError
 		return m, err
@@ -275,6 +347,31 @@ func (r *Reconciler) get(datadogMonitor *datadoghqv1alpha1.DatadogMonitor, statu
 	return m, nil
 }
 
+// getState returns the monitor from the batched listing of the monitors managed by the operator, or gets it
+// when it isn't listed
+func (r *Reconciler) getState(ddClient datadogclient.DatadogMonitorClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus) (datadogV1.Monitor, error) {
+	if r.monitorStates != nil {
+		if m, found := r.monitorStates.GetMonitor(ddClient, datadogMonitor.Status.ID); found {
+			return m, nil
+		}
+	}
+	return r.get(ddClient, datadogMonitor, status)
+}
+
+// getDatadogClient returns the Datadog client using the credentials Secret referenced in the namespace,
+// or the credentials of the operator
+func (r *Reconciler) getDatadogClient(ctx context.Context, namespace string, ref *datadoghqv1alpha1.DatadogCredentialsSecretReference) (datadogclient.DatadogMonitorClient, error) {
+	if ref == nil {
+		return datadogclient.DatadogMonitorClient{Client: r.datadogClient, Auth: r.datadogAuth}, nil
+	}
+	// The Secret is read from the API server, to not cache all the Secrets of the cluster
+	creds, err := datadogclient.GetCredentialsFromSecret(ctx, r.secretReader, namespace, ref)
+	if err != nil {
+		return datadogclient.DatadogMonitorClient{}, err
+	}
+	return r.clientCache.GetMonitorClient(creds)
+}
+
 func updateMonitorState(m datadogV1.Monitor, now metav1.Time, status *datadoghqv1alpha1.DatadogMonitorStatus) {
 	convertStateToStatus(m, status, now)
 	status.MonitorStateLastUpdateTime = &now
@@ -348,7 +445,8 @@ func getRequiredTags() []string {
 	return []string{requiredTag}
 }
 
-// convertStateToStatus updates status.MonitorState, status.TriggeredState, and status.DowntimeStatus according to the current state of the monitor
+// convertStateToStatus updates status.MonitorState and status.TriggeredState according to the current state of the monitor.
+// status.DowntimeStatus is updated by the DatadogDowntime controller.
 func convertStateToStatus(monitor datadogV1.Monitor, newStatus *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) {
 	// If monitor group is in Alert, Warn or No Data, then add its info to the TriggeredState
 	triggeredStates := []datadoghqv1alpha1.DatadogMonitorTriggeredState{}
@@ -382,11 +480,10 @@ func convertStateToStatus(monitor datadogV1.Monitor, newStatus *datadoghqv1alpha
 	if newStatus.MonitorState != oldMonitorState {
 		newStatus.MonitorStateLastTransitionTime = &now
 	}
-	// TODO Updating this requires having the API client also return any matching downtime objects
-	newStatus.DowntimeStatus = datadoghqv1alpha1.DatadogMonitorDowntimeStatus{}
 }
 
-func isSupportedMonitorType(monitorType datadoghqv1alpha1.DatadogMonitorType) bool {
+// IsSupportedMonitorType returns true if the DatadogMonitor controller supports the monitor type
+func IsSupportedMonitorType(monitorType datadoghqv1alpha1.DatadogMonitorType) bool {
 	return supportedMonitorTypes[string(monitorType)]
 }
 
@@ -8,8 +8,6 @@ package datadogmonitor
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +28,8 @@ import (
 	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
 )
 
 const (
@@ -102,9 +102,64 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 					return err
 				}
 				assert.True(t, dm.Status.Primary)
+				assert.NotZero(t, dm.Status.ID)
 				return nil
 			},
 		},
+		{
+			name: "Adopted DatadogMonitor, primary opted in after the adoption",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Annotations = map[string]string{
+						datadoghqv1alpha1.DatadogMonitorIDAnnotationKey:      "12345",
+						datadoghqv1alpha1.DatadogMonitorPrimaryAnnotationKey: "true",
+					}
+					dm.Status.ID = 12345
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.True(t, dm.Status.Primary)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor created, credentials Secret changed",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Spec.ControllerOptions.CredentialsSecretRef = &datadoghqv1alpha1.DatadogCredentialsSecretReference{Name: "datadog-creds"}
+					dm.Status.ID = 12345
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				// The monitor is left unchanged in the organization of the previous credentials
+				assert.Nil(t, dm.Status.CredentialsSecretRef)
+				for _, cond := range dm.Status.Conditions {
+					if cond.Type == datadoghqv1alpha1.DatadogMonitorConditionTypeError {
+						assert.Contains(t, cond.Message, "the credentials Secret can't be changed")
+						return nil
+					}
+				}
+				return fmt.Errorf("missing the error condition")
+			},
+		},
 		{
 			name: "DatadogMonitor exists, check required tags",
 			args: args{
@@ -399,26 +454,15 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 			},
 		},
 		{
-			name: "DatadogMonitor of unsupported type (composite)",
+			name: "DatadogMonitor, composite alert",
 			args: args{
 				request: newRequest(resourcesNamespace, resourcesName),
 				firstAction: func(c client.Client) {
-					_ = c.Create(context.TODO(), &datadoghqv1alpha1.DatadogMonitor{
-						TypeMeta: metav1.TypeMeta{
-							Kind:       "DatadogMonitor",
-							APIVersion: fmt.Sprintf("%s/%s", datadoghqv1alpha1.GroupVersion.Group, datadoghqv1alpha1.GroupVersion.Version),
-						},
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: resourcesNamespace,
-							Name:      resourcesName,
-						},
-						Spec: datadoghqv1alpha1.DatadogMonitorSpec{
-							Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1",
-							Type:    datadoghqv1alpha1.DatadogMonitorTypeComposite,
-							Name:    "test monitor",
-							Message: "something is wrong",
-						},
-					})
+					referenced := genericDatadogMonitor()
+					referenced.Name = "cpu"
+					referenced.Status.ID = 12345
+					_ = c.Create(context.TODO(), referenced)
+					_ = c.Create(context.TODO(), testCompositeMonitor())
 				},
 				firstReconcileCount: 2,
 			},
@@ -429,7 +473,32 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
 					return err
 				}
+				assert.NotContains(t, dm.Status.Conditions[0].Message, "error")
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor, composite alert waiting for a referenced monitor",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					referenced := genericDatadogMonitor()
+					referenced.Name = "cpu"
+					_ = c.Create(context.TODO(), referenced)
+					_ = c.Create(context.TODO(), testCompositeMonitor())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			wantErr:    false,
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dm.Status.ID)
 				assert.Equal(t, dm.Status.Conditions[0].Type, datadoghqv1alpha1.DatadogMonitorConditionTypeError)
+				assert.Contains(t, dm.Status.Conditions[0].Message, "waiting for the referenced DatadogMonitor bar/cpu to be created")
 				return nil
 			},
 		},
@@ -437,23 +506,20 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-			}))
-			defer httpServer.Close()
-
-			testConfig := datadogapi.NewConfiguration()
-			testConfig.HTTPClient = httpServer.Client()
-			apiClient := datadogapi.NewAPIClient(testConfig)
-			client := datadogV1.NewMonitorsApi(apiClient)
-
-			testAuth := setupTestAuth(httpServer.URL)
+			api := testutils.NewFakeDatadogAPI()
+			defer api.Close()
+			// The monitor adopted or referenced by the test cases
+			api.SetMonitor(datadogV1.Monitor{
+				Id:    datadogapi.PtrInt64(12345),
+				Query: "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+				Type:  datadogV1.MONITORTYPE_METRIC_ALERT,
+			})
 
 			// Set up
 			r := &Reconciler{
 				client:        fake.NewFakeClient(),
-				datadogClient: client,
-				datadogAuth:   testAuth,
+				datadogClient: datadogV1.NewMonitorsApi(api.APIClient()),
+				datadogAuth:   api.Auth(),
 				scheme:        s,
 				recorder:      recorder,
 				log:           logf.Log.WithName(tt.name),
@@ -510,6 +576,62 @@ func newRequest(ns, name string) reconcile.Request {
 	}
 }
 
+func Test_getDatadogClient(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: resourcesNamespace, Name: "datadog-creds"},
+		Data: map[string][]byte{
+			"api_key": []byte("apiKey"),
+			"app_key": []byte("appKey"),
+		},
+	}
+	defaultClient := datadogV1.NewMonitorsApi(datadogapi.NewAPIClient(datadogapi.NewConfiguration()))
+
+	tests := []struct {
+		name        string
+		ref         *datadoghqv1alpha1.DatadogCredentialsSecretReference
+		wantDefault bool
+		wantErr     string
+	}{
+		{
+			name:        "operator credentials",
+			wantDefault: true,
+		},
+		{
+			name: "credentials Secret",
+			ref:  &datadoghqv1alpha1.DatadogCredentialsSecretReference{Name: "datadog-creds"},
+		},
+		{
+			name:    "missing credentials Secret",
+			ref:     &datadoghqv1alpha1.DatadogCredentialsSecretReference{Name: "missing"},
+			wantErr: "unable to get the credentials Secret bar/missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newCompositeTestReconciler(secret)
+			r.datadogClient = defaultClient
+			r.datadogAuth = context.TODO()
+			r.clientCache = datadogclient.NewClientCache(r.log)
+
+			ddClient, err := r.getDatadogClient(context.TODO(), resourcesNamespace, tt.ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantDefault {
+				assert.Same(t, defaultClient, ddClient.Client)
+				return
+			}
+			assert.NotSame(t, defaultClient, ddClient.Client)
+			keys := ddClient.Auth.Value(datadogapi.ContextAPIKeys).(map[string]datadogapi.APIKey)
+			assert.Equal(t, "apiKey", keys["apiKeyAuth"].Key)
+			assert.Equal(t, "appKey", keys["appKeyAuth"].Key)
+		})
+	}
+}
+
 func Test_convertStateToStatus(t *testing.T) {
 	triggerTs := int64(1612244495)
 	secondTriggerTs := triggerTs + 300
@@ -942,3 +1064,22 @@ func testAuditMonitor() *datadoghqv1alpha1.DatadogMonitor {
 		},
 	}
 }
+
+func testCompositeMonitor() *datadoghqv1alpha1.DatadogMonitor {
+	return &datadoghqv1alpha1.DatadogMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DatadogMonitor",
+			APIVersion: fmt.Sprintf("%s/%s", datadoghqv1alpha1.GroupVersion.Group, datadoghqv1alpha1.GroupVersion.Version),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      resourcesName,
+		},
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Query:   "${cpu} && 67890",
+			Type:    datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			Name:    "test composite monitor",
+			Message: "something is wrong",
+		},
+	}
+}
@@ -0,0 +1,54 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+)
+
+// getDriftPolicy returns the drift policy of a DatadogMonitor, defaulting to overwrite
+func getDriftPolicy(datadogMonitor *datadoghqv1alpha1.DatadogMonitor) datadoghqv1alpha1.DatadogDriftPolicy {
+	if datadogMonitor.Spec.ControllerOptions.DriftPolicy == "" {
+		return datadoghqv1alpha1.DatadogDriftPolicyOverwrite
+	}
+	return datadogMonitor.Spec.ControllerOptions.DriftPolicy
+}
+
+// reportDrift compares the monitor with the spec and reports the fields edited outside Kubernetes in the Drifted condition.
+// An event is recorded when the drifted fields change, so that an edit is reported once.
+func (r *Reconciler) reportDrift(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, desired *datadoghqv1alpha1.DatadogMonitorSpec, m datadogV1.Monitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) {
+	diff := diffDatadogMonitorSpec(*desired, BuildDatadogMonitorSpec(m))
+	if len(diff) == 0 {
+		condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, corev1.ConditionFalse, "")
+		return
+	}
+
+	fields := strings.Join(diff, ", ")
+	message := fmt.Sprintf("Fields edited outside Kubernetes: %s", fields)
+	if !isDriftReported(status, message) {
+		logger.Info("Monitor drifted from the DatadogMonitor", "Monitor ID", status.ID, "Changed Fields", diff)
+		r.recorder.Eventf(datadogMonitor, corev1.EventTypeWarning, "DriftDetected", "Monitor %d was edited outside Kubernetes: %s", status.ID, fields)
+	}
+	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, corev1.ConditionTrue, message)
+}
+
+func isDriftReported(status *datadoghqv1alpha1.DatadogMonitorStatus, message string) bool {
+	for _, c := range status.Conditions {
+		if c.Type == datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted {
+			return c.Status == corev1.ConditionTrue && c.Message == message
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_getDriftPolicy(t *testing.T) {
+	dm := genericDatadogMonitor()
+	assert.Equal(t, datadoghqv1alpha1.DatadogDriftPolicyOverwrite, getDriftPolicy(dm))
+
+	dm.Spec.ControllerOptions.DriftPolicy = datadoghqv1alpha1.DatadogDriftPolicyReport
+	assert.Equal(t, datadoghqv1alpha1.DatadogDriftPolicyReport, getDriftPolicy(dm))
+}
+
+func Test_reportDrift(t *testing.T) {
+	dm := genericDatadogMonitor()
+	dm.Status.ID = 12345
+	unchanged := datadogV1.Monitor{
+		Name:    datadogapi.PtrString(dm.Spec.Name),
+		Message: datadogapi.PtrString(dm.Spec.Message),
+		Query:   dm.Spec.Query,
+		Type:    datadogV1.MONITORTYPE_METRIC_ALERT,
+	}
+	edited := unchanged
+	edited.Query = "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.9"
+	edited.Message = datadogapi.PtrString("edited during an incident")
+
+	tests := []struct {
+		name          string
+		conditions    []datadoghqv1alpha1.DatadogMonitorCondition
+		monitor       datadogV1.Monitor
+		wantCondition *datadoghqv1alpha1.DatadogMonitorCondition
+		wantEvent     string
+	}{
+		{
+			name:    "no drift",
+			monitor: unchanged,
+		},
+		{
+			name:    "drift detected",
+			monitor: edited,
+			wantCondition: &datadoghqv1alpha1.DatadogMonitorCondition{
+				Status:  corev1.ConditionTrue,
+				Message: "Fields edited outside Kubernetes: message, query",
+			},
+			wantEvent: "Warning DriftDetected Monitor 12345 was edited outside Kubernetes: message, query",
+		},
+		{
+			name: "drift already reported",
+			conditions: []datadoghqv1alpha1.DatadogMonitorCondition{
+				{Type: datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, Status: corev1.ConditionTrue, Message: "Fields edited outside Kubernetes: message, query"},
+			},
+			monitor: edited,
+			wantCondition: &datadoghqv1alpha1.DatadogMonitorCondition{
+				Status:  corev1.ConditionTrue,
+				Message: "Fields edited outside Kubernetes: message, query",
+			},
+		},
+		{
+			name: "drift reverted",
+			conditions: []datadoghqv1alpha1.DatadogMonitorCondition{
+				{Type: datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, Status: corev1.ConditionTrue, Message: "Fields edited outside Kubernetes: query"},
+			},
+			monitor: unchanged,
+			wantCondition: &datadoghqv1alpha1.DatadogMonitorCondition{
+				Status: corev1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := &Reconciler{recorder: recorder}
+			status := dm.Status.DeepCopy()
+			status.Conditions = tt.conditions
+
+			r.reportDrift(logf.Log.WithName(tt.name), dm, &dm.Spec, tt.monitor, status, metav1.Now())
+
+			var drifted *datadoghqv1alpha1.DatadogMonitorCondition
+			for i := range status.Conditions {
+				if status.Conditions[i].Type == datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted {
+					drifted = &status.Conditions[i]
+				}
+			}
+			if tt.wantCondition == nil {
+				assert.Nil(t, drifted)
+			} else if assert.NotNil(t, drifted) {
+				assert.Equal(t, tt.wantCondition.Status, drifted.Status)
+				assert.Equal(t, tt.wantCondition.Message, drifted.Message)
+			}
+
+			if tt.wantEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				assert.Equal(t, tt.wantEvent, <-recorder.Events)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// BuildDatadogMonitorSpec returns the DatadogMonitorSpec describing a Datadog monitor, it is the reverse of buildMonitor.
+func BuildDatadogMonitorSpec(m datadogV1.Monitor) datadoghqv1alpha1.DatadogMonitorSpec {
+	spec := datadoghqv1alpha1.DatadogMonitorSpec{
+		Name:            m.GetName(),
+		Message:         m.GetMessage(),
+		Priority:        m.GetPriority(),
+		Query:           m.GetQuery(),
+		RestrictedRoles: m.GetRestrictedRoles(),
+		Type:            datadoghqv1alpha1.DatadogMonitorType(m.GetType()),
+	}
+	if tags := m.GetTags(); len(tags) > 0 {
+		spec.Tags = append([]string{}, tags...)
+		sort.Strings(spec.Tags)
+	}
+
+	o, ok := m.GetOptionsOk()
+	if !ok || o == nil {
+		return spec
+	}
+	options := &spec.Options
+	if v, ok := o.GetEnableLogsSampleOk(); ok {
+		options.EnableLogsSample = v
+	}
+	if v, ok := o.GetEscalationMessageOk(); ok && v != nil && *v != "" {
+		options.EscalationMessage = v
+	}
+	if v, ok := o.GetEvaluationDelayOk(); ok {
+		options.EvaluationDelay = v
+	}
+	if v, ok := o.GetIncludeTagsOk(); ok {
+		options.IncludeTags = v
+	}
+	if v, ok := o.GetLockedOk(); ok {
+		options.Locked = v
+	}
+	if v, ok := o.GetNewGroupDelayOk(); ok {
+		options.NewGroupDelay = v
+	}
+	if v, ok := o.GetNoDataTimeframeOk(); ok {
+		options.NoDataTimeframe = v
+	}
+	if v, ok := o.GetNotificationPresetNameOk(); ok && v != nil {
+		options.NotificationPresetName = datadoghqv1alpha1.DatadogMonitorOptionsNotificationPreset(*v)
+	}
+	if v, ok := o.GetNotifyAuditOk(); ok {
+		options.NotifyAudit = v
+	}
+	if v, ok := o.GetNotifyNoDataOk(); ok {
+		options.NotifyNoData = v
+	}
+	if v, ok := o.GetRenotifyIntervalOk(); ok {
+		options.RenotifyInterval = v
+	}
+	if v, ok := o.GetRequireFullWindowOk(); ok {
+		options.RequireFullWindow = v
+	}
+	if v, ok := o.GetTimeoutHOk(); ok {
+		options.TimeoutH = v
+	}
+
+	if t, ok := o.GetThresholdsOk(); ok && t != nil {
+		thresholds := &datadoghqv1alpha1.DatadogMonitorOptionsThresholds{}
+		thresholds.Critical = formatThreshold(t.GetCriticalOk())
+		thresholds.CriticalRecovery = formatThreshold(t.GetCriticalRecoveryOk())
+		thresholds.OK = formatThreshold(t.GetOkOk())
+		thresholds.Unknown = formatThreshold(t.GetUnknownOk())
+		thresholds.Warning = formatThreshold(t.GetWarningOk())
+		thresholds.WarningRecovery = formatThreshold(t.GetWarningRecoveryOk())
+		if !reflect.DeepEqual(*thresholds, datadoghqv1alpha1.DatadogMonitorOptionsThresholds{}) {
+			options.Thresholds = thresholds
+		}
+	}
+
+	if w, ok := o.GetThresholdWindowsOk(); ok && w != nil {
+		windows := &datadoghqv1alpha1.DatadogMonitorOptionsThresholdWindows{}
+		if v, ok := w.GetRecoveryWindowOk(); ok && v != nil {
+			windows.RecoveryWindow = v
+		}
+		if v, ok := w.GetTriggerWindowOk(); ok && v != nil {
+			windows.TriggerWindow = v
+		}
+		if windows.RecoveryWindow != nil || windows.TriggerWindow != nil {
+			options.ThresholdWindows = windows
+		}
+	}
+
+	return spec
+}
+
+func formatThreshold(value *float64, ok bool) *string {
+	if !ok || value == nil {
+		return nil
+	}
+	s := strconv.FormatFloat(*value, 'f', -1, 64)
+	return &s
+}
+
+// diffDatadogMonitorSpec returns the fields of the desired spec that differ from the current spec.
+// The options are compared one by one, the options only set in the current spec are ignored as Datadog
+// returns the default values of the unset options.
+func diffDatadogMonitorSpec(desired, current datadoghqv1alpha1.DatadogMonitorSpec) []string {
+	desired.ControllerOptions = datadoghqv1alpha1.DatadogMonitorControllerOptions{}
+	current.ControllerOptions = datadoghqv1alpha1.DatadogMonitorControllerOptions{}
+	desired.Tags = append([]string{}, desired.Tags...)
+	sort.Strings(desired.Tags)
+	desiredFields, currentFields := toFields(desired), toFields(current)
+	delete(desiredFields, "options")
+	delete(currentFields, "options")
+
+	var diff []string
+	diff = append(diff, diffFields("", desiredFields, currentFields, true)...)
+	diff = append(diff, diffFields("options.", toFields(desired.Options), toFields(current.Options), false)...)
+	sort.Strings(diff)
+	return diff
+}
+
+func diffFields(prefix string, desired, current map[string]interface{}, compareUnset bool) []string {
+	var diff []string
+	for key, value := range desired {
+		if !reflect.DeepEqual(value, current[key]) {
+			diff = append(diff, prefix+key)
+		}
+	}
+	if compareUnset {
+		for key := range current {
+			if _, found := desired[key]; !found {
+				diff = append(diff, prefix+key)
+			}
+		}
+	}
+	return diff
+}
+
+func toFields(obj interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
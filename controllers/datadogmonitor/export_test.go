@@ -0,0 +1,139 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func Test_BuildDatadogMonitorSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec datadoghqv1alpha1.DatadogMonitorSpec
+	}{
+		{
+			name: "minimal monitor",
+			spec: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "test monitor",
+				Message: "something is wrong",
+				Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeMetric,
+			},
+		},
+		{
+			name: "monitor with options",
+			spec: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:            "test monitor",
+				Message:         "something is wrong",
+				Priority:        2,
+				Query:           "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1",
+				RestrictedRoles: []string{"an-admin-uuid"},
+				Tags:            []string{"env:prod", "team:foo"},
+				Type:            datadoghqv1alpha1.DatadogMonitorTypeMetric,
+				Options: datadoghqv1alpha1.DatadogMonitorOptions{
+					EscalationMessage:      apiutils.NewStringPointer("still wrong"),
+					EvaluationDelay:        apiutils.NewInt64Pointer(300),
+					IncludeTags:            apiutils.NewBoolPointer(true),
+					NotificationPresetName: datadoghqv1alpha1.DatadogMonitorOptionsNotificationPresetHideQuery,
+					NotifyNoData:           apiutils.NewBoolPointer(true),
+					NoDataTimeframe:        apiutils.NewInt64Pointer(20),
+					Thresholds: &datadoghqv1alpha1.DatadogMonitorOptionsThresholds{
+						Critical: apiutils.NewStringPointer("0.1"),
+						Warning:  apiutils.NewStringPointer("0.05"),
+					},
+					ThresholdWindows: &datadoghqv1alpha1.DatadogMonitorOptionsThresholdWindows{
+						TriggerWindow:  apiutils.NewStringPointer("last_5m"),
+						RecoveryWindow: apiutils.NewStringPointer("last_15m"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := &datadoghqv1alpha1.DatadogMonitor{Spec: *tt.spec.DeepCopy()}
+			m, _ := buildMonitor(logf.Log.WithName(tt.name), dm)
+			// The restricted roles are only sent on updates
+			if len(tt.spec.RestrictedRoles) > 0 {
+				m.SetRestrictedRoles(tt.spec.RestrictedRoles)
+			}
+
+			// The monitor goes through the API serialization, as it would be returned by Datadog
+			data, err := json.Marshal(m)
+			require.NoError(t, err)
+			var returned datadogV1.Monitor
+			require.NoError(t, json.Unmarshal(data, &returned))
+
+			assert.Equal(t, tt.spec, BuildDatadogMonitorSpec(returned))
+		})
+	}
+}
+
+func Test_diffDatadogMonitorSpec(t *testing.T) {
+	current := datadoghqv1alpha1.DatadogMonitorSpec{
+		Name:  "test monitor",
+		Query: "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1",
+		Tags:  []string{"env:prod", "team:foo"},
+		Type:  datadoghqv1alpha1.DatadogMonitorTypeMetric,
+		Options: datadoghqv1alpha1.DatadogMonitorOptions{
+			IncludeTags:  apiutils.NewBoolPointer(true),
+			NotifyNoData: apiutils.NewBoolPointer(false),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		desired  func(spec *datadoghqv1alpha1.DatadogMonitorSpec)
+		wantDiff []string
+	}{
+		{
+			name:    "same spec",
+			desired: func(spec *datadoghqv1alpha1.DatadogMonitorSpec) {},
+		},
+		{
+			name: "unsorted tags and controller options",
+			desired: func(spec *datadoghqv1alpha1.DatadogMonitorSpec) {
+				spec.Tags = []string{"team:foo", "env:prod"}
+				spec.ControllerOptions.DisableRequiredTags = apiutils.NewBoolPointer(true)
+			},
+		},
+		{
+			name: "options set by Datadog",
+			desired: func(spec *datadoghqv1alpha1.DatadogMonitorSpec) {
+				spec.Options = datadoghqv1alpha1.DatadogMonitorOptions{}
+			},
+		},
+		{
+			name: "changed fields",
+			desired: func(spec *datadoghqv1alpha1.DatadogMonitorSpec) {
+				spec.Query = "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.2"
+				spec.Tags = nil
+				spec.Message = "something is wrong"
+				spec.Options.NotifyNoData = apiutils.NewBoolPointer(true)
+				spec.Options.RenotifyInterval = apiutils.NewInt64Pointer(60)
+			},
+			wantDiff: []string{"message", "options.notifyNoData", "options.renotifyInterval", "query", "tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := *current.DeepCopy()
+			tt.desired(&desired)
+			assert.Equal(t, tt.wantDiff, diffDatadogMonitorSpec(desired, *current.DeepCopy()))
+		})
+	}
+}
@@ -8,6 +8,7 @@ package datadogmonitor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/go-logr/logr"
@@ -25,7 +26,10 @@ func (r *Reconciler) handleFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.D
 	// Check if the DatadogMonitor instance is marked to be deleted, which is indicated by the deletion timestamp being set.
 	if dm.GetDeletionTimestamp() != nil {
 		if utils.ContainsString(dm.GetFinalizers(), datadogMonitorFinalizer) {
-			r.finalizeDatadogMonitor(logger, dm)
+			if err := r.finalizeDatadogMonitor(logger, dm); err != nil {
+				// The finalizer is kept until the monitor is deleted in Datadog
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
 
 			dm.SetFinalizers(utils.RemoveString(dm.GetFinalizers(), datadogMonitorFinalizer))
 			err := r.client.Update(context.TODO(), dm)
@@ -51,18 +55,27 @@ func (r *Reconciler) handleFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.D
 	return ctrl.Result{}, nil
 }
 
-func (r *Reconciler) finalizeDatadogMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) {
+func (r *Reconciler) finalizeDatadogMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) error {
 	if dm.Status.Primary {
-		err := deleteMonitor(r.datadogAuth, r.datadogClient, dm.Status.ID)
+		// The monitor is deleted with the credentials used to create it
+		ddClient, err := r.getDatadogClient(context.TODO(), dm.Namespace, dm.Status.CredentialsSecretRef)
 		if err != nil {
 			logger.Error(err, "failed to finalize monitor", "Monitor ID", fmt.Sprint(dm.Status.ID))
 
-			return
+			return err
+		}
+		err = deleteMonitor(ddClient.Auth, ddClient.Client, dm.Status.ID)
+		if err != nil && !strings.Contains(err.Error(), utils.NotFoundString) {
+			logger.Error(err, "failed to finalize monitor", "Monitor ID", fmt.Sprint(dm.Status.ID))
+
+			return err
 		}
 		logger.Info("Successfully finalized DatadogMonitor", "Monitor ID", fmt.Sprint(dm.Status.ID))
 		event := buildEventInfo(dm.Name, dm.Namespace, datadog.DeletionEvent)
 		r.recordEvent(dm, event)
 	}
+
+	return nil
 }
 
 func (r *Reconciler) addFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) error {
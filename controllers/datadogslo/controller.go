@@ -40,6 +40,7 @@ const (
 	defaultRequeuePeriod    = 60 * time.Second
 	defaultErrRequeuePeriod = 5 * time.Second
 	defaultForceSyncPeriod  = 60 * time.Minute
+	defaultHistoryPeriod    = 5 * time.Minute
 	datadogSLOKind          = "DatadogSLO"
 	datadogSLOFinalizer     = "finalizer.slo.datadoghq.com"
 )
@@ -48,16 +49,20 @@ type Reconciler struct {
 	client        client.Client
 	datadogClient *datadogV1.ServiceLevelObjectivesApi
 	datadogAuth   context.Context
+	clientCache   *datadogclient.ClientCache
+	secretReader  client.Reader
 	versionInfo   *version.Info
 	log           logr.Logger
 	recorder      record.EventRecorder
 }
 
-func NewReconciler(client client.Client, ddClient datadogclient.DatadogSLOClient, versionInfo *version.Info, log logr.Logger, recorder record.EventRecorder) *Reconciler {
+func NewReconciler(client client.Client, secretReader client.Reader, ddClient datadogclient.DatadogSLOClient, versionInfo *version.Info, log logr.Logger, recorder record.EventRecorder) *Reconciler {
 	return &Reconciler{
 		client:        client,
 		datadogClient: ddClient.Client,
 		datadogAuth:   ddClient.Auth,
+		clientCache:   datadogclient.NewClientCache(log),
+		secretReader:  secretReader,
 		versionInfo:   versionInfo,
 		log:           log,
 		recorder:      recorder,
@@ -108,7 +113,37 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		return r.updateStatusIfNeeded(logger, instance, status, result)
 	}
 
-	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	// The SLO can't be moved to the Datadog organization of other credentials
+	if instance.Status.ID != "" {
+		if err = datadogclient.CheckCredentialsSecretRefUnchanged(credentialsSecretRef(instance), instance.Status.CredentialsSecretRef); err != nil {
+			logger.Error(err, "invalid SLO credentials")
+			updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusCredentialsError, "ValidatingCredentials", err)
+			return r.updateStatusIfNeeded(logger, instance, status, result)
+		}
+	}
+
+	// Get the Datadog client using the credentials of the DatadogSLO
+	ddClient, err := r.getDatadogClient(ctx, instance.Namespace, credentialsSecretRef(instance))
+	if err != nil {
+		logger.Error(err, "error getting the Datadog credentials")
+		updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusCredentialsError, "GettingCredentials", err)
+		result.RequeueAfter = defaultErrRequeuePeriod
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+
+	// Resolve the referenced DatadogMonitors, the resolved monitor IDs are part of the hash
+	// so that the SLO is updated when a referenced monitor is recreated with a new ID.
+	monitorIDs, err := r.resolveMonitorIDs(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error resolving monitor references")
+		updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusMonitorRefsError, "ResolvingMonitorRefs", err)
+		result.RequeueAfter = defaultErrRequeuePeriod
+		return r.updateStatusIfNeeded(logger, instance, status, result)
+	}
+	resolvedSpec := instance.Spec.DeepCopy()
+	resolvedSpec.MonitorIDs = monitorIDs
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(resolvedSpec)
 	if err != nil {
 		logger.Error(err, "error generating hash")
 		updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusUpdateError, "GeneratingSLOSpecHash", err)
@@ -126,14 +161,23 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		} else if instance.Status.LastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0 {
 			// Periodically force a sync with the API SLO to ensure parity
 			// Get SLO to make sure it exists before trying any updates. If it doesn't, set shouldCreate
-			_, err = r.get(instance)
+			var slo *datadogV1.SLOResponseData
+			slo, err = r.get(ddClient, instance)
 			if err != nil {
 				logger.Error(err, "error getting SLO", "SLO ID", instance.Status.ID)
 				if strings.Contains(err.Error(), ctrutils.NotFoundString) {
 					shouldCreate = true
 				}
 			} else {
-				shouldUpdate = true
+				// The drift policy decides whether the edits made outside Kubernetes are overwritten
+				switch getDriftPolicy(instance) {
+				case v1alpha1.DatadogDriftPolicyReport:
+					r.reportDrift(logger, withMonitorIDs(instance, monitorIDs), slo, status, now)
+				case v1alpha1.DatadogDriftPolicyIgnore:
+					// The SLO is only recreated when it is deleted
+				default:
+					shouldUpdate = true
+				}
 			}
 			status.LastForceSyncTime = &now
 		}
@@ -144,7 +188,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		if result, err = r.checkRequiredTags(logger, instance); err != nil || result.Requeue {
 			return r.updateStatusIfNeeded(logger, instance, status, result)
 		}
-		err = r.create(logger, instance, status, now, instanceSpecHash)
+		err = r.create(logger, ddClient, withMonitorIDs(instance, monitorIDs), status, now, instanceSpecHash)
 		if err != nil {
 			result.RequeueAfter = defaultErrRequeuePeriod
 		}
@@ -153,12 +197,17 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		if result, err = r.checkRequiredTags(logger, instance); err != nil || result.Requeue {
 			return r.updateStatusIfNeeded(logger, instance, status, result)
 		}
-		err = r.update(logger, instance, status, now, instanceSpecHash)
+		err = r.update(logger, ddClient, withMonitorIDs(instance, monitorIDs), status, now, instanceSpecHash)
 		if err != nil {
 			result.RequeueAfter = defaultErrRequeuePeriod
 		}
 	}
 
+	// Periodically fetch the SLO history to report the error budget
+	if status.ID != "" && (status.HistoryLastUpdateTime == nil || (defaultHistoryPeriod-now.Sub(status.HistoryLastUpdateTime.Time)) <= 0) {
+		r.updateSLOHistory(logger, ddClient, instance, status, now)
+	}
+
 	// If reconcile was successful, requeue with period defaultRequeuePeriod
 	if !result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = defaultRequeuePeriod
@@ -193,28 +242,6 @@ func (r *Reconciler) checkRequiredTags(logger logr.Logger, instance *v1alpha1.Da
 	return ctrl.Result{}, nil
 }
 
-// TODO implement when /search endpoint has been updated
-// func updateSLOState(logger logr.Logger, instance *v1alpha1.DatadogSLO, sloHistory *datadogV1.SLOHistoryResponseData, status *v1alpha1.DatadogSLOStatus, now metav1.Time, err error) {
-// 	if err != nil {
-// 		status.SLIValue = nil
-// 		status.ErrorBudgetRemaining = nil
-// 		return
-// 	}
-// 	rawSLIVal := sloHistory.Overall.SliValue.Get()
-// 	if rawSLIVal == nil && len(sloHistory.Overall.Errors) > 0 {
-// 		logger.Info("Problem with Datadog SLO", "error message", sloHistory.Overall.Errors[0].ErrorMessage, "SLO ID", instance.Status.ID)
-// 		status.Message = fmt.Sprintf("SLO Error: %s", sloHistory.Overall.Errors[0].ErrorMessage)
-// 		return
-// 	}
-// 	sliVal := fmt.Sprintf("%.2f", *rawSLIVal)
-// 	status.SLIValue = &sliVal
-// 	// There should be only one element in map
-// 	for _, v := range sloHistory.Overall.ErrorBudgetRemaining {
-// 		ebr := fmt.Sprintf("%.2f", v)
-// 		status.ErrorBudgetRemaining = &ebr
-// 	}
-// }
-
 func updateErrStatus(status *v1alpha1.DatadogSLOStatus, now metav1.Time, syncStatus v1alpha1.DatadogSLOSyncStatus, reason string, err error) {
 	condition.UpdateFailureStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeError, reason, err)
 	status.SyncStatus = syncStatus
@@ -235,11 +262,11 @@ func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1
 	return result, nil
 }
 
-func (r *Reconciler) create(logger logr.Logger, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, now metav1.Time, hash string) error {
+func (r *Reconciler) create(logger logr.Logger, ddClient datadogclient.DatadogSLOClient, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, now metav1.Time, hash string) error {
 	logger.V(1).Info("SLO ID is not set; creating SLO in Datadog")
 
 	// Create SLO in Datadog
-	createdSLO, err := createSLO(r.datadogAuth, r.datadogClient, instance)
+	createdSLO, err := createSLO(ddClient.Auth, ddClient.Client, instance)
 	if err != nil {
 		logger.Error(err, "error creating SLO")
 		updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusCreateError, "CreatingSLO", err)
@@ -256,6 +283,7 @@ func (r *Reconciler) create(logger logr.Logger, instance *v1alpha1.DatadogSLO, s
 	status.Creator = creator.GetEmail()
 	status.Created = &createdTime
 	status.CurrentHash = hash
+	status.CredentialsSecretRef = credentialsSecretRef(instance).DeepCopy()
 
 	logger.Info("Created a new DatadogSLO", "SLO ID", instance.Status.ID)
 	r.recordEvent(instance, buildEventInfo(instance.Name, instance.Namespace, datadog.CreationEvent))
@@ -263,12 +291,12 @@ func (r *Reconciler) create(logger logr.Logger, instance *v1alpha1.DatadogSLO, s
 	return nil
 }
 
-func (r *Reconciler) get(instance *v1alpha1.DatadogSLO) (*datadogV1.SLOResponseData, error) {
-	return getSLO(r.datadogAuth, r.datadogClient, instance.Status.ID)
+func (r *Reconciler) get(ddClient datadogclient.DatadogSLOClient, instance *v1alpha1.DatadogSLO) (*datadogV1.SLOResponseData, error) {
+	return getSLO(ddClient.Auth, ddClient.Client, instance.Status.ID)
 }
 
-func (r *Reconciler) update(logger logr.Logger, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, now metav1.Time, hash string) error {
-	if _, err := updateSLO(r.datadogAuth, r.datadogClient, instance); err != nil {
+func (r *Reconciler) update(logger logr.Logger, ddClient datadogclient.DatadogSLOClient, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, now metav1.Time, hash string) error {
+	if _, err := updateSLO(ddClient.Auth, ddClient.Client, instance); err != nil {
 		logger.Error(err, "error updating SLO", "SLO ID", instance.Status.ID)
 		updateErrStatus(status, now, v1alpha1.DatadogSLOSyncStatusUpdateError, "UpdatingSLO", err)
 		return err
@@ -277,6 +305,8 @@ func (r *Reconciler) update(logger logr.Logger, instance *v1alpha1.DatadogSLO, s
 
 	// Set condition and status
 	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeUpdated, metav1.ConditionTrue, "UpdatingSLO", "DatadogSLO Updated")
+	// The SLO matches the spec again
+	clearDrift(status, now)
 	status.SyncStatus = v1alpha1.DatadogSLOSyncStatusOK
 	status.CurrentHash = hash
 
@@ -288,7 +318,13 @@ func (r *Reconciler) deleteResource(logger logr.Logger, instance *v1alpha1.Datad
 	return func(ctx context.Context, k8sObj client.Object, datadogID string) error {
 		if datadogID != "" {
 			kind := k8sObj.GetObjectKind().GroupVersionKind().Kind
-			if err := deleteSLO(r.datadogAuth, r.datadogClient, datadogID); err != nil {
+			// The SLO is deleted with the credentials used to create it
+			ddClient, err := r.getDatadogClient(ctx, instance.Namespace, instance.Status.CredentialsSecretRef)
+			if err != nil {
+				logger.Error(err, "error getting the Datadog credentials", "kind", kind, "ID", datadogID)
+				return err
+			}
+			if err := deleteSLO(ddClient.Auth, ddClient.Client, datadogID); err != nil {
 				logger.Error(err, "error deleting SLO", "kind", kind, "ID", datadogID)
 				return err
 			}
@@ -299,6 +335,28 @@ func (r *Reconciler) deleteResource(logger logr.Logger, instance *v1alpha1.Datad
 	}
 }
 
+// credentialsSecretRef returns the credentials Secret referenced by the DatadogSLO, if any
+func credentialsSecretRef(instance *v1alpha1.DatadogSLO) *v1alpha1.DatadogCredentialsSecretReference {
+	if instance.Spec.ControllerOptions == nil {
+		return nil
+	}
+	return instance.Spec.ControllerOptions.CredentialsSecretRef
+}
+
+// getDatadogClient returns the Datadog client using the credentials Secret referenced in the namespace,
+// or the credentials of the operator
+func (r *Reconciler) getDatadogClient(ctx context.Context, namespace string, ref *v1alpha1.DatadogCredentialsSecretReference) (datadogclient.DatadogSLOClient, error) {
+	if ref == nil {
+		return datadogclient.DatadogSLOClient{Client: r.datadogClient, Auth: r.datadogAuth}, nil
+	}
+	// The Secret is read from the API server, to not cache all the Secrets of the cluster
+	creds, err := datadogclient.GetCredentialsFromSecret(ctx, r.secretReader, namespace, ref)
+	if err != nil {
+		return datadogclient.DatadogSLOClient{}, err
+	}
+	return r.clientCache.GetSLOClient(creds)
+}
+
 // buildEventInfo creates a new EventInfo instance.
 func buildEventInfo(name, ns string, eventType datadog.EventType) utils.EventInfo {
 	return utils.BuildEventInfo(name, ns, datadogSLOKind, eventType)
@@ -7,13 +7,10 @@ package datadogslo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -31,6 +28,7 @@ import (
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
 )
 
 const (
@@ -43,17 +41,18 @@ func TestReconciler_Reconcile(t *testing.T) {
 	ctx := context.Background()
 	testLogger := zap.New(zap.UseDevMode(true))
 	s := scheme.Scheme
-	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.DatadogSLO{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.DatadogSLO{}, &v1alpha1.DatadogMonitor{})
 
 	type mockedFields struct {
 		k8sClient client.Client
+		api       *testutils.FakeDatadogAPI
 	}
 	tests := []struct {
-		name                 string
-		request              ctrl.Request
-		expectedResult       ctrl.Result
-		mockOn               func(t *testing.T, m *mockedFields)
-		datadogClientHandler http.HandlerFunc
+		name           string
+		request        ctrl.Request
+		expectedResult ctrl.Result
+		mockOn         func(t *testing.T, m *mockedFields)
+		check          func(t *testing.T, m *mockedFields)
 	}{
 		{
 			name: "Create SLO when not exists",
@@ -66,10 +65,13 @@ func TestReconciler_Reconcile(t *testing.T) {
 			mockOn: func(t *testing.T, m *mockedFields) {
 				_ = m.k8sClient.Create(context.TODO(), defaultSLO())
 			},
-			datadogClientHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				_ = json.NewEncoder(w).Encode(defaultDatadogSLOResponse())
-			}),
+			check: func(t *testing.T, m *mockedFields) {
+				slo := &v1alpha1.DatadogSLO{}
+				assert.NoError(t, m.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: resourceNamespace, Name: resourceName}, slo))
+				created, found := m.api.SLO(slo.Status.ID)
+				assert.True(t, found)
+				assert.Equal(t, "Test SLO", created.Name)
+			},
 			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
 		},
 		{
@@ -80,8 +82,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 					Name:      resourceName,
 				},
 			},
-			datadogClientHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			}),
+			check: func(t *testing.T, m *mockedFields) {
+				assert.Empty(t, m.api.Requests())
+			},
 			expectedResult: ctrl.Result{},
 		},
 		{
@@ -94,10 +97,8 @@ func TestReconciler_Reconcile(t *testing.T) {
 			},
 			mockOn: func(t *testing.T, m *mockedFields) {
 				_ = m.k8sClient.Create(context.TODO(), defaultSLO())
+				m.api.InjectError(http.MethodPost, "/api/v1/slo", http.StatusBadRequest, 0)
 			},
-			datadogClientHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				http.Error(w, "invalid data", http.StatusBadRequest)
-			}),
 			expectedResult: ctrl.Result{Requeue: false, RequeueAfter: defaultErrRequeuePeriod},
 		},
 		{
@@ -110,40 +111,72 @@ func TestReconciler_Reconcile(t *testing.T) {
 			},
 			mockOn: func(t *testing.T, m *mockedFields) {
 				slo := defaultSLO()
-				slo.Status.ID = "SLO123"
+				slo.Status.ID = m.api.SetSLO(defaultDatadogSLO())
 				_ = m.k8sClient.Create(context.TODO(), slo)
 			},
-			datadogClientHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				_ = json.NewEncoder(w).Encode(defaultDatadogSLOResponse())
-			}),
+			check: func(t *testing.T, m *mockedFields) {
+				slo := &v1alpha1.DatadogSLO{}
+				assert.NoError(t, m.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: resourceNamespace, Name: resourceName}, slo))
+				updated, _ := m.api.SLO(slo.Status.ID)
+				assert.Equal(t, "Test SLO", updated.Name)
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
+		},
+		{
+			name: "Create SLO referencing a DatadogMonitor",
+			request: ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: resourceNamespace,
+					Name:      resourceName,
+				},
+			},
+			mockOn: func(t *testing.T, m *mockedFields) {
+				_ = m.k8sClient.Create(context.TODO(), referencedMonitor(123))
+				_ = m.k8sClient.Create(context.TODO(), monitorSLO())
+			},
+			check: func(t *testing.T, m *mockedFields) {
+				slo := &v1alpha1.DatadogSLO{}
+				assert.NoError(t, m.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: resourceNamespace, Name: resourceName}, slo))
+				created, _ := m.api.SLO(slo.Status.ID)
+				assert.Equal(t, []int64{456, 123}, created.MonitorIds)
+			},
 			expectedResult: ctrl.Result{RequeueAfter: defaultRequeuePeriod},
 		},
+		{
+			name: "Requeue SLO when a referenced DatadogMonitor is not created yet",
+			request: ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: resourceNamespace,
+					Name:      resourceName,
+				},
+			},
+			mockOn: func(t *testing.T, m *mockedFields) {
+				_ = m.k8sClient.Create(context.TODO(), referencedMonitor(0))
+				_ = m.k8sClient.Create(context.TODO(), monitorSLO())
+			},
+			check: func(t *testing.T, m *mockedFields) {
+				assert.Empty(t, m.api.Requests(), "the SLO must not be created")
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultErrRequeuePeriod},
+		},
 	}
 
 	// Iterate through test cases
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			httpServer := httptest.NewServer(tt.datadogClientHandler)
-			defer httpServer.Close()
-
-			testConfig := datadogapi.NewConfiguration()
-			testConfig.HTTPClient = httpServer.Client()
-			apiClient := datadogapi.NewAPIClient(testConfig)
-			client := datadogV1.NewServiceLevelObjectivesApi(apiClient)
-			testAuth := setupTestAuth(httpServer.URL)
-
 			m := mockedFields{
 				k8sClient: fake.NewClientBuilder().Build(),
+				api:       testutils.NewFakeDatadogAPI(),
 			}
+			defer m.api.Close()
 			if tt.mockOn != nil {
 				tt.mockOn(t, &m)
 			}
 			recorder := record.NewFakeRecorder(5)
 			r := &Reconciler{
 				client:        m.k8sClient,
-				datadogClient: client,
-				datadogAuth:   testAuth,
+				datadogClient: datadogV1.NewServiceLevelObjectivesApi(m.api.APIClient()),
+				datadogAuth:   m.api.Auth(),
 				recorder:      recorder,
 				log:           testLogger,
 				versionInfo:   &version.Info{},
@@ -151,6 +184,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 
 			res, _ := r.Reconcile(ctx, tt.request)
 			assert.Equal(t, tt.expectedResult, res)
+			if tt.check != nil {
+				tt.check(t, &m)
+			}
 		})
 	}
 }
@@ -178,44 +214,41 @@ func defaultSLO() *v1alpha1.DatadogSLO {
 	}
 }
 
-func defaultDatadogSLOResponse() datadogV1.SLOListResponse {
-	unix := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC).Unix()
-	return datadogV1.SLOListResponse{
-		Data: []datadogV1.ServiceLevelObjective{
-			{
-				CreatedAt: &unix,
-				Creator: &datadogV1.Creator{
-					Name:  *datadogapi.NewNullableString(ptrString("test user")),
-					Email: ptrString("email@example.com"),
-				},
-				Description: datadogapi.NullableString{},
-				Groups:      []string{},
-				Id:          ptrString("SLO123"),
-				ModifiedAt:  &unix,
-				MonitorIds:  []int64{},
-				MonitorTags: []string{},
-				Name:        "Test",
-				Query: &datadogV1.ServiceLevelObjectiveQuery{
-					Denominator: "sum:my.custom.count.metric{*}.as_count()",
-					Numerator:   "sum:my.custom.count.metric{type:good_events}.as_count()",
-				},
-				Tags: []string{"tag3", "tag4"},
-				Thresholds: []datadogV1.SLOThreshold{
-					{
-						Timeframe: "7d",
-						Target:    99,
-					},
-				},
-				Type: "metric",
-			},
+func monitorSLO() *v1alpha1.DatadogSLO {
+	slo := defaultSLO()
+	slo.Spec.Type = v1alpha1.DatadogSLOTypeMonitor
+	slo.Spec.Query = nil
+	slo.Spec.MonitorIDs = []int64{456}
+	slo.Spec.MonitorRefs = []v1alpha1.DatadogSLOMonitorReference{{Name: "monitor"}}
+	return slo
+}
+
+func referencedMonitor(id int) *v1alpha1.DatadogMonitor {
+	return &v1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourceNamespace,
+			Name:      "monitor",
 		},
-		Errors:   []string{},
-		Metadata: &datadogV1.SLOListResponseMetadata{},
+		Status: v1alpha1.DatadogMonitorStatus{ID: id},
 	}
 }
 
-func ptrString(s string) *string {
-	return &s
+func defaultDatadogSLO() datadogV1.ServiceLevelObjective {
+	return datadogV1.ServiceLevelObjective{
+		Name: "Test",
+		Query: &datadogV1.ServiceLevelObjectiveQuery{
+			Denominator: "sum:my.custom.count.metric{*}.as_count()",
+			Numerator:   "sum:my.custom.count.metric{type:good_events}.as_count()",
+		},
+		Tags: []string{"tag3", "tag4"},
+		Thresholds: []datadogV1.SLOThreshold{
+			{
+				Timeframe: "7d",
+				Target:    99,
+			},
+		},
+		Type: "metric",
+	}
 }
 
 func setupTestAuth(apiURL string) context.Context {
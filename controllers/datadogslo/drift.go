@@ -0,0 +1,135 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+)
+
+// getDriftPolicy returns the drift policy of a DatadogSLO, defaulting to overwrite
+func getDriftPolicy(instance *v1alpha1.DatadogSLO) v1alpha1.DatadogDriftPolicy {
+	if instance.Spec.ControllerOptions == nil || instance.Spec.ControllerOptions.DriftPolicy == "" {
+		return v1alpha1.DatadogDriftPolicyOverwrite
+	}
+	return instance.Spec.ControllerOptions.DriftPolicy
+}
+
+// reportDrift compares the SLO with the spec and reports the fields edited outside Kubernetes in the Drifted condition.
+// An event is recorded when the drifted fields change, so that an edit is reported once.
+func (r *Reconciler) reportDrift(logger logr.Logger, instance *v1alpha1.DatadogSLO, slo *datadogV1.SLOResponseData, status *v1alpha1.DatadogSLOStatus, now metav1.Time) {
+	diff := diffSLO(instance, slo)
+	if len(diff) == 0 {
+		clearDrift(status, now)
+		return
+	}
+
+	fields := strings.Join(diff, ", ")
+	message := fmt.Sprintf("Fields edited outside Kubernetes: %s", fields)
+	if c := meta.FindStatusCondition(status.Conditions, string(condition.DatadogConditionTypeDrifted)); c == nil || c.Status != metav1.ConditionTrue || c.Message != message {
+		logger.Info("SLO drifted from the DatadogSLO", "SLO ID", status.ID, "Changed Fields", diff)
+		r.recorder.Eventf(instance, corev1.EventTypeWarning, "DriftDetected", "SLO %s was edited outside Kubernetes: %s", status.ID, fields)
+	}
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeDrifted, metav1.ConditionTrue, "DriftDetected", message)
+}
+
+// clearDrift sets the Drifted condition to false when it was reported
+func clearDrift(status *v1alpha1.DatadogSLOStatus, now metav1.Time) {
+	if meta.FindStatusCondition(status.Conditions, string(condition.DatadogConditionTypeDrifted)) == nil {
+		return
+	}
+	condition.UpdateStatusConditions(&status.Conditions, now, condition.DatadogConditionTypeDrifted, metav1.ConditionFalse, "NoDrift", "DatadogSLO matches the SLO")
+}
+
+// diffSLO returns the fields of the spec that differ from the SLO, the instance monitor IDs must be resolved
+func diffSLO(instance *v1alpha1.DatadogSLO, slo *datadogV1.SLOResponseData) []string {
+	_, desired := buildSLO(instance)
+
+	var diff []string
+	if desired.GetName() != slo.GetName() {
+		diff = append(diff, "name")
+	}
+	if desired.GetDescription() != slo.GetDescription() {
+		diff = append(diff, "description")
+	}
+	if !equalStrings(desired.GetTags(), slo.GetTags()) {
+		diff = append(diff, "tags")
+	}
+	if desired.GetType() != slo.GetType() {
+		diff = append(diff, "type")
+	}
+	desiredQuery, currentQuery := desired.GetQuery(), slo.GetQuery()
+	if desiredQuery.Numerator != currentQuery.Numerator || desiredQuery.Denominator != currentQuery.Denominator {
+		diff = append(diff, "query")
+	}
+	if !equalIDs(desired.GetMonitorIds(), slo.GetMonitorIds()) {
+		diff = append(diff, "monitorIDs")
+	}
+	if !equalStrings(desired.GetGroups(), slo.GetGroups()) {
+		diff = append(diff, "groups")
+	}
+
+	threshold := desired.GetThresholds()[0]
+	current, found := findThreshold(slo.GetThresholds(), threshold.Timeframe)
+	if !found {
+		diff = append(diff, "timeframe")
+	} else {
+		if threshold.Target != current.Target {
+			diff = append(diff, "targetThreshold")
+		}
+		if threshold.GetWarning() != current.GetWarning() {
+			diff = append(diff, "warningThreshold")
+		}
+	}
+	return diff
+}
+
+func findThreshold(thresholds []datadogV1.SLOThreshold, timeframe datadogV1.SLOTimeframe) (datadogV1.SLOThreshold, bool) {
+	for _, t := range thresholds {
+		if t.Timeframe == timeframe {
+			return t, true
+		}
+	}
+	return datadogV1.SLOThreshold{}, false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !containsMonitorID(b, id) {
+			return false
+		}
+	}
+	return true
+}
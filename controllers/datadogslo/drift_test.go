@@ -0,0 +1,122 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+)
+
+func newDriftTestSLO() *v1alpha1.DatadogSLO {
+	warning := resource.MustParse("99.5")
+	return &v1alpha1.DatadogSLO{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+		Spec: v1alpha1.DatadogSLOSpec{
+			Name:             "test slo",
+			Type:             v1alpha1.DatadogSLOTypeMonitor,
+			MonitorIDs:       []int64{1, 2},
+			Tags:             []string{"team:foo", "env:prod"},
+			Timeframe:        v1alpha1.DatadogSLOTimeFrame30d,
+			TargetThreshold:  resource.MustParse("99"),
+			WarningThreshold: &warning,
+		},
+		Status: v1alpha1.DatadogSLOStatus{ID: "abc"},
+	}
+}
+
+func newDriftTestSLOData() *datadogV1.SLOResponseData {
+	slo := &datadogV1.SLOResponseData{
+		Name:       datadogapi.PtrString("test slo"),
+		Type:       datadogV1.SLOTYPE_MONITOR.Ptr(),
+		MonitorIds: []int64{2, 1},
+		Tags:       []string{"env:prod", "team:foo"},
+		Thresholds: []datadogV1.SLOThreshold{
+			{Timeframe: datadogV1.SLOTIMEFRAME_THIRTY_DAYS, Target: 99, Warning: datadogapi.PtrFloat64(99.5)},
+		},
+	}
+	slo.SetDescriptionNil()
+	return slo
+}
+
+func Test_diffSLO(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(slo *datadogV1.SLOResponseData)
+		wantDiff []string
+	}{
+		{
+			name: "no drift",
+			edit: func(slo *datadogV1.SLOResponseData) {},
+		},
+		{
+			name: "edited fields",
+			edit: func(slo *datadogV1.SLOResponseData) {
+				slo.SetName("edited")
+				slo.SetDescription("edited during an incident")
+				slo.MonitorIds = []int64{1}
+				slo.Thresholds[0].Target = 98
+				slo.Thresholds[0].Warning = nil
+			},
+			wantDiff: []string{"name", "description", "monitorIDs", "targetThreshold", "warningThreshold"},
+		},
+		{
+			name: "edited timeframe",
+			edit: func(slo *datadogV1.SLOResponseData) {
+				slo.Thresholds[0].Timeframe = datadogV1.SLOTIMEFRAME_SEVEN_DAYS
+			},
+			wantDiff: []string{"timeframe"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo := newDriftTestSLOData()
+			tt.edit(slo)
+			assert.Equal(t, tt.wantDiff, diffSLO(newDriftTestSLO(), slo))
+		})
+	}
+}
+
+func Test_reportDrift(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	r := &Reconciler{recorder: recorder}
+	instance := newDriftTestSLO()
+	status := instance.Status.DeepCopy()
+	logger := zap.New(zap.UseDevMode(true))
+
+	// No drift, no condition
+	r.reportDrift(logger, instance, newDriftTestSLOData(), status, metav1.Now())
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, string(condition.DatadogConditionTypeDrifted)))
+
+	// The drift is reported once
+	edited := newDriftTestSLOData()
+	edited.SetName("edited")
+	r.reportDrift(logger, instance, edited, status, metav1.Now())
+	r.reportDrift(logger, instance, edited, status, metav1.Now())
+	drifted := meta.FindStatusCondition(status.Conditions, string(condition.DatadogConditionTypeDrifted))
+	if assert.NotNil(t, drifted) {
+		assert.Equal(t, metav1.ConditionTrue, drifted.Status)
+		assert.Equal(t, "Fields edited outside Kubernetes: name", drifted.Message)
+	}
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning DriftDetected SLO abc was edited outside Kubernetes: name", <-recorder.Events)
+
+	// The drift is reverted
+	r.reportDrift(logger, instance, newDriftTestSLOData(), status, metav1.Now())
+	assert.True(t, meta.IsStatusConditionFalse(status.Conditions, string(condition.DatadogConditionTypeDrifted)))
+}
@@ -0,0 +1,114 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+// sloTimeframes are the timeframes the SLO history is reported over, from the shortest to the longest
+var sloTimeframes = []struct {
+	timeframe v1alpha1.DatadogSLOTimeFrame
+	duration  time.Duration
+}{
+	{timeframe: v1alpha1.DatadogSLOTimeFrame7d, duration: 7 * 24 * time.Hour},
+	{timeframe: v1alpha1.DatadogSLOTimeFrame30d, duration: 30 * 24 * time.Hour},
+	{timeframe: v1alpha1.DatadogSLOTimeFrame90d, duration: 90 * 24 * time.Hour},
+}
+
+// updateSLOHistory fetches the SLO history over each timeframe up to the SLO timeframe, and reports the SLI value,
+// the remaining error budget and the burn rate in the status. An event is recorded when the error budget state changes.
+func (r *Reconciler) updateSLOHistory(logger logr.Logger, ddClient datadogclient.DatadogSLOClient, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, now metav1.Time) {
+	var history []v1alpha1.DatadogSLOHistory
+	var sliValue *float64
+	for _, tf := range sloTimeframes {
+		data, err := getSLOHistory(ddClient.Auth, ddClient.Client, status.ID, now.Add(-tf.duration), now.Time)
+		if err != nil {
+			logger.Error(err, "error getting SLO history", "SLO ID", status.ID, "timeframe", tf.timeframe)
+			return
+		}
+
+		value := getSLIValue(logger, data)
+		if value != nil {
+			history = append(history, buildSLOHistory(instance.Spec, tf.timeframe, *value))
+		}
+		if tf.timeframe == instance.Spec.Timeframe {
+			sliValue = value
+			break
+		}
+	}
+
+	status.History = history
+	status.HistoryLastUpdateTime = &now
+	if sliValue == nil {
+		// No data over the SLO timeframe, keep the previous error budget state
+		return
+	}
+
+	state := getErrorBudgetState(instance.Spec, *sliValue)
+	if state != status.ErrorBudgetState && !(status.ErrorBudgetState == "" && state == v1alpha1.DatadogSLOErrorBudgetStateOK) {
+		r.recordErrorBudgetEvent(instance, state, *sliValue)
+	}
+	status.ErrorBudgetState = state
+}
+
+// getSLIValue returns the overall SLI value of the SLO history, nil when there is no data
+func getSLIValue(logger logr.Logger, data *datadogV1.SLOHistoryResponseData) *float64 {
+	if data == nil || data.Overall == nil {
+		return nil
+	}
+	value, ok := data.Overall.GetSliValueOk()
+	if !ok || value == nil {
+		for _, e := range data.Overall.Errors {
+			logger.Info("Problem with Datadog SLO history", "error message", e.ErrorMessage)
+		}
+		return nil
+	}
+	return value
+}
+
+func buildSLOHistory(spec v1alpha1.DatadogSLOSpec, timeframe v1alpha1.DatadogSLOTimeFrame, sliValue float64) v1alpha1.DatadogSLOHistory {
+	target := spec.TargetThreshold.AsApproximateFloat64()
+	budget := 100 - target
+	return v1alpha1.DatadogSLOHistory{
+		Timeframe:            timeframe,
+		SLIValue:             fmt.Sprintf("%.3f", sliValue),
+		ErrorBudgetRemaining: fmt.Sprintf("%.2f", (sliValue-target)/budget*100),
+		BurnRate:             fmt.Sprintf("%.2f", (100-sliValue)/budget),
+	}
+}
+
+func getErrorBudgetState(spec v1alpha1.DatadogSLOSpec, sliValue float64) v1alpha1.DatadogSLOErrorBudgetState {
+	if sliValue < spec.TargetThreshold.AsApproximateFloat64() {
+		return v1alpha1.DatadogSLOErrorBudgetStateExhausted
+	}
+	if spec.WarningThreshold != nil && sliValue < spec.WarningThreshold.AsApproximateFloat64() {
+		return v1alpha1.DatadogSLOErrorBudgetStateWarning
+	}
+	return v1alpha1.DatadogSLOErrorBudgetStateOK
+}
+
+// recordErrorBudgetEvent records an event when the error budget state changes
+func (r *Reconciler) recordErrorBudgetEvent(instance *v1alpha1.DatadogSLO, state v1alpha1.DatadogSLOErrorBudgetState, sliValue float64) {
+	switch state {
+	case v1alpha1.DatadogSLOErrorBudgetStateExhausted:
+		r.recorder.Eventf(instance, corev1.EventTypeWarning, "ErrorBudgetExhausted", "SLI value %.3f%% is below the target threshold %s%% over %s", sliValue, instance.Spec.TargetThreshold.String(), instance.Spec.Timeframe)
+	case v1alpha1.DatadogSLOErrorBudgetStateWarning:
+		r.recorder.Eventf(instance, corev1.EventTypeWarning, "ErrorBudgetWarning", "SLI value %.3f%% is below the warning threshold %s%% over %s", sliValue, instance.Spec.WarningThreshold.String(), instance.Spec.Timeframe)
+	default:
+		r.recorder.Eventf(instance, corev1.EventTypeNormal, "ErrorBudgetRecovered", "SLI value %.3f%% is above the thresholds over %s", sliValue, instance.Spec.Timeframe)
+	}
+}
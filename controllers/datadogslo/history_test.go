@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func Test_getErrorBudgetState(t *testing.T) {
+	warning := resource.MustParse("99.5")
+	spec := v1alpha1.DatadogSLOSpec{
+		TargetThreshold:  resource.MustParse("99"),
+		WarningThreshold: &warning,
+	}
+
+	assert.Equal(t, v1alpha1.DatadogSLOErrorBudgetStateOK, getErrorBudgetState(spec, 99.9))
+	assert.Equal(t, v1alpha1.DatadogSLOErrorBudgetStateWarning, getErrorBudgetState(spec, 99.2))
+	assert.Equal(t, v1alpha1.DatadogSLOErrorBudgetStateExhausted, getErrorBudgetState(spec, 98.5))
+
+	spec.WarningThreshold = nil
+	assert.Equal(t, v1alpha1.DatadogSLOErrorBudgetStateOK, getErrorBudgetState(spec, 99.2))
+}
+
+func Test_buildSLOHistory(t *testing.T) {
+	spec := v1alpha1.DatadogSLOSpec{TargetThreshold: resource.MustParse("99")}
+
+	assert.Equal(t, v1alpha1.DatadogSLOHistory{
+		Timeframe:            v1alpha1.DatadogSLOTimeFrame7d,
+		SLIValue:             "99.750",
+		ErrorBudgetRemaining: "75.00",
+		BurnRate:             "0.25",
+	}, buildSLOHistory(spec, v1alpha1.DatadogSLOTimeFrame7d, 99.75))
+
+	assert.Equal(t, v1alpha1.DatadogSLOHistory{
+		Timeframe:            v1alpha1.DatadogSLOTimeFrame30d,
+		SLIValue:             "98.500",
+		ErrorBudgetRemaining: "-50.00",
+		BurnRate:             "1.50",
+	}, buildSLOHistory(spec, v1alpha1.DatadogSLOTimeFrame30d, 98.5))
+}
+
+func TestReconciler_updateSLOHistory(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	// the SLI values returned by the history endpoint for each timeframe
+	sliValues := map[time.Duration]float64{
+		7 * 24 * time.Hour:  99.75,
+		30 * 24 * time.Hour: 98.5,
+	}
+
+	tests := []struct {
+		name          string
+		timeframe     v1alpha1.DatadogSLOTimeFrame
+		previousState v1alpha1.DatadogSLOErrorBudgetState
+		wantHistory   []string
+		wantState     v1alpha1.DatadogSLOErrorBudgetState
+		wantEvent     string
+	}{
+		{
+			name:        "7d SLO, first history",
+			timeframe:   v1alpha1.DatadogSLOTimeFrame7d,
+			wantHistory: []string{"7d"},
+			wantState:   v1alpha1.DatadogSLOErrorBudgetStateOK,
+		},
+		{
+			name:          "30d SLO, budget exhausted",
+			timeframe:     v1alpha1.DatadogSLOTimeFrame30d,
+			previousState: v1alpha1.DatadogSLOErrorBudgetStateOK,
+			wantHistory:   []string{"7d", "30d"},
+			wantState:     v1alpha1.DatadogSLOErrorBudgetStateExhausted,
+			wantEvent:     "Warning ErrorBudgetExhausted SLI value 98.500% is below the target threshold 99% over 30d",
+		},
+		{
+			name:          "7d SLO, budget recovered",
+			timeframe:     v1alpha1.DatadogSLOTimeFrame7d,
+			previousState: v1alpha1.DatadogSLOErrorBudgetStateExhausted,
+			wantHistory:   []string{"7d"},
+			wantState:     v1alpha1.DatadogSLOErrorBudgetStateOK,
+			wantEvent:     "Normal ErrorBudgetRecovered SLI value 99.750% is above the thresholds over 7d",
+		},
+		{
+			name:          "90d SLO, no data over the SLO timeframe",
+			timeframe:     v1alpha1.DatadogSLOTimeFrame90d,
+			previousState: v1alpha1.DatadogSLOErrorBudgetStateWarning,
+			wantHistory:   []string{"7d", "30d"},
+			wantState:     v1alpha1.DatadogSLOErrorBudgetStateWarning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/slo/SLO123/history", r.URL.Path)
+				from, _ := strconv.ParseInt(r.URL.Query().Get("from_ts"), 10, 64)
+				to, _ := strconv.ParseInt(r.URL.Query().Get("to_ts"), 10, 64)
+
+				overall := datadogV1.SLOHistorySLIData{}
+				if value, found := sliValues[time.Duration(to-from)*time.Second]; found {
+					overall.SetSliValue(value)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(datadogV1.SLOHistoryResponse{
+					Data: &datadogV1.SLOHistoryResponseData{Overall: &overall},
+				})
+			}))
+			defer httpServer.Close()
+
+			testConfig := datadogapi.NewConfiguration()
+			testConfig.HTTPClient = httpServer.Client()
+			recorder := record.NewFakeRecorder(5)
+			ddClient := datadogclient.DatadogSLOClient{
+				Client: datadogV1.NewServiceLevelObjectivesApi(datadogapi.NewAPIClient(testConfig)),
+				Auth:   setupTestAuth(httpServer.URL),
+			}
+			r := &Reconciler{
+				recorder: recorder,
+				log:      zap.New(zap.UseDevMode(true)),
+			}
+
+			instance := defaultSLO()
+			instance.Spec.Timeframe = tt.timeframe
+			instance.Status.ID = "SLO123"
+			instance.Status.ErrorBudgetState = tt.previousState
+			status := instance.Status.DeepCopy()
+
+			r.updateSLOHistory(r.log, ddClient, instance, status, now)
+
+			var timeframes []string
+			for _, h := range status.History {
+				timeframes = append(timeframes, string(h.Timeframe))
+			}
+			assert.Equal(t, tt.wantHistory, timeframes)
+			assert.Equal(t, tt.wantState, status.ErrorBudgetState)
+			assert.Equal(t, &now, status.HistoryLastUpdateTime)
+
+			select {
+			case event := <-recorder.Events:
+				assert.Equal(t, tt.wantEvent, event)
+			default:
+				assert.Empty(t, tt.wantEvent, "expected an event")
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// resolveMonitorIDs returns the monitor IDs of the SLO: its MonitorIDs followed by the IDs of the DatadogMonitors
+// referenced by its MonitorRefs. It fails until all the referenced monitors are created.
+func (r *Reconciler) resolveMonitorIDs(ctx context.Context, instance *v1alpha1.DatadogSLO) ([]int64, error) {
+	if len(instance.Spec.MonitorRefs) == 0 {
+		return instance.Spec.MonitorIDs, nil
+	}
+
+	monitorIDs := append([]int64{}, instance.Spec.MonitorIDs...)
+	for _, ref := range instance.Spec.MonitorRefs {
+		key := monitorRefKey(instance, ref)
+		monitor := &v1alpha1.DatadogMonitor{}
+		if err := r.client.Get(ctx, key, monitor); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("referenced DatadogMonitor %s not found", key)
+			}
+			return nil, err
+		}
+		if monitor.Status.ID == 0 {
+			return nil, fmt.Errorf("referenced DatadogMonitor %s is not created yet", key)
+		}
+
+		id := int64(monitor.Status.ID)
+		if !containsMonitorID(monitorIDs, id) {
+			monitorIDs = append(monitorIDs, id)
+		}
+	}
+	return monitorIDs, nil
+}
+
+// withMonitorIDs returns a copy of the DatadogSLO with the given monitor IDs
+func withMonitorIDs(instance *v1alpha1.DatadogSLO, monitorIDs []int64) *v1alpha1.DatadogSLO {
+	slo := instance.DeepCopy()
+	slo.Spec.MonitorIDs = monitorIDs
+	return slo
+}
+
+// MonitorRefsField is the field index of the DatadogSLOs by the `namespace/name` of the DatadogMonitors they reference
+const MonitorRefsField = "spec.monitorRefs"
+
+// IndexMonitorRefs returns the `namespace/name` of the DatadogMonitors referenced by a DatadogSLO, to index them
+func IndexMonitorRefs(obj client.Object) []string {
+	slo, ok := obj.(*v1alpha1.DatadogSLO)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(slo.Spec.MonitorRefs))
+	for _, ref := range slo.Spec.MonitorRefs {
+		keys = append(keys, monitorRefKey(slo, ref).String())
+	}
+	return keys
+}
+
+// SLOsReferencing returns the requests of the DatadogSLOs referencing a DatadogMonitor,
+// so that they are updated when the referenced monitor is created or recreated with a new ID.
+func (r *Reconciler) SLOsReferencing(obj client.Object) []reconcile.Request {
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	slos := &v1alpha1.DatadogSLOList{}
+	if err := r.client.List(context.TODO(), slos, client.MatchingFields{MonitorRefsField: key.String()}); err != nil {
+		r.log.Error(err, "unable to list the DatadogSLOs")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range slos.Items {
+		slo := &slos.Items[i]
+		// The list is filtered again for the clients not supporting the field index
+		for _, ref := range slo.Spec.MonitorRefs {
+			if monitorRefKey(slo, ref) == key {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: slo.Namespace, Name: slo.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+func monitorRefKey(instance *v1alpha1.DatadogSLO, ref v1alpha1.DatadogSLOMonitorReference) types.NamespacedName {
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = instance.Namespace
+	}
+	return key
+}
+
+func containsMonitorID(monitorIDs []int64, id int64) bool {
+	for _, monitorID := range monitorIDs {
+		if monitorID == id {
+			return true
+		}
+	}
+	return false
+}
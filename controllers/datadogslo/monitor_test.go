@@ -0,0 +1,123 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func newMonitorRefsTestReconciler(objs ...client.Object) *Reconciler {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	return &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+		log:    zap.New(zap.UseDevMode(true)),
+	}
+}
+
+func newTestMonitor(namespace, name string, id int) *v1alpha1.DatadogMonitor {
+	return &v1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1alpha1.DatadogMonitorStatus{ID: id},
+	}
+}
+
+func newTestSLO(namespace, name string, monitorIDs []int64, refs ...v1alpha1.DatadogSLOMonitorReference) *v1alpha1.DatadogSLO {
+	return &v1alpha1.DatadogSLO{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.DatadogSLOSpec{
+			Type:        v1alpha1.DatadogSLOTypeMonitor,
+			MonitorIDs:  monitorIDs,
+			MonitorRefs: refs,
+		},
+	}
+}
+
+func TestReconciler_resolveMonitorIDs(t *testing.T) {
+	r := newMonitorRefsTestReconciler(
+		newTestMonitor("default", "cpu", 1),
+		newTestMonitor("monitoring", "disk", 2),
+		newTestMonitor("default", "pending", 0),
+	)
+
+	tests := []struct {
+		name    string
+		slo     *v1alpha1.DatadogSLO
+		want    []int64
+		wantErr string
+	}{
+		{
+			name: "no references",
+			slo:  newTestSLO("default", "slo", []int64{3, 4}),
+			want: []int64{3, 4},
+		},
+		{
+			name: "references",
+			slo:  newTestSLO("default", "slo", []int64{3, 1}, v1alpha1.DatadogSLOMonitorReference{Name: "cpu"}, v1alpha1.DatadogSLOMonitorReference{Name: "disk", Namespace: "monitoring"}),
+			want: []int64{3, 1, 2},
+		},
+		{
+			name:    "referenced monitor not found",
+			slo:     newTestSLO("default", "slo", nil, v1alpha1.DatadogSLOMonitorReference{Name: "disk"}),
+			wantErr: "referenced DatadogMonitor default/disk not found",
+		},
+		{
+			name:    "referenced monitor not created yet",
+			slo:     newTestSLO("default", "slo", nil, v1alpha1.DatadogSLOMonitorReference{Name: "pending"}),
+			wantErr: "referenced DatadogMonitor default/pending is not created yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.resolveMonitorIDs(context.TODO(), tt.slo)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.slo.Spec.MonitorIDs[0], got[0], "the spec must not be modified")
+		})
+	}
+}
+
+func TestReconciler_SLOsReferencing(t *testing.T) {
+	r := newMonitorRefsTestReconciler(
+		newTestSLO("default", "cpu", nil, v1alpha1.DatadogSLOMonitorReference{Name: "cpu"}),
+		newTestSLO("monitoring", "cpu", nil, v1alpha1.DatadogSLOMonitorReference{Name: "cpu", Namespace: "default"}),
+		newTestSLO("monitoring", "other", nil, v1alpha1.DatadogSLOMonitorReference{Name: "cpu"}),
+		newTestSLO("default", "ids", []int64{1}),
+	)
+
+	got := r.SLOsReferencing(newTestMonitor("default", "cpu", 1))
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cpu"}},
+		{NamespacedName: types.NamespacedName{Namespace: "monitoring", Name: "cpu"}},
+	}, got)
+
+	assert.Empty(t, r.SLOsReferencing(newTestMonitor("default", "disk", 2)))
+}
+
+func TestIndexMonitorRefs(t *testing.T) {
+	slo := newTestSLO("monitoring", "cpu", []int64{1}, v1alpha1.DatadogSLOMonitorReference{Name: "cpu"}, v1alpha1.DatadogSLOMonitorReference{Name: "disk", Namespace: "default"})
+	assert.Equal(t, []string{"monitoring/cpu", "default/disk"}, IndexMonitorRefs(slo))
+	assert.Empty(t, IndexMonitorRefs(newTestSLO("default", "ids", []int64{1})))
+}
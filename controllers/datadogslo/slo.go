@@ -7,14 +7,12 @@ package datadogslo
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"net/url"
+	"time"
 
-	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
 )
 
 func buildSLO(crdSLO *v1alpha1.DatadogSLO) (*datadogV1.ServiceLevelObjectiveRequest, *datadogV1.ServiceLevelObjective) {
@@ -89,7 +87,7 @@ func createSLO(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi
 	sloReq, _ := buildSLO(crdSLO)
 	slo, _, err := client.CreateSLO(auth, *sloReq)
 	if err != nil {
-		return datadogV1.ServiceLevelObjective{}, translateClientError(err, "error creating SLO")
+		return datadogV1.ServiceLevelObjective{}, utils.TranslateClientError(err, "error creating SLO")
 	}
 
 	return slo.Data[0], nil
@@ -98,17 +96,26 @@ func createSLO(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi
 func getSLO(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi, sloId string) (*datadogV1.SLOResponseData, error) {
 	slo, _, err := client.GetSLO(auth, sloId, datadogV1.GetSLOOptionalParameters{})
 	if err != nil {
-		return &datadogV1.SLOResponseData{}, translateClientError(err, "error getting SLO")
+		return &datadogV1.SLOResponseData{}, utils.TranslateClientError(err, "error getting SLO")
 	}
 
 	return slo.Data, nil
 }
 
+func getSLOHistory(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi, sloID string, from, to time.Time) (*datadogV1.SLOHistoryResponseData, error) {
+	history, _, err := client.GetSLOHistory(auth, sloID, from.Unix(), to.Unix())
+	if err != nil {
+		return &datadogV1.SLOHistoryResponseData{}, utils.TranslateClientError(err, "error getting SLO history")
+	}
+
+	return history.Data, nil
+}
+
 func updateSLO(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi, crdSLO *v1alpha1.DatadogSLO) (datadogV1.SLOListResponse, error) {
 	_, slo := buildSLO(crdSLO)
 	sloListResponse, _, err := client.UpdateSLO(auth, crdSLO.Status.ID, *slo)
 	if err != nil {
-		return datadogV1.SLOListResponse{}, translateClientError(err, "error updating SLO")
+		return datadogV1.SLOListResponse{}, utils.TranslateClientError(err, "error updating SLO")
 	}
 	return sloListResponse, nil
 }
@@ -119,25 +126,7 @@ func deleteSLO(auth context.Context, client *datadogV1.ServiceLevelObjectivesApi
 		Force: &force,
 	}
 	if _, _, err := client.DeleteSLO(auth, sloID, optionalParams); err != nil {
-		return translateClientError(err, "error deleting SLO")
+		return utils.TranslateClientError(err, "error deleting SLO")
 	}
 	return nil
 }
-
-func translateClientError(err error, msg string) error {
-	if msg == "" {
-		msg = "an error occurred"
-	}
-
-	var apiErr datadogapi.GenericOpenAPIError
-	var errURL *url.Error
-	if errors.As(err, &apiErr) {
-		return fmt.Errorf(msg+": %w: %s", err, apiErr.Body())
-	}
-
-	if errors.As(err, &errURL) {
-		return fmt.Errorf(msg+" (url.Error): %s", errURL)
-	}
-
-	return fmt.Errorf(msg+": %w", err)
-}
@@ -11,14 +11,18 @@ import (
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 
 	"github.com/DataDog/datadog-operator/controllers/datadogslo"
+	"github.com/DataDog/datadog-operator/controllers/utils"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 type DatadogSLOReconciler struct {
@@ -34,6 +38,8 @@ type DatadogSLOReconciler struct {
 // +kubebuilder:rbac:groups=datadoghq.com,resources=datadogslos,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=datadoghq.com,resources=datadogslos/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=datadoghq.com,resources=datadogslos/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogmonitors,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
 
 // Reconcile loop for Datadog SLO
 func (r *DatadogSLOReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -41,12 +47,19 @@ func (r *DatadogSLOReconciler) Reconcile(ctx context.Context, req reconcile.Requ
 }
 
 func (r *DatadogSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.internal = datadogslo.NewReconciler(r.Client, r.DDClient, r.VersionInfo, r.Log, r.Recorder)
+	r.internal = datadogslo.NewReconciler(r.Client, mgr.GetAPIReader(), r.DDClient, r.VersionInfo, r.Log, r.Recorder)
+
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DatadogSLO{}, datadogslo.MonitorRefsField, datadogslo.IndexMonitorRefs)
+	if err != nil {
+		return err
+	}
 
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.DatadogSLO{})
+		For(&v1alpha1.DatadogSLO{}).
+		// The SLOs are updated when the DatadogMonitors they reference are created or recreated.
+		Watches(&source.Kind{Type: &v1alpha1.DatadogMonitor{}}, handler.EnqueueRequestsFromMapFunc(r.internal.SLOsReferencing), ctrlbuilder.WithPredicates(utils.DatadogMonitorIDChanged))
 
-	err := builder.Complete(r)
+	err = builder.Complete(r)
 	if err != nil {
 		return err
 	}
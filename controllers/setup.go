@@ -26,9 +26,11 @@ import (
 )
 
 const (
-	agentControllerName   = "DatadogAgent"
-	monitorControllerName = "DatadogMonitor"
-	sloControllerName     = "DatadogSLO"
+	agentControllerName     = "DatadogAgent"
+	monitorControllerName   = "DatadogMonitor"
+	sloControllerName       = "DatadogSLO"
+	downtimeControllerName  = "DatadogDowntime"
+	dashboardControllerName = "DatadogDashboard"
 )
 
 // SetupOptions defines options for setting up controllers to ease testing
@@ -39,9 +41,12 @@ type SetupOptions struct {
 	DatadogAgentEnabled      bool
 	DatadogMonitorEnabled    bool
 	DatadogSLOEnabled        bool
+	DatadogDowntimeEnabled   bool
+	DatadogDashboardEnabled  bool
 	OperatorMetricsEnabled   bool
 	V2APIEnabled             bool
 	IntrospectionEnabled     bool
+	PersistDefaultsEnabled   bool
 }
 
 // ExtendedDaemonsetOptions defines ExtendedDaemonset options
@@ -62,9 +67,11 @@ type ExtendedDaemonsetOptions struct {
 type starterFunc func(logr.Logger, manager.Manager, *version.Info, kubernetes.PlatformInfo, *kubernetes.ProviderStore, SetupOptions) error
 
 var controllerStarters = map[string]starterFunc{
-	agentControllerName:   startDatadogAgent,
-	monitorControllerName: startDatadogMonitor,
-	sloControllerName:     startDatadogSLO,
+	agentControllerName:     startDatadogAgent,
+	monitorControllerName:   startDatadogMonitor,
+	sloControllerName:       startDatadogSLO,
+	downtimeControllerName:  startDatadogDowntime,
+	dashboardControllerName: startDatadogDashboard,
 }
 
 // SetupControllers starts all controllers (also used by e2e tests)
@@ -142,6 +149,8 @@ func startDatadogAgent(logger logr.Logger, mgr manager.Manager, vInfo *version.I
 			OperatorMetricsEnabled: options.OperatorMetricsEnabled,
 			V2Enabled:              options.V2APIEnabled,
 			IntrospectionEnabled:   options.IntrospectionEnabled,
+			PersistDefaultsEnabled: options.PersistDefaultsEnabled,
+			OperatorNamespace:      config.GetOperatorNamespace(),
 		},
 	}).SetupWithManager(mgr)
 }
@@ -190,3 +199,49 @@ func startDatadogSLO(logger logr.Logger, mgr manager.Manager, info *version.Info
 
 	return controller.SetupWithManager(mgr)
 }
+
+func startDatadogDowntime(logger logr.Logger, mgr manager.Manager, info *version.Info, pInfo kubernetes.PlatformInfo, providerStore *kubernetes.ProviderStore, options SetupOptions) error {
+	if !options.DatadogDowntimeEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", downtimeControllerName)
+		return nil
+	}
+
+	ddClient, err := datadogclient.InitDatadogDowntimeClient(logger, options.Creds)
+	if err != nil {
+		return fmt.Errorf("unable to create Datadog API Client: %w", err)
+	}
+
+	controller := &DatadogDowntimeReconciler{
+		Client:      mgr.GetClient(),
+		DDClient:    ddClient,
+		VersionInfo: info,
+		Log:         ctrl.Log.WithName("controllers").WithName(downtimeControllerName),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor(downtimeControllerName),
+	}
+
+	return controller.SetupWithManager(mgr)
+}
+
+func startDatadogDashboard(logger logr.Logger, mgr manager.Manager, info *version.Info, pInfo kubernetes.PlatformInfo, providerStore *kubernetes.ProviderStore, options SetupOptions) error {
+	if !options.DatadogDashboardEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", dashboardControllerName)
+		return nil
+	}
+
+	ddClient, err := datadogclient.InitDatadogDashboardClient(logger, options.Creds)
+	if err != nil {
+		return fmt.Errorf("unable to create Datadog API Client: %w", err)
+	}
+
+	controller := &DatadogDashboardReconciler{
+		Client:      mgr.GetClient(),
+		DDClient:    ddClient,
+		VersionInfo: info,
+		Log:         ctrl.Log.WithName("controllers").WithName(dashboardControllerName),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor(dashboardControllerName),
+	}
+
+	return controller.SetupWithManager(mgr)
+}
@@ -0,0 +1,32 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package utils
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// DatadogMonitorIDChanged filters the DatadogMonitor events to the updates changing the monitor ID, when the monitor
+// is created or recreated in Datadog. It is used by the resources referencing DatadogMonitors by name.
+var DatadogMonitorIDChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return false
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldMonitor, okOld := e.ObjectOld.(*v1alpha1.DatadogMonitor)
+		newMonitor, okNew := e.ObjectNew.(*v1alpha1.DatadogMonitor)
+		return okOld && okNew && oldMonitor.Status.ID != newMonitor.Status.ID
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
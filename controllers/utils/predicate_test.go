@@ -0,0 +1,28 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func TestDatadogMonitorIDChanged(t *testing.T) {
+	pending := &v1alpha1.DatadogMonitor{}
+	created := pending.DeepCopy()
+	created.Status.ID = 42
+	renamed := created.DeepCopy()
+	renamed.Spec.Name = "foo"
+
+	assert.True(t, DatadogMonitorIDChanged.Update(event.UpdateEvent{ObjectOld: pending, ObjectNew: created}))
+	assert.False(t, DatadogMonitorIDChanged.Update(event.UpdateEvent{ObjectOld: created, ObjectNew: renamed}))
+	assert.False(t, DatadogMonitorIDChanged.Create(event.CreateEvent{Object: created}))
+	assert.False(t, DatadogMonitorIDChanged.Delete(event.DeleteEvent{Object: created}))
+}
@@ -116,11 +116,14 @@ type options struct {
 	datadogAgentEnabled                    bool
 	datadogMonitorEnabled                  bool
 	datadogSLOEnabled                      bool
+	datadogDowntimeEnabled                 bool
+	datadogDashboardEnabled                bool
 	operatorMetricsEnabled                 bool
 	webhookEnabled                         bool
 	v2APIEnabled                           bool
 	maximumGoroutines                      int
 	introspectionEnabled                   bool
+	persistDefaultsEnabled                 bool
 
 	// Secret Backend options
 	secretBackendCommand string
@@ -149,11 +152,14 @@ func (opts *options) Parse() {
 	flag.BoolVar(&opts.datadogAgentEnabled, "datadogAgentEnabled", true, "Enable the DatadogAgent controller")
 	flag.BoolVar(&opts.datadogMonitorEnabled, "datadogMonitorEnabled", false, "Enable the DatadogMonitor controller")
 	flag.BoolVar(&opts.datadogSLOEnabled, "datadogSLOEnabled", false, "Enable the DatadogSLO controller")
+	flag.BoolVar(&opts.datadogDowntimeEnabled, "datadogDowntimeEnabled", false, "Enable the DatadogDowntime controller")
+	flag.BoolVar(&opts.datadogDashboardEnabled, "datadogDashboardEnabled", false, "Enable the DatadogDashboard controller")
 	flag.BoolVar(&opts.operatorMetricsEnabled, "operatorMetricsEnabled", true, "Enable sending operator metrics to Datadog")
 	flag.BoolVar(&opts.v2APIEnabled, "v2APIEnabled", true, "Enable the v2 api")
-	flag.BoolVar(&opts.webhookEnabled, "webhookEnabled", false, "Enable CRD conversion webhook.")
+	flag.BoolVar(&opts.webhookEnabled, "webhookEnabled", false, "Enable CRD conversion, defaulting and validating webhooks.")
 	flag.IntVar(&opts.maximumGoroutines, "maximumGoroutines", defaultMaximumGoroutines, "Override health check threshold for maximum number of goroutines.")
 	flag.BoolVar(&opts.introspectionEnabled, "introspectionEnabled", false, "Enable introspection (beta)")
+	flag.BoolVar(&opts.persistDefaultsEnabled, "persistDefaultsEnabled", false, "Write the default values to the DatadogAgent spec")
 
 	// ExtendedDaemonset configuration
 	flag.BoolVar(&opts.supportExtendedDaemonset, "supportExtendedDaemonset", false, "Support usage of Datadog ExtendedDaemonset CRD.")
@@ -263,14 +269,17 @@ func run(opts *options) error {
 			CanaryAutoPauseMaxSlowStartDuration: opts.edsCanaryAutoPauseMaxSlowStartDuration,
 			MaxPodSchedulerFailure:              opts.edsMaxPodSchedulerFailure,
 		},
-		SupportCilium:          opts.supportCilium,
-		Creds:                  creds,
-		DatadogAgentEnabled:    opts.datadogAgentEnabled,
-		DatadogMonitorEnabled:  opts.datadogMonitorEnabled,
-		DatadogSLOEnabled:      opts.datadogSLOEnabled,
-		OperatorMetricsEnabled: opts.operatorMetricsEnabled,
-		V2APIEnabled:           opts.v2APIEnabled,
-		IntrospectionEnabled:   opts.introspectionEnabled,
+		SupportCilium:           opts.supportCilium,
+		Creds:                   creds,
+		DatadogAgentEnabled:     opts.datadogAgentEnabled,
+		DatadogMonitorEnabled:   opts.datadogMonitorEnabled,
+		DatadogSLOEnabled:       opts.datadogSLOEnabled,
+		DatadogDowntimeEnabled:  opts.datadogDowntimeEnabled,
+		DatadogDashboardEnabled: opts.datadogDashboardEnabled,
+		OperatorMetricsEnabled:  opts.operatorMetricsEnabled,
+		V2APIEnabled:            opts.v2APIEnabled,
+		IntrospectionEnabled:    opts.introspectionEnabled,
+		PersistDefaultsEnabled:  opts.persistDefaultsEnabled,
 	}
 
 	if err = controllers.SetupControllers(setupLog, mgr, options); err != nil {
@@ -19,6 +19,9 @@ const (
 	// which specifies the Namespace to watch.
 	// An empty value means the operator is running with cluster scope.
 	WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+	// PodNamespaceEnvVar is the constant for env variable POD_NAMESPACE
+	// which specifies the Namespace the operator is running in.
+	PodNamespaceEnvVar = "POD_NAMESPACE"
 	// DDAPIKeyEnvVar is the constant for the env variable DD_API_KEY which is the fallback
 	// API key to use if a resource does not have it defined in its spec.
 	DDAPIKeyEnvVar = "DD_API_KEY"
@@ -46,6 +49,20 @@ func GetWatchNamespaces() []string {
 	return []string{ns}
 }
 
+// serviceAccountNamespaceFile is the file storing the Namespace of the service account mounted in the operator pod.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// GetOperatorNamespace returns the Namespace the operator is running in, or an empty string if it's unknown.
+func GetOperatorNamespace() string {
+	if ns, found := os.LookupEnv(PodNamespaceEnvVar); found {
+		return ns
+	}
+	if ns, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(ns))
+	}
+	return ""
+}
+
 // ManagerOptionsWithNamespaces returns an updated Options with namespaces information.
 func ManagerOptionsWithNamespaces(logger logr.Logger, opt ctrl.Options) ctrl.Options {
 	namespaces := GetWatchNamespaces()
@@ -17,10 +17,12 @@ import (
 	"k8s.io/client-go/util/retry"
 )
 
-// Creds holds the api and app keys.
+// Creds holds the api and app keys, and the Datadog site they belong to.
+// An empty site means the site of the operator configuration.
 type Creds struct {
 	APIKey string
 	AppKey string
+	Site   string
 }
 
 // CredentialManager provides the credentials from the operator configuration.
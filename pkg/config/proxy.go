@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+)
+
+// Proxy holds the proxy settings of the outbound HTTP clients of the operator.
+type Proxy struct {
+	HTTP    string
+	HTTPS   string
+	NoProxy []string
+}
+
+// GetProxy returns the proxy configured in the operator with the DD_PROXY_HTTP, DD_PROXY_HTTPS and
+// DD_PROXY_NO_PROXY env vars, which are the ones used by the Agents.
+func GetProxy() Proxy {
+	return Proxy{
+		HTTP:    os.Getenv(apicommon.DDProxyHTTP),
+		HTTPS:   os.Getenv(apicommon.DDProxyHTTPS),
+		NoProxy: strings.Fields(os.Getenv(apicommon.DDProxyNoProxy)),
+	}
+}
+
+// IsEmpty returns true if no proxy is configured.
+func (p Proxy) IsEmpty() bool {
+	return p.HTTP == "" && p.HTTPS == ""
+}
+
+// ProxyFunc returns the function selecting the proxy of a request, to use in an http.Transport.
+// Without proxy settings, it uses the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY env vars.
+func (p Proxy) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	if p.IsEmpty() {
+		return http.ProxyFromEnvironment
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  p.HTTP,
+		HTTPSProxy: p.HTTPS,
+		NoProxy:    strings.Join(p.NoProxy, ","),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// NewTransport returns a copy of http.DefaultTransport sending the requests through the proxy.
+func (p Proxy) NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = p.ProxyFunc()
+	return transport
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"net/http"
 	"reflect"
 	"sync"
 	"time"
@@ -98,6 +99,7 @@ type metricsForwarder struct {
 	v2Enabled    bool
 	platformInfo *kubernetes.PlatformInfo
 	apiKey       string
+	proxy        config.Proxy
 	clusterName  string
 	labels       map[string]string
 	dsStatus     []*commonv1.DaemonSetStatus
@@ -107,6 +109,7 @@ type metricsForwarder struct {
 	EnabledFeatures map[string][]string
 
 	keysHash            uint64
+	clientProxy         config.Proxy
 	retryInterval       time.Duration
 	sendMetricsInterval time.Duration
 	metricsPrefix       string
@@ -266,6 +269,13 @@ func (mf *metricsForwarder) setupV2() error {
 		return err
 	}
 	mf.apiKey = apiKey
+
+	// set proxy
+	proxy, err := mf.getProxyV2(dda)
+	if err != nil {
+		return err
+	}
+	mf.proxy = proxy
 	return nil
 }
 
@@ -293,6 +303,7 @@ func (mf *metricsForwarder) setup() error {
 		return err
 	}
 	mf.apiKey = apiKey
+	mf.proxy = config.GetProxy()
 	return nil
 }
 
@@ -445,12 +456,13 @@ func (mf *metricsForwarder) initAPIClient(apiKey string) error {
 	}
 	mf.datadogClient = datadogClient
 	mf.keysHash = hashKeys(apiKey)
+	mf.clientProxy = mf.proxy
 	return nil
 }
 
-// updateCredsIfNeeded used to update Datadog apiKey if it changes
+// updateCredsIfNeeded used to update Datadog apiKey or proxy if it changes
 func (mf *metricsForwarder) updateCredsIfNeeded(apiKey string) error {
-	if mf.keysHash != hashKeys(apiKey) {
+	if mf.keysHash != hashKeys(apiKey) || !reflect.DeepEqual(mf.clientProxy, mf.proxy) {
 		return mf.initAPIClient(apiKey)
 	}
 	return nil
@@ -465,6 +477,7 @@ func (mf *metricsForwarder) validateCreds(apiKey string) (*api.Client, error) {
 func (mf *metricsForwarder) delegatedValidateCreds(apiKey string) (*api.Client, error) {
 	datadogClient := api.NewClient(apiKey, emptyAppKey)
 	datadogClient.SetBaseUrl(mf.baseURL)
+	datadogClient.HttpClient = &http.Client{Transport: mf.proxy.NewTransport()}
 	valid, err := datadogClient.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("cannot validate datadog credentials: %w", err)
@@ -638,6 +651,34 @@ func (mf *metricsForwarder) getCredentialsV2(dda *v2alpha1.DatadogAgent) (string
 	return mf.resolveSecretsIfNeeded(apiKey)
 }
 
+// getProxyV2 returns the proxy configured in the DatadogAgent, or the proxy of the operator if there is none
+func (mf *metricsForwarder) getProxyV2(dda *v2alpha1.DatadogAgent) (config.Proxy, error) {
+	if dda.Spec.Global == nil || dda.Spec.Global.Proxy == nil {
+		return config.GetProxy(), nil
+	}
+
+	var err error
+	proxyConfig := dda.Spec.Global.Proxy
+	proxy := config.Proxy{NoProxy: proxyConfig.NoProxy}
+	if proxy.HTTP, err = mf.getProxyURL(dda.Namespace, proxyConfig.HTTP, proxyConfig.HTTPSecret); err != nil {
+		return config.Proxy{}, err
+	}
+	if proxy.HTTPS, err = mf.getProxyURL(dda.Namespace, proxyConfig.HTTPS, proxyConfig.HTTPSSecret); err != nil {
+		return config.Proxy{}, err
+	}
+
+	return proxy, nil
+}
+
+// getProxyURL returns a proxy URL from its secret if it is configured, or from its value
+func (mf *metricsForwarder) getProxyURL(namespace string, value *string, secret *commonv1.SecretConfig) (string, error) {
+	if secret != nil {
+		return mf.getKeyFromSecret(namespace, secret.SecretName, secret.KeyName)
+	}
+
+	return apiutils.StringValue(value), nil
+}
+
 // getCredentials returns the Datadog API Key and App Key, it returns an error if one key is missing
 func (mf *metricsForwarder) getCredentials(dda *v1alpha1.DatadogAgent) (string, error) {
 	apiKey, err := mf.getCredsFromDatadogAgent(dda)
@@ -0,0 +1,105 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+// clientCacheTTL is the duration after which the clients not used anymore are evicted, for example the clients of
+// rotated credentials. The resources using a client are reconciled much more often.
+const clientCacheTTL = time.Hour
+
+type monitorClientEntry struct {
+	client   DatadogMonitorClient
+	lastUsed time.Time
+}
+
+type sloClientEntry struct {
+	client   DatadogSLOClient
+	lastUsed time.Time
+}
+
+// ClientCache caches the Datadog API clients per credentials, so that the resources referencing the same
+// credentials share their clients. The clients not used for clientCacheTTL are evicted.
+type ClientCache struct {
+	logger     logr.Logger
+	mutex      sync.Mutex
+	now        func() time.Time
+	monitorAPI map[config.Creds]monitorClientEntry
+	sloAPI     map[config.Creds]sloClientEntry
+}
+
+// NewClientCache returns an empty ClientCache.
+func NewClientCache(logger logr.Logger) *ClientCache {
+	return &ClientCache{
+		logger:     logger,
+		now:        time.Now,
+		monitorAPI: map[config.Creds]monitorClientEntry{},
+		sloAPI:     map[config.Creds]sloClientEntry{},
+	}
+}
+
+// GetMonitorClient returns the Datadog Monitor API Client for the credentials, initializing it if needed.
+func (c *ClientCache) GetMonitorClient(creds config.Creds) (DatadogMonitorClient, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	c.evict(now)
+	if entry, found := c.monitorAPI[creds]; found {
+		entry.lastUsed = now
+		c.monitorAPI[creds] = entry
+		return entry.client, nil
+	}
+	client, err := InitDatadogMonitorClient(c.logger, creds)
+	if err != nil {
+		return client, err
+	}
+	c.monitorAPI[creds] = monitorClientEntry{client: client, lastUsed: now}
+
+	return client, nil
+}
+
+// GetSLOClient returns the Datadog SLO API Client for the credentials, initializing it if needed.
+func (c *ClientCache) GetSLOClient(creds config.Creds) (DatadogSLOClient, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	c.evict(now)
+	if entry, found := c.sloAPI[creds]; found {
+		entry.lastUsed = now
+		c.sloAPI[creds] = entry
+		return entry.client, nil
+	}
+	client, err := InitDatadogSLOClient(c.logger, creds)
+	if err != nil {
+		return client, err
+	}
+	c.sloAPI[creds] = sloClientEntry{client: client, lastUsed: now}
+
+	return client, nil
+}
+
+// evict removes the clients not used since clientCacheTTL, the mutex must be held.
+func (c *ClientCache) evict(now time.Time) {
+	for creds, entry := range c.monitorAPI {
+		if now.Sub(entry.lastUsed) > clientCacheTTL {
+			delete(c.monitorAPI, creds)
+		}
+	}
+	for creds, entry := range c.sloAPI {
+		if now.Sub(entry.lastUsed) > clientCacheTTL {
+			delete(c.sloAPI, creds)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+func Test_ClientCache(t *testing.T) {
+	cache := NewClientCache(logr.Discard())
+	credsA := config.Creds{APIKey: "apiKeyA", AppKey: "appKeyA"}
+	credsB := config.Creds{APIKey: "apiKeyB", AppKey: "appKeyB", Site: "datadoghq.eu"}
+
+	clientA, err := cache.GetMonitorClient(credsA)
+	require.NoError(t, err)
+	cachedA, err := cache.GetMonitorClient(credsA)
+	require.NoError(t, err)
+	assert.Same(t, clientA.Client, cachedA.Client)
+
+	clientB, err := cache.GetMonitorClient(credsB)
+	require.NoError(t, err)
+	assert.NotSame(t, clientA.Client, clientB.Client)
+	assert.Equal(t, map[string]string{"name": "api.datadoghq.eu", "protocol": "https"}, clientB.Auth.Value(datadogapi.ContextServerVariables))
+
+	sloClient, err := cache.GetSLOClient(credsA)
+	require.NoError(t, err)
+	cachedSLOClient, err := cache.GetSLOClient(credsA)
+	require.NoError(t, err)
+	assert.Same(t, sloClient.Client, cachedSLOClient.Client)
+
+	_, err = cache.GetSLOClient(config.Creds{APIKey: "apiKeyA"})
+	assert.Error(t, err)
+}
+
+func Test_ClientCache_evict(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cache := NewClientCache(logr.Discard())
+	cache.now = func() time.Time { return now }
+	used := config.Creds{APIKey: "apiKeyA", AppKey: "appKeyA"}
+	rotated := config.Creds{APIKey: "apiKeyB", AppKey: "appKeyB"}
+
+	usedClient, err := cache.GetMonitorClient(used)
+	require.NoError(t, err)
+	_, err = cache.GetMonitorClient(rotated)
+	require.NoError(t, err)
+	_, err = cache.GetSLOClient(rotated)
+	require.NoError(t, err)
+
+	// The client used since is kept, the other ones are evicted
+	now = now.Add(clientCacheTTL / 2)
+	_, err = cache.GetMonitorClient(used)
+	require.NoError(t, err)
+	now = now.Add(clientCacheTTL/2 + time.Second)
+	cachedClient, err := cache.GetMonitorClient(used)
+	require.NoError(t, err)
+
+	assert.Same(t, usedClient.Client, cachedClient.Client)
+	assert.Len(t, cache.monitorAPI, 1)
+	assert.Empty(t, cache.sloAPI)
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -36,9 +37,7 @@ func InitDatadogMonitorClient(logger logr.Logger, creds config.Creds) (DatadogMo
 		return DatadogMonitorClient{}, errors.New("error obtaining API key and/or app key")
 	}
 
-	configV1 := datadogapi.NewConfiguration()
-	apiClient := datadogapi.NewAPIClient(configV1)
-	client := datadogV1.NewMonitorsApi(apiClient)
+	client := datadogV1.NewMonitorsApi(newAPIClient("datadogmonitor"))
 
 	authV1, err := setupAuth(logger, creds)
 	if err != nil {
@@ -60,9 +59,7 @@ func InitDatadogSLOClient(logger logr.Logger, creds config.Creds) (DatadogSLOCli
 		return DatadogSLOClient{}, errors.New("error obtaining API key and/or app key")
 	}
 
-	configV1 := datadogapi.NewConfiguration()
-	apiClient := datadogapi.NewAPIClient(configV1)
-	client := datadogV1.NewServiceLevelObjectivesApi(apiClient)
+	client := datadogV1.NewServiceLevelObjectivesApi(newAPIClient("datadogslo"))
 
 	authV1, err := setupAuth(logger, creds)
 	if err != nil {
@@ -72,6 +69,61 @@ func InitDatadogSLOClient(logger logr.Logger, creds config.Creds) (DatadogSLOCli
 	return DatadogSLOClient{Client: client, Auth: authV1}, nil
 }
 
+// DatadogDowntimeClient contains the Datadog Downtime API Client and Authentication context.
+type DatadogDowntimeClient struct {
+	Client *datadogV1.DowntimesApi
+	Auth   context.Context
+}
+
+// InitDatadogDowntimeClient initializes the Datadog Downtime API Client and establishes credentials.
+func InitDatadogDowntimeClient(logger logr.Logger, creds config.Creds) (DatadogDowntimeClient, error) {
+	if creds.APIKey == "" || creds.AppKey == "" {
+		return DatadogDowntimeClient{}, errors.New("error obtaining API key and/or app key")
+	}
+
+	client := datadogV1.NewDowntimesApi(newAPIClient("datadogdowntime"))
+
+	authV1, err := setupAuth(logger, creds)
+	if err != nil {
+		return DatadogDowntimeClient{}, err
+	}
+
+	return DatadogDowntimeClient{Client: client, Auth: authV1}, nil
+}
+
+// DatadogDashboardClient contains the Datadog Dashboard API Client and Authentication context.
+type DatadogDashboardClient struct {
+	Client *datadogV1.DashboardsApi
+	Auth   context.Context
+}
+
+// InitDatadogDashboardClient initializes the Datadog Dashboard API Client and establishes credentials.
+func InitDatadogDashboardClient(logger logr.Logger, creds config.Creds) (DatadogDashboardClient, error) {
+	if creds.APIKey == "" || creds.AppKey == "" {
+		return DatadogDashboardClient{}, errors.New("error obtaining API key and/or app key")
+	}
+
+	client := datadogV1.NewDashboardsApi(newAPIClient("datadogdashboard"))
+
+	authV1, err := setupAuth(logger, creds)
+	if err != nil {
+		return DatadogDashboardClient{}, err
+	}
+
+	return DatadogDashboardClient{Client: client, Auth: authV1}, nil
+}
+
+// newAPIClient returns a Datadog API client pacing its requests according to the rate limits of the API,
+// and sending them through the proxy configured in the operator.
+// The controller name identifies the API usage of the client in the metrics.
+func newAPIClient(controller string) *datadogapi.APIClient {
+	configV1 := datadogapi.NewConfiguration()
+	configV1.HTTPClient = &http.Client{
+		Transport: newRateLimitTransport(controller, sharedRateLimiter, config.GetProxy().NewTransport()),
+	}
+	return datadogapi.NewAPIClient(configV1)
+}
+
 func setupAuth(logger logr.Logger, creds config.Creds) (context.Context, error) {
 	// Initialize the official Datadog V1 API client.
 	authV1 := context.WithValue(
@@ -88,7 +140,9 @@ func setupAuth(logger logr.Logger, creds config.Creds) (context.Context, error)
 	)
 
 	apiURL := ""
-	if os.Getenv(config.DDURLEnvVar) != "" {
+	if creds.Site != "" {
+		apiURL = prefix + strings.TrimSpace(creds.Site)
+	} else if os.Getenv(config.DDURLEnvVar) != "" {
 		apiURL = os.Getenv(config.DDURLEnvVar)
 	} else if site := os.Getenv(apicommon.DDSite); site != "" {
 		apiURL = prefix + strings.TrimSpace(site)
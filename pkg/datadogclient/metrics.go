@@ -0,0 +1,46 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "datadog_api_requests_total",
+			Help: "Number of requests sent to the Datadog API, per controller, endpoint and status code",
+		},
+		[]string{"controller", "endpoint", "code"},
+	)
+	apiRateLimitedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "datadog_api_rate_limited_requests_total",
+			Help: "Number of requests rejected by the rate limits of the Datadog API, per controller and endpoint",
+		},
+		[]string{"controller", "endpoint"},
+	)
+	apiThrottledSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "datadog_api_throttled_seconds_total",
+			Help: "Time the requests to the Datadog API waited for the rate limits, per controller",
+		},
+		[]string{"controller"},
+	)
+	apiRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "datadog_api_rate_limit_remaining",
+			Help: "Requests remaining in the current period of a Datadog API rate limit, as reported by the last response",
+		},
+		[]string{"name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRateLimitedRequests, apiThrottledSeconds, apiRateLimitRemaining)
+}
@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+const monitorListPageSize = 1000
+
+// MonitorStateReader reads the monitors in batches: the monitors having a tag are listed with a few paginated
+// requests, instead of getting them one by one. The listing is shared by the monitors using the same client,
+// and refreshed when it's older than maxAge. Like the clients of the ClientCache, the listings not used for
+// clientCacheTTL are evicted, so that the listings of the evicted or rebuilt clients don't leak.
+type MonitorStateReader struct {
+	logger  logr.Logger
+	tag     string
+	maxAge  time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+	batches map[*datadogV1.MonitorsApi]*monitorBatch
+}
+
+// monitorBatch is the last listing of the monitors of a client
+type monitorBatch struct {
+	mutex    sync.Mutex
+	monitors map[int64]datadogV1.Monitor
+	listedAt time.Time
+	// lastUsed is protected by the mutex of the MonitorStateReader
+	lastUsed time.Time
+}
+
+// NewMonitorStateReader returns a MonitorStateReader listing the monitors having the tag.
+func NewMonitorStateReader(logger logr.Logger, tag string, maxAge time.Duration) *MonitorStateReader {
+	return &MonitorStateReader{
+		logger:  logger,
+		tag:     tag,
+		maxAge:  maxAge,
+		now:     time.Now,
+		batches: map[*datadogV1.MonitorsApi]*monitorBatch{},
+	}
+}
+
+// GetMonitor returns the monitor from the listing of the monitors of the client. It returns false when the monitor
+// isn't listed, for instance when it doesn't have the tag or when the listing failed, the monitor must then be
+// retrieved on its own.
+func (s *MonitorStateReader) GetMonitor(ddClient DatadogMonitorClient, monitorID int) (datadogV1.Monitor, bool) {
+	batch := s.getBatch(ddClient.Client)
+
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+
+	now := s.now()
+	if now.Sub(batch.listedAt) >= s.maxAge {
+		monitors, err := s.listMonitors(ddClient)
+		if err != nil {
+			s.logger.Error(err, "error listing monitors, getting them one by one", "tag", s.tag)
+		}
+		// The monitors are retrieved one by one until the next listing when it fails
+		batch.monitors = monitors
+		batch.listedAt = now
+	}
+
+	m, found := batch.monitors[int64(monitorID)]
+	return m, found
+}
+
+func (s *MonitorStateReader) getBatch(client *datadogV1.MonitorsApi) *monitorBatch {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	for c, batch := range s.batches {
+		if now.Sub(batch.lastUsed) > clientCacheTTL {
+			delete(s.batches, c)
+		}
+	}
+	batch, found := s.batches[client]
+	if !found {
+		batch = &monitorBatch{}
+		s.batches[client] = batch
+	}
+	batch.lastUsed = now
+	return batch
+}
+
+func (s *MonitorStateReader) listMonitors(ddClient DatadogMonitorClient) (map[int64]datadogV1.Monitor, error) {
+	monitors := map[int64]datadogV1.Monitor{}
+	params := datadogV1.NewListMonitorsOptionalParameters().WithMonitorTags(s.tag).WithGroupStates("all").WithPageSize(monitorListPageSize)
+	for page := int64(0); ; page++ {
+		result, _, err := ddClient.Client.ListMonitors(ddClient.Auth, *params.WithPage(page))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range result {
+			monitors[m.GetId()] = m
+		}
+		if len(result) < monitorListPageSize {
+			return monitors, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_MonitorStateReader(t *testing.T) {
+	fakeAPI := testutils.NewFakeDatadogAPI()
+	defer fakeAPI.Close()
+	fakeAPI.SetMonitor(datadogV1.Monitor{Id: datadogapi.PtrInt64(1), Tags: []string{"generated:kubernetes"}, OverallState: datadogV1.MONITOROVERALLSTATES_ALERT.Ptr()})
+	fakeAPI.SetMonitor(datadogV1.Monitor{Id: datadogapi.PtrInt64(2), Tags: []string{"generated:kubernetes"}, OverallState: datadogV1.MONITOROVERALLSTATES_OK.Ptr()})
+	fakeAPI.SetMonitor(datadogV1.Monitor{Id: datadogapi.PtrInt64(3), OverallState: datadogV1.MONITOROVERALLSTATES_OK.Ptr()})
+	ddClient := DatadogMonitorClient{Client: datadogV1.NewMonitorsApi(fakeAPI.APIClient()), Auth: fakeAPI.Auth()}
+
+	now := time.Unix(1600000000, 0)
+	reader := NewMonitorStateReader(logr.Discard(), "generated:kubernetes", 30*time.Second)
+	reader.now = func() time.Time { return now }
+
+	m, found := reader.GetMonitor(ddClient, 1)
+	assert.True(t, found)
+	assert.Equal(t, datadogV1.MONITOROVERALLSTATES_ALERT, m.GetOverallState())
+	_, found = reader.GetMonitor(ddClient, 3)
+	assert.False(t, found)
+	requests := fakeAPI.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "generated:kubernetes", requests[0].Query.Get("monitor_tags"))
+	assert.Equal(t, "all", requests[0].Query.Get("group_states"))
+
+	// The listing is refreshed when it's too old
+	now = now.Add(30 * time.Second)
+	fakeAPI.InjectError(http.MethodGet, "/api/v1/monitor", http.StatusInternalServerError, 0)
+	_, found = reader.GetMonitor(ddClient, 1)
+	assert.False(t, found)
+	_, found = reader.GetMonitor(ddClient, 2)
+	assert.False(t, found)
+	assert.Len(t, fakeAPI.Requests(), 2)
+
+	// The listings of the clients not used anymore are evicted
+	fakeAPI.ClearErrors()
+	otherClient := DatadogMonitorClient{Client: datadogV1.NewMonitorsApi(fakeAPI.APIClient()), Auth: fakeAPI.Auth()}
+	now = now.Add(clientCacheTTL + time.Second)
+	_, found = reader.GetMonitor(otherClient, 1)
+	assert.True(t, found)
+	assert.Len(t, reader.batches, 1)
+	assert.Contains(t, reader.batches, otherClient.Client)
+}
@@ -0,0 +1,172 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	rateLimitNameHeader      = "X-RateLimit-Name"
+	apiKeyHeader             = "DD-API-KEY"
+
+	// paceThreshold is the fraction of a rate limit below which the remaining requests are spread until the reset
+	paceThreshold = 0.1
+)
+
+var apiVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// sharedRateLimiter is used by all the clients, so that the controllers using the same credentials share their budget
+var sharedRateLimiter = newRateLimiter()
+
+// rateLimit is the state of a rate limit of the Datadog API, as reported by the headers of the last response
+type rateLimit struct {
+	limit     int
+	remaining int
+	reset     time.Time
+	// next is the earliest time the next request can be sent when the requests are paced
+	next time.Time
+}
+
+// rateLimiter tracks the rate limits of the Datadog API per organization and endpoint
+type rateLimiter struct {
+	mutex  sync.Mutex
+	limits map[string]*rateLimit
+	now    func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		limits: map[string]*rateLimit{},
+		now:    time.Now,
+	}
+}
+
+// reserve counts a request in the budget of the rate limit and returns how long to wait before sending it.
+// The requests wait for the reset once the budget is exhausted, and are spread until the reset when it's almost exhausted.
+func (rl *rateLimiter) reserve(key string) time.Duration {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	l, found := rl.limits[key]
+	now := rl.now()
+	if !found || !now.Before(l.reset) {
+		// Unknown rate limit, or a new period: the next response reports the budget
+		return 0
+	}
+	if l.remaining <= 0 {
+		return l.reset.Sub(now)
+	}
+
+	at := now
+	if l.next.After(at) {
+		at = l.next
+	}
+	if float64(l.remaining) <= paceThreshold*float64(l.limit) {
+		l.next = at.Add(l.reset.Sub(at) / time.Duration(l.remaining+1))
+	}
+	l.remaining--
+
+	return at.Sub(now)
+}
+
+// update records the rate limit reported by the headers of a response. It returns the rate limit name and
+// the remaining requests, the name is empty when the response doesn't report a rate limit.
+func (rl *rateLimiter) update(key string, resp *http.Response) (string, int) {
+	limit, limitErr := strconv.Atoi(resp.Header.Get(rateLimitLimitHeader))
+	remaining, remainingErr := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader))
+	reset, resetErr := strconv.Atoi(resp.Header.Get(rateLimitResetHeader))
+	if limitErr != nil || remainingErr != nil || resetErr != nil {
+		return "", 0
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		remaining = 0
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	l, found := rl.limits[key]
+	if !found {
+		l = &rateLimit{}
+		rl.limits[key] = l
+	}
+	l.limit = limit
+	l.remaining = remaining
+	l.reset = rl.now().Add(time.Duration(reset) * time.Second)
+
+	return resp.Header.Get(rateLimitNameHeader), remaining
+}
+
+// rateLimitTransport paces the requests to the Datadog API according to its rate limits, and reports the API usage
+// of a controller in the metrics
+type rateLimitTransport struct {
+	controller string
+	limiter    *rateLimiter
+	transport  http.RoundTripper
+}
+
+func newRateLimitTransport(controller string, limiter *rateLimiter, transport http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		controller: controller,
+		limiter:    limiter,
+		transport:  transport,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointName(req)
+	// The rate limits apply per organization, the API key identifies it
+	key := req.Header.Get(apiKeyHeader) + " " + endpoint
+
+	if delay := t.limiter.reserve(key); delay > 0 {
+		apiThrottledSeconds.WithLabelValues(t.controller).Add(delay.Seconds())
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		apiRequests.WithLabelValues(t.controller, endpoint, "error").Inc()
+		return resp, err
+	}
+
+	apiRequests.WithLabelValues(t.controller, endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		apiRateLimitedRequests.WithLabelValues(t.controller, endpoint).Inc()
+	}
+	if name, remaining := t.limiter.update(key, resp); name != "" {
+		apiRateLimitRemaining.WithLabelValues(name).Set(float64(remaining))
+	}
+
+	return resp, nil
+}
+
+// endpointName returns the method and the path of a request, the IDs in the path are replaced by a placeholder
+// to keep the number of endpoints bounded
+func endpointName(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, s := range segments {
+		if !apiVersionSegment.MatchString(s) && strings.ContainsAny(s, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
@@ -0,0 +1,147 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponse(statusCode int, limit, remaining, reset string) *http.Response {
+	header := http.Header{}
+	header.Set(rateLimitLimitHeader, limit)
+	header.Set(rateLimitRemainingHeader, remaining)
+	header.Set(rateLimitResetHeader, reset)
+	header.Set(rateLimitNameHeader, "monitor_get")
+	return &http.Response{StatusCode: statusCode, Header: header}
+}
+
+func Test_rateLimiter(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "no rate limit headers",
+			resp:       &http.Response{StatusCode: http.StatusOK, Header: http.Header{}},
+			wantDelays: []time.Duration{0, 0},
+		},
+		{
+			name:       "budget left",
+			resp:       newTestResponse(http.StatusOK, "100", "50", "10"),
+			wantDelays: []time.Duration{0, 0},
+		},
+		{
+			name:       "budget almost exhausted",
+			resp:       newTestResponse(http.StatusOK, "100", "4", "10"),
+			wantDelays: []time.Duration{0, 2 * time.Second, 4 * time.Second, 6 * time.Second, 10 * time.Second},
+		},
+		{
+			name:       "rate limited",
+			resp:       newTestResponse(http.StatusTooManyRequests, "100", "3", "10"),
+			wantDelays: []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := newRateLimiter()
+			rl.now = func() time.Time { return now }
+
+			assert.Equal(t, time.Duration(0), rl.reserve("key"))
+			rl.update("key", tt.resp)
+			var delays []time.Duration
+			for range tt.wantDelays {
+				delays = append(delays, rl.reserve("key"))
+			}
+			assert.Equal(t, tt.wantDelays, delays)
+			assert.Equal(t, time.Duration(0), rl.reserve("other key"))
+
+			// The budget is renewed after the reset
+			rl.now = func() time.Time { return now.Add(10 * time.Second) }
+			assert.Equal(t, time.Duration(0), rl.reserve("key"))
+		})
+	}
+}
+
+func Test_rateLimitTransport(t *testing.T) {
+	requests := 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set(rateLimitLimitHeader, "100")
+		w.Header().Set(rateLimitRemainingHeader, "0")
+		w.Header().Set(rateLimitResetHeader, "60")
+		w.Header().Set(rateLimitNameHeader, "monitor_get")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer httpServer.Close()
+
+	transport := newRateLimitTransport("test", newRateLimiter(), http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/api/v1/monitor/12345", nil)
+	require.NoError(t, err)
+	req.Header.Set(apiKeyHeader, "apiKey")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// The next request waits for the reset, until the request is canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.Do(req.WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, requests)
+
+	// The rate limits apply per organization
+	req.Header.Set(apiKeyHeader, "otherAPIKey")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 2, requests)
+}
+
+func Test_endpointName(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{
+			method: http.MethodGet,
+			path:   "/api/v1/monitor",
+			want:   "GET /api/v1/monitor",
+		},
+		{
+			method: http.MethodPut,
+			path:   "/api/v1/monitor/12345",
+			want:   "PUT /api/v1/monitor/{id}",
+		},
+		{
+			method: http.MethodGet,
+			path:   "/api/v1/slo/e8b4d6a2c9f04b3c8e1f2a3b4c5d6e7f/history",
+			want:   "GET /api/v1/slo/{id}/history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://api.datadoghq.com"+tt.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, endpointName(req))
+		})
+	}
+}
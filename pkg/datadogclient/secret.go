@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+const defaultSiteKey = "site"
+
+// GetCredentialsFromSecret returns the credentials held by the Secret referenced by a resource in the namespace.
+// It returns an error if the Secret doesn't exist or doesn't contain the API and application keys.
+func GetCredentialsFromSecret(ctx context.Context, c client.Reader, namespace string, ref *v1alpha1.DatadogCredentialsSecretReference) (config.Creds, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return config.Creds{}, fmt.Errorf("unable to get the credentials Secret %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	apiKeyKey := defaultIfEmpty(ref.APIKeyKey, apicommon.DefaultAPIKeyKey)
+	appKeyKey := defaultIfEmpty(ref.AppKeyKey, apicommon.DefaultAPPKeyKey)
+	creds := config.Creds{
+		APIKey: string(secret.Data[apiKeyKey]),
+		AppKey: string(secret.Data[appKeyKey]),
+		Site:   string(secret.Data[defaultIfEmpty(ref.SiteKey, defaultSiteKey)]),
+	}
+	if creds.APIKey == "" || creds.AppKey == "" {
+		return config.Creds{}, fmt.Errorf("the credentials Secret %s/%s must contain the keys %s and %s", namespace, ref.Name, apiKeyKey, appKeyKey)
+	}
+
+	return creds, nil
+}
+
+// CheckCredentialsSecretRefUnchanged returns an error if a resource references another credentials Secret than the one
+// used to create it in Datadog, as the resource would be left in the Datadog organization of the previous credentials.
+func CheckCredentialsSecretRefUnchanged(ref, createdWith *v1alpha1.DatadogCredentialsSecretReference) error {
+	if apiequality.Semantic.DeepEqual(ref, createdWith) {
+		return nil
+	}
+	return fmt.Errorf("the credentials Secret can't be changed once the resource is created in Datadog, recreate the resource to use other credentials")
+}
+
+func defaultIfEmpty(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+func Test_GetCredentialsFromSecret(t *testing.T) {
+	defaultKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "datadog"},
+		Data: map[string][]byte{
+			"api_key": []byte("apiKeyA"),
+			"app_key": []byte("appKeyA"),
+		},
+	}
+	customKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "datadog"},
+		Data: map[string][]byte{
+			"apiKey": []byte("apiKeyB"),
+			"appKey": []byte("appKeyB"),
+			"ddSite": []byte("datadoghq.eu"),
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(defaultKeys, customKeys).Build()
+
+	tests := []struct {
+		name      string
+		namespace string
+		ref       v1alpha1.DatadogCredentialsSecretReference
+		want      config.Creds
+		wantErr   string
+	}{
+		{
+			name:      "default keys",
+			namespace: "team-a",
+			ref:       v1alpha1.DatadogCredentialsSecretReference{Name: "datadog"},
+			want:      config.Creds{APIKey: "apiKeyA", AppKey: "appKeyA"},
+		},
+		{
+			name:      "custom keys and site",
+			namespace: "team-b",
+			ref:       v1alpha1.DatadogCredentialsSecretReference{Name: "datadog", APIKeyKey: "apiKey", AppKeyKey: "appKey", SiteKey: "ddSite"},
+			want:      config.Creds{APIKey: "apiKeyB", AppKey: "appKeyB", Site: "datadoghq.eu"},
+		},
+		{
+			name:      "missing keys",
+			namespace: "team-b",
+			ref:       v1alpha1.DatadogCredentialsSecretReference{Name: "datadog"},
+			wantErr:   "the credentials Secret team-b/datadog must contain the keys api_key and app_key",
+		},
+		{
+			name:      "Secret in another namespace",
+			namespace: "team-c",
+			ref:       v1alpha1.DatadogCredentialsSecretReference{Name: "datadog"},
+			wantErr:   "unable to get the credentials Secret team-c/datadog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := GetCredentialsFromSecret(context.TODO(), c, tt.namespace, &tt.ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, creds)
+		})
+	}
+}
+
+func Test_CheckCredentialsSecretRefUnchanged(t *testing.T) {
+	ref := &v1alpha1.DatadogCredentialsSecretReference{Name: "datadog"}
+
+	assert.NoError(t, CheckCredentialsSecretRefUnchanged(nil, nil))
+	assert.NoError(t, CheckCredentialsSecretRefUnchanged(ref, ref.DeepCopy()))
+	assert.Error(t, CheckCredentialsSecretRefUnchanged(ref, nil))
+	assert.Error(t, CheckCredentialsSecretRefUnchanged(nil, ref))
+	assert.Error(t, CheckCredentialsSecretRefUnchanged(ref, &v1alpha1.DatadogCredentialsSecretReference{Name: "datadog", APIKeyKey: "key"}))
+}
@@ -29,6 +29,8 @@ const (
 	DefaultImageRegistry = GCRContainerRegistry
 	// JMXTagSuffix prefix tag for agent JMX images
 	JMXTagSuffix = "-jmx"
+	// FIPSTagSuffix suffix tag for agent and cluster-agent FIPS images
+	FIPSTagSuffix = "-fips"
 
 	agentImageName        = "agent"
 	clusterAgentImageName = "cluster-agent"
@@ -50,16 +52,60 @@ type Image struct {
 	imageName string
 	tag       string
 	isJMX     bool
+	isFIPS    bool
+	digest    string
 }
 
 // NewImage return a new Image instance
 func NewImage(name, tag string, isJMX bool) *Image {
-	return &Image{
+	image := &Image{
 		registry:  DefaultImageRegistry,
 		imageName: name,
-		tag:       strings.TrimSuffix(tag, JMXTagSuffix),
-		isJMX:     strings.HasSuffix(tag, JMXTagSuffix) || isJMX,
 	}
+	image.setTag(tag)
+	image.isJMX = image.isJMX || isJMX
+	return image
+}
+
+// ParseImage return the Image instance of a full image string: `[<registry>/]<name>[:<tag>][@<digest>]`
+func ParseImage(fullImage string) *Image {
+	image := &Image{}
+	if idx := strings.LastIndex(fullImage, "@"); idx >= 0 {
+		image.digest = fullImage[idx+1:]
+		fullImage = fullImage[:idx]
+	}
+
+	name := fullImage
+	if idx := strings.LastIndex(fullImage, "/"); idx >= 0 {
+		image.registry = ContainerRegistry(fullImage[:idx])
+		name = fullImage[idx+1:]
+	}
+
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		image.setTag(name[idx+1:])
+		name = name[:idx]
+	}
+	image.imageName = name
+
+	return image
+}
+
+// setTag sets the image tag, without its FIPS and JMX variant suffixes
+func (i *Image) setTag(tag string) {
+	i.isJMX = strings.HasSuffix(tag, JMXTagSuffix)
+	tag = strings.TrimSuffix(tag, JMXTagSuffix)
+	i.isFIPS = strings.HasSuffix(tag, FIPSTagSuffix)
+	i.tag = strings.TrimSuffix(tag, FIPSTagSuffix)
+}
+
+// Name return the image name, without registry and tag
+func (i *Image) Name() string {
+	return i.imageName
+}
+
+// Digest return the digest the image is pinned to, if any
+func (i *Image) Digest() string {
+	return i.digest
 }
 
 // ImageOptions use to allow extra Image configuration
@@ -127,6 +173,38 @@ func WithJMX(jmx bool) ImageOptions {
 	}
 }
 
+// WithFIPS ImageOptions to specify if the FIPS suffix should be added
+func WithFIPS(fips bool) ImageOptions {
+	return func(image *Image) {
+		image.isFIPS = fips
+	}
+}
+
+// WithRepository ImageOptions to replace the container registry and image name with a repository: `<registry>/<name>`
+func WithRepository(repository string) ImageOptions {
+	return func(image *Image) {
+		image.registry = ""
+		image.imageName = repository
+		if idx := strings.LastIndex(repository, "/"); idx >= 0 {
+			image.registry = ContainerRegistry(repository[:idx])
+			image.imageName = repository[idx+1:]
+		}
+	}
+}
+
+// WithDigest ImageOptions to pin the image to a digest, for example `sha256:<hash>`
+func WithDigest(digest string) ImageOptions {
+	return func(image *Image) {
+		image.digest = digest
+	}
+}
+
+// ProcessOptions applies the ImageOptions to the image
+func (i *Image) ProcessOptions(opts ...ImageOptions) *Image {
+	processOptions(i, opts...)
+	return i
+}
+
 func processOptions(image *Image, opts ...ImageOptions) {
 	for _, option := range opts {
 		option(image)
@@ -135,9 +213,22 @@ func processOptions(image *Image, opts ...ImageOptions) {
 
 // String return the string representation of an image
 func (i *Image) String() string {
-	suffix := ""
-	if i.isJMX {
-		suffix = JMXTagSuffix
+	image := i.imageName
+	if i.registry != "" {
+		image = fmt.Sprintf("%s/%s", i.registry, i.imageName)
+	}
+	if i.tag != "" {
+		suffix := ""
+		if i.isFIPS {
+			suffix += FIPSTagSuffix
+		}
+		if i.isJMX {
+			suffix += JMXTagSuffix
+		}
+		image = fmt.Sprintf("%s:%s%s", image, i.tag, suffix)
+	}
+	if i.digest != "" {
+		image = fmt.Sprintf("%s@%s", image, i.digest)
 	}
-	return fmt.Sprintf("%s/%s:%s%s", i.registry, i.imageName, i.tag, suffix)
+	return image
 }
@@ -198,3 +198,68 @@ func TestIsImageNameContainsTag(t *testing.T) {
 		assert.Equal(t, expected, IsImageNameContainsTag(tc))
 	}
 }
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		image      string
+		opts       []ImageOptions
+		wantName   string
+		wantDigest string
+		want       string
+	}{
+		{
+			name:     "full image",
+			image:    "gcr.io/datadoghq/agent:7.50.3",
+			wantName: "agent",
+			want:     "gcr.io/datadoghq/agent:7.50.3",
+		},
+		{
+			name:     "registry with port and variants",
+			image:    "localhost:5000/agent:7.50.3-fips-jmx",
+			wantName: "agent",
+			want:     "localhost:5000/agent:7.50.3-fips-jmx",
+		},
+		{
+			name:       "digest",
+			image:      "gcr.io/datadoghq/cluster-agent:7.50.3@sha256:0123456789abcdef",
+			wantName:   "cluster-agent",
+			wantDigest: "sha256:0123456789abcdef",
+			want:       "gcr.io/datadoghq/cluster-agent:7.50.3@sha256:0123456789abcdef",
+		},
+		{
+			name:     "no registry, no tag",
+			image:    "agent",
+			wantName: "agent",
+			want:     "agent",
+		},
+		{
+			name:  "with variants",
+			image: "gcr.io/datadoghq/agent:7.50.3-jmx",
+			opts: []ImageOptions{
+				WithFIPS(true),
+			},
+			wantName: "agent",
+			want:     "gcr.io/datadoghq/agent:7.50.3-fips-jmx",
+		},
+		{
+			name:  "with repository and digest",
+			image: "gcr.io/datadoghq/agent:7.50.3",
+			opts: []ImageOptions{
+				WithRepository("registry.example.com/mirror/datadog-agent"),
+				WithDigest("sha256:0123456789abcdef"),
+			},
+			wantName:   "datadog-agent",
+			wantDigest: "sha256:0123456789abcdef",
+			want:       "registry.example.com/mirror/datadog-agent:7.50.3@sha256:0123456789abcdef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := ParseImage(tt.image).ProcessOptions(tt.opts...)
+			assert.Equal(t, tt.wantName, image.Name())
+			assert.Equal(t, tt.wantDigest, image.Digest())
+			assert.Equal(t, tt.want, image.String())
+		})
+	}
+}
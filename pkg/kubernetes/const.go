@@ -46,6 +46,8 @@ const (
 	ServiceAccountsKind = "serviceaccounts"
 	// PodDisruptionBudgetsKind PodDisruptionBudgets resource kind
 	PodDisruptionBudgetsKind = "poddisruptionbudgets"
+	// HorizontalPodAutoscalersKind HorizontalPodAutoscalers resource kind
+	HorizontalPodAutoscalersKind = "horizontalpodautoscalers"
 	// NetworkPoliciesKind NetworkPolicies resource kind
 	NetworkPoliciesKind = "networkpolicies"
 	// PodSecurityPoliciesKind PodSecurityPolicies resource kind
@@ -68,6 +70,7 @@ func getResourcesKind(withCiliumResources, withPodSecurityPolicy bool) []ObjectK
 		ServicesKind,
 		ServiceAccountsKind,
 		PodDisruptionBudgetsKind,
+		HorizontalPodAutoscalersKind,
 		NetworkPoliciesKind,
 	}
 
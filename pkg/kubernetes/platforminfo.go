@@ -1,9 +1,14 @@
 package kubernetes
 
 import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -61,6 +66,43 @@ func NewPlatformInfoFromVersionMaps(versionInfo *version.Info, apiPreferredVersi
 	}
 }
 
+// NewPlatformInfoFromVersion returns the PlatformInfo of a Kubernetes version, without api-server discovery.
+// Only the api versions used by the operator are set, it's meant to render the manifests offline.
+func NewPlatformInfoFromVersion(gitVersion string) (PlatformInfo, error) {
+	parsed, err := utilversion.ParseGeneric(gitVersion)
+	if err != nil {
+		return PlatformInfo{}, fmt.Errorf("invalid Kubernetes version %q: %w", gitVersion, err)
+	}
+	versionInfo := &version.Info{
+		Major:      fmt.Sprint(parsed.Major()),
+		Minor:      fmt.Sprint(parsed.Minor()),
+		GitVersion: "v" + parsed.String(),
+	}
+
+	apiPreferredVersions := map[string]string{
+		"PodDisruptionBudget":     "policy/v1",
+		"HorizontalPodAutoscaler": "autoscaling/v2",
+	}
+	apiOtherVersions := map[string]string{}
+	if !parsed.AtLeast(utilversion.MustParseGeneric("1.21")) {
+		apiPreferredVersions["PodDisruptionBudget"] = "policy/v1beta1"
+	}
+	if !parsed.AtLeast(utilversion.MustParseGeneric("1.23")) {
+		apiPreferredVersions["HorizontalPodAutoscaler"] = "autoscaling/v2beta2"
+	}
+	// PodSecurityPolicies were removed in Kubernetes 1.25
+	if !parsed.AtLeast(utilversion.MustParseGeneric("1.25")) {
+		apiOtherVersions["PodSecurityPolicy"] = "policy/v1beta1"
+	}
+
+	return NewPlatformInfoFromVersionMaps(versionInfo, apiPreferredVersions, apiOtherVersions), nil
+}
+
+// GetVersionInfo returns the Kubernetes version
+func (platformInfo *PlatformInfo) GetVersionInfo() *version.Info {
+	return platformInfo.versionInfo
+}
+
 func (platformInfo *PlatformInfo) UseV1Beta1PDB() bool {
 	preferredVersion := platformInfo.apiPreferredVersions["PodDisruptionBudget"]
 
@@ -88,6 +130,29 @@ func (platformInfo *PlatformInfo) CreatePDBObjectList() client.ObjectList {
 	}
 }
 
+// UseV2Beta2HPA returns true if autoscaling/v2beta2 should be used for the HorizontalPodAutoscalers.
+// autoscaling/v2beta2 is served by all the Kubernetes versions that don't prefer autoscaling/v2 (< 1.26).
+func (platformInfo *PlatformInfo) UseV2Beta2HPA() bool {
+	preferredVersion := platformInfo.apiPreferredVersions["HorizontalPodAutoscaler"]
+
+	// If the preferred version is unknown, we default to v2.
+	return preferredVersion != "" && preferredVersion != "autoscaling/v2"
+}
+
+func (platformInfo *PlatformInfo) CreateHPAObject() client.Object {
+	if platformInfo.UseV2Beta2HPA() {
+		return &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	}
+	return &autoscalingv2.HorizontalPodAutoscaler{}
+}
+
+func (platformInfo *PlatformInfo) CreateHPAObjectList() client.ObjectList {
+	if platformInfo.UseV2Beta2HPA() {
+		return &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	}
+	return &autoscalingv2.HorizontalPodAutoscalerList{}
+}
+
 func (platformInfo *PlatformInfo) GetAgentResourcesKind(withCiliumResources bool) []ObjectKind {
 	return getResourcesKind(withCiliumResources, platformInfo.supportsPSP())
 }
@@ -141,6 +141,63 @@ func Test_getPDBFlag(t *testing.T) {
 	}
 }
 
+func Test_NewPlatformInfoFromVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		wantErr       bool
+		wantMinor     string
+		useV1Beta1PDB bool
+		useV2Beta2HPA bool
+		supportsPSP   bool
+	}{
+		{
+			name:          "Kubernetes 1.20",
+			version:       "v1.20.15",
+			wantMinor:     "20",
+			useV1Beta1PDB: true,
+			useV2Beta2HPA: true,
+			supportsPSP:   true,
+		},
+		{
+			name:          "Kubernetes 1.24, provider suffix",
+			version:       "v1.24.8-eks-ffeb93d",
+			wantMinor:     "24",
+			useV1Beta1PDB: false,
+			useV2Beta2HPA: false,
+			supportsPSP:   true,
+		},
+		{
+			name:          "Kubernetes 1.25",
+			version:       "1.25",
+			wantMinor:     "25",
+			useV1Beta1PDB: false,
+			useV2Beta2HPA: false,
+			supportsPSP:   false,
+		},
+		{
+			name:    "invalid version",
+			version: "foo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platformInfo, err := NewPlatformInfoFromVersion(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMinor, platformInfo.GetVersionInfo().Minor)
+			assert.Equal(t, tt.useV1Beta1PDB, platformInfo.UseV1Beta1PDB())
+			assert.Equal(t, tt.useV2Beta2HPA, platformInfo.UseV2Beta2HPA())
+			assert.Equal(t, tt.supportsPSP, platformInfo.supportsPSP())
+		})
+	}
+}
+
 func Test_getDatadogAgentVersions(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -6,10 +6,12 @@
 package kubernetes
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
 
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -28,18 +30,190 @@ const (
 	// GKE provider values https://cloud.google.com/kubernetes-engine/docs/concepts/node-images#available_node_images
 	GKECosContainerdType = "cos_containerd"
 	GKECosType           = "cos"
+	// GKEAutopilotType is the provider value of the GKE Autopilot nodes
+	GKEAutopilotType = "autopilot"
+	// EKSBottlerocketType is the provider value of the Bottlerocket nodes
+	EKSBottlerocketType = "bottlerocket"
+	// AKS provider values https://learn.microsoft.com/en-us/azure/aks/cluster-configuration#default-os-disk-sizing
+	AKSUbuntuType     = "Ubuntu"
+	AKSAzureLinuxType = "AzureLinux"
+	AKSCBLMarinerType = "CBLMariner"
+	// OpenShiftRHCOSType is the provider value of the Red Hat Enterprise Linux CoreOS nodes
+	OpenShiftRHCOSType = "rhcos"
 
 	// CloudProvider
-	GKECloudProvider = "gke"
+	GKECloudProvider       = "gke"
+	EKSCloudProvider       = "eks"
+	AKSCloudProvider       = "aks"
+	OpenShiftCloudProvider = "openshift"
 
 	// ProviderLabel
-	GKEProviderLabel = "cloud.google.com/gke-os-distribution"
+	GKEProviderLabel          = "cloud.google.com/gke-os-distribution"
+	GKEAutopilotProviderLabel = "cloud.google.com/gke-autopilot"
+	// EKSBottlerocketProviderLabel must be set by the user on the nodes running Bottlerocket
+	EKSBottlerocketProviderLabel = OSImageProviderLabel
+	AKSProviderLabel             = "kubernetes.azure.com/os-sku"
+	OpenShiftProviderLabel       = "node.openshift.io/os_id"
+
+	// OSImageProviderLabel identifies the nodes of the providers without a label of their own, it must be set by the user
+	OSImageProviderLabel = "agent.datadoghq.com/os-image"
+	// EKSBottlerocketOSImage is the prefix of the OS image of the Bottlerocket nodes
+	EKSBottlerocketOSImage = "Bottlerocket OS"
 )
 
-// ProviderValue allowlist
-var providerValueAllowlist = map[string]struct{}{
-	GKECosContainerdType: {},
-	GKECosType:           {},
+// ProviderDefinition describes how to detect the nodes of a provider, and the node Agent defaults they require.
+type ProviderDefinition struct {
+	// CloudProvider is the cloud provider, the provider name is `<CloudProvider>-<Value>`.
+	CloudProvider string
+	// Label and Value identify the nodes of the provider.
+	Label string
+	Value string
+	// OSImage, if set, is the prefix of the OS image (`status.nodeInfo.osImage`) of the nodes of the provider. These
+	// nodes don't have a label of their own: the user sets Label to Value on them, so that the node Agents can select them.
+	// The nodes with the label but another OS image don't belong to the provider.
+	OSImage string
+	// Defaults are applied to the node Agent pod template of the provider.
+	Defaults ProviderDefaults
+}
+
+// Name returns the provider name. It's lowercased as it's used in the node Agent DaemonSet name.
+// NOTE: this should not be used to create a resource name as it may contain underscores
+func (d *ProviderDefinition) Name() string {
+	return strings.ToLower(generateValidProviderName(d.CloudProvider, d.Value))
+}
+
+// matches returns whether a node belongs to the provider
+func (d *ProviderDefinition) matches(labels map[string]string, osImage string) bool {
+	val, ok := labels[d.Label]
+	if !ok || val != d.Value {
+		return false
+	}
+	return d.OSImage == "" || osImage == "" || strings.HasPrefix(osImage, d.OSImage)
+}
+
+// ProviderDefaults contains the node Agent settings required on the nodes of a provider.
+type ProviderDefaults struct {
+	// DisabledFeatures lists the IDs of the features that can't run on the nodes of the provider.
+	DisabledFeatures []string
+	// UnavailableHostPaths lists the host paths that can't be mounted on the nodes of the provider.
+	// The volumes using them are removed from the pod template.
+	UnavailableHostPaths []string
+	// CriSocketPath is the path of the container runtime socket, used if no runtime socket is configured.
+	CriSocketPath string
+	// EnvVars are added to the node Agent containers, unless they are already set.
+	EnvVars []corev1.EnvVar
+	// DisableAppArmor removes the AppArmor profiles of the node Agent containers, on nodes without AppArmor.
+	DisableAppArmor bool
+	// SeccompProfile replaces the Localhost seccomp profiles of the node Agent containers, on nodes where
+	// custom seccomp profiles can't be installed. The seccomp-setup init container is removed.
+	SeccompProfile *corev1.SeccompProfile
+}
+
+// systemProbeFeatures lists the IDs of the features running in the system-probe container.
+var systemProbeFeatures = []string{"cws", "npm", "usm", "oom_kill", "tcp_queue_length", "ebpf_check"}
+
+// providerRegistry lists the supported providers by order of precedence: a node matching
+// the labels of several providers belongs to the first one.
+var (
+	providerRegistry = []ProviderDefinition{
+		{
+			// Autopilot nodes also run COS, they must be detected first.
+			CloudProvider: GKECloudProvider,
+			Label:         GKEAutopilotProviderLabel,
+			Value:         "true",
+			Defaults: ProviderDefaults{
+				// Autopilot doesn't allow privileged containers, Localhost seccomp profiles and restricts host paths.
+				DisabledFeatures:     append([]string{"cspm"}, systemProbeFeatures...),
+				UnavailableHostPaths: []string{apicommon.SrcVolumePath, apicommon.SeccompRootPath},
+				SeccompProfile:       &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+		{
+			CloudProvider: GKECloudProvider,
+			Label:         GKEProviderLabel,
+			Value:         GKECosType,
+			Defaults:      ProviderDefaults{UnavailableHostPaths: []string{apicommon.SrcVolumePath}},
+		},
+		{
+			CloudProvider: GKECloudProvider,
+			Label:         GKEProviderLabel,
+			Value:         GKECosContainerdType,
+			Defaults:      ProviderDefaults{UnavailableHostPaths: []string{apicommon.SrcVolumePath}},
+		},
+		{
+			CloudProvider: EKSCloudProvider,
+			Label:         EKSBottlerocketProviderLabel,
+			Value:         EKSBottlerocketType,
+			OSImage:       EKSBottlerocketOSImage,
+			Defaults: ProviderDefaults{
+				UnavailableHostPaths: []string{apicommon.SrcVolumePath},
+				CriSocketPath:        "/run/dockershim.sock",
+			},
+		},
+		{
+			CloudProvider: AKSCloudProvider,
+			Label:         AKSProviderLabel,
+			Value:         AKSUbuntuType,
+			Defaults:      aksDefaults,
+		},
+		{
+			CloudProvider: AKSCloudProvider,
+			Label:         AKSProviderLabel,
+			Value:         AKSAzureLinuxType,
+			Defaults:      aksDefaults,
+		},
+		{
+			CloudProvider: AKSCloudProvider,
+			Label:         AKSProviderLabel,
+			Value:         AKSCBLMarinerType,
+			Defaults:      aksDefaults,
+		},
+		{
+			CloudProvider: OpenShiftCloudProvider,
+			Label:         OpenShiftProviderLabel,
+			Value:         OpenShiftRHCOSType,
+			Defaults:      ProviderDefaults{DisableAppArmor: true},
+		},
+	}
+	providerRegistryMutex sync.RWMutex
+)
+
+// aksDefaults are the defaults of the AKS nodes, the kubelet certificates don't contain the node IP.
+var aksDefaults = ProviderDefaults{
+	EnvVars: []corev1.EnvVar{{Name: apicommon.DDKubeletTLSVerify, Value: "false"}},
+}
+
+// RegisterProvider adds a provider to the registry, with a lower precedence than the already registered providers.
+// It returns an error if a provider with the same name is already registered.
+func RegisterProvider(definition ProviderDefinition) error {
+	providerRegistryMutex.Lock()
+	defer providerRegistryMutex.Unlock()
+
+	name := definition.Name()
+	if name == "" || definition.Label == "" {
+		return fmt.Errorf("invalid provider definition: cloud provider, label and value must be set")
+	}
+	for _, def := range providerRegistry {
+		if def.Name() == name {
+			return fmt.Errorf("provider %s is already registered", name)
+		}
+	}
+	providerRegistry = append(providerRegistry, definition)
+
+	return nil
+}
+
+// GetProviderDefinition returns the definition of a registered provider
+func GetProviderDefinition(provider string) (ProviderDefinition, bool) {
+	providerRegistryMutex.RLock()
+	defer providerRegistryMutex.RUnlock()
+
+	for _, def := range providerRegistry {
+		if def.Name() == provider {
+			return def, true
+		}
+	}
+	return ProviderDefinition{}, false
 }
 
 // NewProviderStore generates an empty ProviderStore instance
@@ -50,13 +224,25 @@ func NewProviderStore(log logr.Logger) ProviderStore {
 	}
 }
 
-// DetermineProvider creates a Provider based on a map of labels
+// DetermineProvider creates a Provider based on a map of labels.
+// The OS image of the nodes isn't checked, see DetermineNodeProvider.
 func DetermineProvider(labels map[string]string) string {
-	if len(labels) > 0 {
-		// GKE
-		if val, ok := labels[GKEProviderLabel]; ok {
-			if provider := generateValidProviderName(GKECloudProvider, val); provider != "" {
-				return provider
+	return determineProvider(labels, "")
+}
+
+// DetermineNodeProvider creates a Provider based on the labels and the OS image of a node
+func DetermineNodeProvider(node *corev1.Node) string {
+	return determineProvider(node.Labels, node.Status.NodeInfo.OSImage)
+}
+
+func determineProvider(labels map[string]string, osImage string) string {
+	if len(labels) > 0 || osImage != "" {
+		providerRegistryMutex.RLock()
+		defer providerRegistryMutex.RUnlock()
+
+		for _, def := range providerRegistry {
+			if def.matches(labels, osImage) {
+				return def.Name()
 			}
 		}
 	}
@@ -64,6 +250,28 @@ func DetermineProvider(labels map[string]string) string {
 	return DefaultProvider
 }
 
+// UnlabelledNodeProvider returns the provider of a node running the OS image of the provider, but missing the label
+// the user must set. Such a node belongs to the default provider. It returns false if the node isn't missing a label.
+func UnlabelledNodeProvider(node *corev1.Node) (ProviderDefinition, bool) {
+	osImage := node.Status.NodeInfo.OSImage
+	if osImage == "" {
+		return ProviderDefinition{}, false
+	}
+	providerRegistryMutex.RLock()
+	defer providerRegistryMutex.RUnlock()
+
+	for _, def := range providerRegistry {
+		if def.OSImage == "" || !strings.HasPrefix(osImage, def.OSImage) {
+			continue
+		}
+		if val, ok := node.Labels[def.Label]; ok && val == def.Value {
+			return ProviderDefinition{}, false
+		}
+		return def, true
+	}
+	return ProviderDefinition{}, false
+}
+
 // GetProviders gets a list of providers
 func (p *ProviderStore) GetProviders() *map[string]struct{} {
 	p.mutex.Lock()
@@ -107,32 +315,61 @@ func (p *ProviderStore) GenerateProviderNodeAffinity(provider string) []corev1.N
 				value,
 			},
 		})
+		// exclude the nodes of the providers with a higher precedence
+		for _, def := range p.precedingProviders(provider) {
+			if def.Label == key {
+				// the nodes can't have both values
+				continue
+			}
+			nsrList = append(nsrList, corev1.NodeSelectorRequirement{
+				Key:      def.Label,
+				Operator: corev1.NodeSelectorOpNotIn,
+				Values: []string{
+					def.Value,
+				},
+			})
+		}
 	}
 
 	return nsrList
 }
 
+// precedingProviders returns the providers present in the store with a higher precedence than the given one
+func (p *ProviderStore) precedingProviders(provider string) []ProviderDefinition {
+	providerRegistryMutex.RLock()
+	defer providerRegistryMutex.RUnlock()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var preceding []ProviderDefinition
+	for _, def := range providerRegistry {
+		name := def.Name()
+		if name == provider {
+			return preceding
+		}
+		if _, found := p.providers[name]; found {
+			preceding = append(preceding, def)
+		}
+	}
+	return nil
+}
+
 // generateValidProviderName creates a provider name from the cloud provider
 // and provider value. NOTE: this should not be used to create a resource name
 // as it may contain underscores
 func generateValidProviderName(cloudProvider, providerValue string) string {
-	if isProviderValueAllowed(providerValue) {
+	if cloudProvider != "" && providerValue != "" {
 		return cloudProvider + "-" + providerValue
 	}
 	return ""
 }
 
-// isProviderValueAllowed returns whether the value of a provider is present
-// in the allowlist
-func isProviderValueAllowed(value string) bool {
-	if _, ok := providerValueAllowlist[value]; ok {
-		return true
-	}
-	return false
-}
-
 // GetProviderLabelKeyValue gets the corresponding cloud provider label key and value from a provider name
 func GetProviderLabelKeyValue(provider string) (string, string) {
+	if def, found := GetProviderDefinition(provider); found {
+		return def.Label, def.Value
+	}
+
 	// cloud provider to label mapping
 	providerMapping := map[string]string{
 		GKECloudProvider: GKEProviderLabel,
@@ -20,12 +20,17 @@ var (
 	defaultProvider          = DefaultProvider
 	gkeCosContainerdProvider = generateValidProviderName(GKECloudProvider, GKECosContainerdType)
 	gkeCosProvider           = generateValidProviderName(GKECloudProvider, GKECosType)
+	gkeAutopilotProvider     = generateValidProviderName(GKECloudProvider, "true")
+	eksBottlerocketProvider  = generateValidProviderName(EKSCloudProvider, EKSBottlerocketType)
+	aksUbuntuProvider        = "aks-ubuntu"
+	openShiftRHCOSProvider   = generateValidProviderName(OpenShiftCloudProvider, OpenShiftRHCOSType)
 )
 
 func Test_determineProvider(t *testing.T) {
 	tests := []struct {
 		name     string
 		labels   map[string]string
+		osImage  string
 		provider string
 	}{
 		{
@@ -56,43 +61,65 @@ func Test_determineProvider(t *testing.T) {
 			},
 			provider: generateValidProviderName(GKECloudProvider, GKECosContainerdType),
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			p := DetermineProvider(tt.labels)
-			assert.Equal(t, tt.provider, p)
-		})
-	}
-}
-
-func Test_isProviderValueAllowed(t *testing.T) {
-	tests := []struct {
-		name  string
-		value string
-		want  bool
-	}{
 		{
-			name:  "valid value",
-			value: GKECosContainerdType,
-			want:  true,
+			name: "gke autopilot provider takes precedence over gke cos",
+			labels: map[string]string{
+				GKEProviderLabel:          GKECosContainerdType,
+				GKEAutopilotProviderLabel: "true",
+			},
+			provider: gkeAutopilotProvider,
+		},
+		{
+			name: "eks bottlerocket provider",
+			labels: map[string]string{
+				EKSBottlerocketProviderLabel: EKSBottlerocketType,
+			},
+			osImage:  "Bottlerocket OS 1.19.2 (aws-k8s-1.28)",
+			provider: eksBottlerocketProvider,
+		},
+		{
+			name:     "eks bottlerocket os image without the label",
+			osImage:  "Bottlerocket OS 1.19.2 (aws-k8s-1.28)",
+			provider: defaultProvider,
+		},
+		{
+			name: "eks bottlerocket label without the bottlerocket os image",
+			labels: map[string]string{
+				EKSBottlerocketProviderLabel: EKSBottlerocketType,
+			},
+			osImage:  "Amazon Linux 2",
+			provider: defaultProvider,
 		},
 		{
-			name:  "invalid value",
-			value: "foo",
-			want:  false,
+			name: "aks ubuntu provider",
+			labels: map[string]string{
+				AKSProviderLabel: AKSUbuntuType,
+			},
+			provider: aksUbuntuProvider,
 		},
 		{
-			name:  "empty value",
-			value: "",
-			want:  false,
+			name: "aks unknown os sku",
+			labels: map[string]string{
+				AKSProviderLabel: "Windows2022",
+			},
+			provider: defaultProvider,
+		},
+		{
+			name: "openshift rhcos provider",
+			labels: map[string]string{
+				OpenShiftProviderLabel: OpenShiftRHCOSType,
+			},
+			provider: openShiftRHCOSProvider,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			allowed := isProviderValueAllowed(tt.value)
-			assert.Equal(t, tt.want, allowed)
+			node := &corev1.Node{}
+			node.Labels = tt.labels
+			node.Status.NodeInfo.OSImage = tt.osImage
+			p := DetermineNodeProvider(node)
+			assert.Equal(t, tt.provider, p)
 		})
 	}
 }
@@ -223,6 +250,47 @@ func Test_GenerateProviderNodeAffinity(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "gke autopilot and gke cos providers, gke cos provider",
+			existingProviders: map[string]struct{}{
+				gkeAutopilotProvider: {},
+				gkeCosProvider:       {},
+			},
+			provider: gkeCosProvider,
+			wantNSR: []corev1.NodeSelectorRequirement{
+				{
+					Key:      GKEProviderLabel,
+					Operator: corev1.NodeSelectorOpIn,
+					Values: []string{
+						GKECosType,
+					},
+				},
+				{
+					Key:      GKEAutopilotProviderLabel,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values: []string{
+						"true",
+					},
+				},
+			},
+		},
+		{
+			name: "gke autopilot and gke cos providers, gke autopilot provider",
+			existingProviders: map[string]struct{}{
+				gkeAutopilotProvider: {},
+				gkeCosProvider:       {},
+			},
+			provider: gkeAutopilotProvider,
+			wantNSR: []corev1.NodeSelectorRequirement{
+				{
+					Key:      GKEAutopilotProviderLabel,
+					Operator: corev1.NodeSelectorOpIn,
+					Values: []string{
+						"true",
+					},
+				},
+			},
+		},
 		{
 			name: "multiple providers, ubuntu provider",
 			existingProviders: map[string]struct{}{
@@ -300,6 +368,18 @@ func Test_GetProviderLabelKeyValue(t *testing.T) {
 			wantLabel: GKEProviderLabel,
 			wantValue: GKECosType,
 		},
+		{
+			name:      "aks provider with uppercase value",
+			provider:  "aks-azurelinux",
+			wantLabel: AKSProviderLabel,
+			wantValue: AKSAzureLinuxType,
+		},
+		{
+			name:      "eks bottlerocket provider",
+			provider:  eksBottlerocketProvider,
+			wantLabel: EKSBottlerocketProviderLabel,
+			wantValue: EKSBottlerocketType,
+		},
 	}
 
 	for _, tt := range tests {
@@ -506,6 +586,20 @@ func Test_GetAgentNameWithProvider(t *testing.T) {
 			overrideName: apiutils.NewStringPointer("bar"),
 			want:         "bar-default",
 		},
+		{
+			name:         "ds name set, aks provider",
+			dsName:       "foo",
+			overrideName: nil,
+			provider:     "aks-azurelinux",
+			want:         "foo-aks-azurelinux",
+		},
+		{
+			name:         "ds name set, provider case is kept",
+			dsName:       "foo",
+			overrideName: nil,
+			provider:     "custom-Foo_Bar",
+			want:         "foo-custom-Foo-Bar",
+		},
 		{
 			name:         "ds and override name set, no provider",
 			dsName:       "foo",
@@ -537,3 +631,43 @@ func Test_GetAgentNameWithProvider(t *testing.T) {
 		})
 	}
 }
+
+func Test_RegisterProvider(t *testing.T) {
+	registry := providerRegistry
+	defer func() { providerRegistry = registry }()
+
+	err := RegisterProvider(ProviderDefinition{CloudProvider: GKECloudProvider, Label: GKEProviderLabel, Value: GKECosType})
+	assert.Error(t, err, "a provider can't be registered twice")
+
+	err = RegisterProvider(ProviderDefinition{CloudProvider: "custom", Value: "foo"})
+	assert.Error(t, err, "a provider needs a label")
+
+	customDefaults := ProviderDefaults{DisabledFeatures: []string{"npm"}}
+	err = RegisterProvider(ProviderDefinition{CloudProvider: "custom", Label: "example.com/os", Value: "foo", Defaults: customDefaults})
+	assert.NoError(t, err)
+
+	def, found := GetProviderDefinition("custom-foo")
+	assert.True(t, found)
+	assert.Equal(t, customDefaults, def.Defaults)
+	assert.Equal(t, "custom-foo", DetermineProvider(map[string]string{"example.com/os": "foo"}))
+	// registered providers have a lower precedence than the built-in ones
+	assert.Equal(t, openShiftRHCOSProvider, DetermineProvider(map[string]string{"example.com/os": "foo", OpenShiftProviderLabel: OpenShiftRHCOSType}))
+}
+
+func Test_UnlabelledNodeProvider(t *testing.T) {
+	node := &corev1.Node{}
+	node.Status.NodeInfo.OSImage = "Bottlerocket OS 1.19.2 (aws-k8s-1.28)"
+
+	def, missing := UnlabelledNodeProvider(node)
+	assert.True(t, missing)
+	assert.Equal(t, eksBottlerocketProvider, def.Name())
+	assert.Equal(t, EKSBottlerocketProviderLabel, def.Label)
+	assert.Equal(t, EKSBottlerocketType, def.Value)
+
+	node.Labels = map[string]string{def.Label: def.Value}
+	_, missing = UnlabelledNodeProvider(node)
+	assert.False(t, missing, "the label is already set")
+
+	_, missing = UnlabelledNodeProvider(&corev1.Node{})
+	assert.False(t, missing, "the node has no OS image")
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
@@ -39,6 +41,15 @@ func NewClient(clientConfig clientcmd.ClientConfig) (client.Client, error) {
 		return nil, fmt.Errorf("unable register DatadogAgent apis: %w", err)
 	}
 
+	// Register the schemes of the objects managed by the operator
+	if err = edsv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable register ExtendedDaemonSet apis: %w", err)
+	}
+
+	if err = apiregistrationv1.AddToScheme(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable register APIService apis: %w", err)
+	}
+
 	// Create the Client for Read/Write operations.
 	var newClient client.Client
 	newClient, err = client.New(restConfig, client.Options{Scheme: scheme.Scheme, Mapper: mapper})
@@ -0,0 +1,617 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package testutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	zorkian "github.com/zorkian/go-datadog-api"
+)
+
+const (
+	// FakeAPIKey is the API key sent by the clients returned by FakeDatadogAPI
+	FakeAPIKey = "fake-api-key"
+	// FakeAppKey is the application key sent by the clients returned by FakeDatadogAPI
+	FakeAppKey = "fake-app-key"
+	// FakeCreator is the creator of the objects created in FakeDatadogAPI
+	FakeCreator = "operator@example.com"
+
+	fakeRateLimitName = "fake_rate_limit"
+)
+
+// FakeDatadogRequest is a request received by FakeDatadogAPI
+type FakeDatadogRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// fakeError makes the requests matching the method and the path pattern fail
+type fakeError struct {
+	method     string
+	pattern    string
+	statusCode int
+	// remaining is the number of requests left to fail, a negative value makes all the requests fail
+	remaining int
+}
+
+// FakeDatadogAPI is an in-process fake of the Datadog API, serving the monitors, SLOs and downtimes endpoints
+// used by the controllers, and the validate, series and events endpoints used by the metrics forwarder.
+// The objects are stored in memory, so that they can be created, updated and deleted. Errors can be injected,
+// the requests can be rate limited, and all the requests are recorded.
+type FakeDatadogAPI struct {
+	server *httptest.Server
+
+	mutex          sync.Mutex
+	nextID         int64
+	monitors       map[int64]datadogV1.Monitor
+	slos           map[string]datadogV1.ServiceLevelObjective
+	sloHistory     map[string]float64
+	downtimes      map[int64]datadogV1.Downtime
+	series         []zorkian.Metric
+	events         []zorkian.Event
+	invalidAPIKeys map[string]bool
+	errors         []*fakeError
+	requests       []FakeDatadogRequest
+
+	rateLimit       int
+	rateLimitPeriod time.Duration
+	windowStart     time.Time
+	windowRequests  int
+}
+
+// NewFakeDatadogAPI starts a FakeDatadogAPI, it must be closed with Close.
+func NewFakeDatadogAPI() *FakeDatadogAPI {
+	f := &FakeDatadogAPI{
+		nextID:         1000,
+		monitors:       map[int64]datadogV1.Monitor{},
+		slos:           map[string]datadogV1.ServiceLevelObjective{},
+		sloHistory:     map[string]float64{},
+		downtimes:      map[int64]datadogV1.Downtime{},
+		invalidAPIKeys: map[string]bool{},
+	}
+	f.server = httptest.NewServer(f)
+	return f
+}
+
+// Close shuts the server down.
+func (f *FakeDatadogAPI) Close() {
+	f.server.Close()
+}
+
+// URL returns the base URL of the fake API, for instance to use with the metrics forwarder.
+func (f *FakeDatadogAPI) URL() string {
+	return f.server.URL
+}
+
+// APIClient returns a datadog-api-client-go client to use with the context returned by Auth.
+func (f *FakeDatadogAPI) APIClient() *datadogapi.APIClient {
+	config := datadogapi.NewConfiguration()
+	config.HTTPClient = f.server.Client()
+	return datadogapi.NewAPIClient(config)
+}
+
+// Auth returns the authentication context sending the requests of the datadog-api-client-go clients to the fake API.
+func (f *FakeDatadogAPI) Auth() context.Context {
+	parsedURL, _ := url.Parse(f.server.URL)
+	auth := context.WithValue(context.Background(), datadogapi.ContextAPIKeys, map[string]datadogapi.APIKey{
+		"apiKeyAuth": {Key: FakeAPIKey},
+		"appKeyAuth": {Key: FakeAppKey},
+	})
+	auth = context.WithValue(auth, datadogapi.ContextServerIndex, 1)
+	return context.WithValue(auth, datadogapi.ContextServerVariables, map[string]string{
+		"name":     parsedURL.Host,
+		"protocol": parsedURL.Scheme,
+	})
+}
+
+// InjectError makes the next count requests matching the method and the path fail with the status code.
+// The path can be a pattern as defined by path.Match, for instance /api/v1/monitor/*. A count of 0 makes all the
+// matching requests fail.
+func (f *FakeDatadogAPI) InjectError(method, pathPattern string, statusCode, count int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if count == 0 {
+		count = -1
+	}
+	f.errors = append(f.errors, &fakeError{method: method, pattern: pathPattern, statusCode: statusCode, remaining: count})
+}
+
+// ClearErrors removes the injected errors.
+func (f *FakeDatadogAPI) ClearErrors() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.errors = nil
+}
+
+// SetRateLimit limits the requests to limit per period, the requests over the limit get a 429 response.
+// The responses report the rate limit in the X-RateLimit headers, as the Datadog API does.
+func (f *FakeDatadogAPI) SetRateLimit(limit int, period time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.rateLimit = limit
+	f.rateLimitPeriod = period
+	f.windowStart = time.Time{}
+	f.windowRequests = 0
+}
+
+// SetInvalidAPIKey makes the validate endpoint reject the API key.
+func (f *FakeDatadogAPI) SetInvalidAPIKey(apiKey string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.invalidAPIKeys[apiKey] = true
+}
+
+// Requests returns the requests received by the fake API, in order.
+func (f *FakeDatadogAPI) Requests() []FakeDatadogRequest {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]FakeDatadogRequest{}, f.requests...)
+}
+
+// SetMonitor stores a monitor, for instance to set its state or to create it outside the controllers.
+// A monitor without ID gets a new one, which is returned.
+func (f *FakeDatadogAPI) SetMonitor(m datadogV1.Monitor) int64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if m.GetId() == 0 {
+		m.SetId(f.newID())
+	}
+	f.monitors[m.GetId()] = m
+	return m.GetId()
+}
+
+// Monitor returns a stored monitor.
+func (f *FakeDatadogAPI) Monitor(id int64) (datadogV1.Monitor, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	m, found := f.monitors[id]
+	return m, found
+}
+
+// SetSLO stores a SLO. A SLO without ID gets a new one, which is returned.
+func (f *FakeDatadogAPI) SetSLO(slo datadogV1.ServiceLevelObjective) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if slo.GetId() == "" {
+		slo.SetId(f.newSLOID())
+	}
+	f.slos[slo.GetId()] = slo
+	return slo.GetId()
+}
+
+// SLO returns a stored SLO.
+func (f *FakeDatadogAPI) SLO(id string) (datadogV1.ServiceLevelObjective, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	slo, found := f.slos[id]
+	return slo, found
+}
+
+// SetSLOHistory sets the SLI value returned by the history endpoint of a SLO, over any timeframe.
+func (f *FakeDatadogAPI) SetSLOHistory(id string, sliValue float64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.sloHistory[id] = sliValue
+}
+
+// Downtime returns a stored downtime.
+func (f *FakeDatadogAPI) Downtime(id int64) (datadogV1.Downtime, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	d, found := f.downtimes[id]
+	return d, found
+}
+
+// Series returns the metrics posted to the fake API.
+func (f *FakeDatadogAPI) Series() []zorkian.Metric {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]zorkian.Metric{}, f.series...)
+}
+
+// Events returns the events posted to the fake API.
+func (f *FakeDatadogAPI) Events() []zorkian.Event {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]zorkian.Event{}, f.events...)
+}
+
+// ServeHTTP implements the http.Handler interface
+func (f *FakeDatadogAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.requests = append(f.requests, FakeDatadogRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if f.isRateLimited(w) {
+		writeErrors(w, http.StatusTooManyRequests, "Rate limit exceeded")
+		return
+	}
+	if statusCode, found := f.getInjectedError(r); found {
+		writeErrors(w, statusCode, "Injected error")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" || segments[1] != "v1" {
+		writeErrors(w, http.StatusNotFound, "Not found")
+		return
+	}
+	switch segments[2] {
+	case "validate":
+		f.serveValidate(w, r)
+	case "monitor":
+		f.serveMonitors(w, r, segments[3:], body)
+	case "slo":
+		f.serveSLOs(w, r, segments[3:], body)
+	case "downtime":
+		f.serveDowntimes(w, r, segments[3:], body)
+	case "series":
+		f.serveSeries(w, r, body)
+	case "events":
+		f.serveEvents(w, r, body)
+	default:
+		writeErrors(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (f *FakeDatadogAPI) isRateLimited(w http.ResponseWriter) bool {
+	if f.rateLimit == 0 {
+		return false
+	}
+	now := time.Now()
+	if now.Sub(f.windowStart) >= f.rateLimitPeriod {
+		f.windowStart = now
+		f.windowRequests = 0
+	}
+	f.windowRequests++
+
+	remaining := f.rateLimit - f.windowRequests
+	if remaining < 0 {
+		remaining = 0
+	}
+	reset := math.Ceil(f.windowStart.Add(f.rateLimitPeriod).Sub(now).Seconds())
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(f.rateLimit))
+	w.Header().Set("X-RateLimit-Period", strconv.Itoa(int(f.rateLimitPeriod.Seconds())))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(reset)))
+	w.Header().Set("X-RateLimit-Name", fakeRateLimitName)
+
+	return f.windowRequests > f.rateLimit
+}
+
+func (f *FakeDatadogAPI) getInjectedError(r *http.Request) (int, bool) {
+	for _, e := range f.errors {
+		if e.remaining == 0 || e.method != r.Method {
+			continue
+		}
+		if matched, _ := path.Match(e.pattern, r.URL.Path); !matched {
+			continue
+		}
+		if e.remaining > 0 {
+			e.remaining--
+		}
+		return e.statusCode, true
+	}
+	return 0, false
+}
+
+func (f *FakeDatadogAPI) serveValidate(w http.ResponseWriter, r *http.Request) {
+	apiKey := r.Header.Get("DD-API-KEY")
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get("api_key")
+	}
+	if f.invalidAPIKeys[apiKey] {
+		writeErrors(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"valid": true})
+}
+
+func (f *FakeDatadogAPI) serveMonitors(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.listMonitors(r.URL.Query()))
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var m datadogV1.Monitor
+		if err := json.Unmarshal(body, &m); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		now := time.Now()
+		m.SetId(f.newID())
+		m.SetCreated(now)
+		m.SetModified(now)
+		m.SetCreator(datadogV1.Creator{Email: datadogapi.PtrString(FakeCreator)})
+		if _, found := m.GetOverallStateOk(); !found {
+			m.SetOverallState(datadogV1.MONITOROVERALLSTATES_OK)
+		}
+		f.monitors[m.GetId()] = m
+		writeJSON(w, http.StatusOK, m)
+	case len(segments) == 1 && segments[0] == "validate" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case len(segments) == 1 || (len(segments) == 2 && segments[1] == "validate"):
+		id, err := strconv.ParseInt(segments[0], 10, 64)
+		m, found := f.monitors[id]
+		if err != nil || !found {
+			writeErrors(w, http.StatusNotFound, "Monitor not found")
+			return
+		}
+		f.serveMonitor(w, r, segments, body, m)
+	default:
+		writeErrors(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (f *FakeDatadogAPI) serveMonitor(w http.ResponseWriter, r *http.Request, segments []string, body []byte, m datadogV1.Monitor) {
+	switch {
+	case len(segments) == 2 && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, m)
+	case r.Method == http.MethodPut:
+		if err := overlay(&m, body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		m.SetModified(time.Now())
+		f.monitors[m.GetId()] = m
+		writeJSON(w, http.StatusOK, m)
+	case r.Method == http.MethodDelete:
+		delete(f.monitors, m.GetId())
+		writeJSON(w, http.StatusOK, datadogV1.DeletedMonitor{DeletedMonitorId: datadogapi.PtrInt64(m.GetId())})
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// listMonitors returns the monitors having all the monitor_tags, sorted by ID and paginated
+func (f *FakeDatadogAPI) listMonitors(query url.Values) []datadogV1.Monitor {
+	var tags []string
+	if t := query.Get("monitor_tags"); t != "" {
+		tags = strings.Split(t, ",")
+	}
+
+	monitors := []datadogV1.Monitor{}
+	for _, m := range f.monitors {
+		if containsAll(m.GetTags(), tags) {
+			monitors = append(monitors, m)
+		}
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i].GetId() < monitors[j].GetId() })
+
+	if query.Get("page") == "" {
+		return monitors
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 100
+	}
+	start := page * pageSize
+	if start >= len(monitors) {
+		return []datadogV1.Monitor{}
+	}
+	end := start + pageSize
+	if end > len(monitors) {
+		end = len(monitors)
+	}
+	return monitors[start:end]
+}
+
+func (f *FakeDatadogAPI) serveSLOs(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	if len(segments) == 0 {
+		if r.Method != http.MethodPost {
+			writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		var slo datadogV1.ServiceLevelObjective
+		if err := json.Unmarshal(body, &slo); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		now := time.Now().Unix()
+		slo.SetId(f.newSLOID())
+		slo.SetCreatedAt(now)
+		slo.SetModifiedAt(now)
+		slo.SetCreator(datadogV1.Creator{Email: datadogapi.PtrString(FakeCreator)})
+		f.slos[slo.GetId()] = slo
+		writeJSON(w, http.StatusOK, datadogV1.SLOListResponse{Data: []datadogV1.ServiceLevelObjective{slo}})
+		return
+	}
+
+	slo, found := f.slos[segments[0]]
+	if !found || len(segments) > 2 || (len(segments) == 2 && segments[1] != "history") {
+		writeErrors(w, http.StatusNotFound, "SLO not found")
+		return
+	}
+	switch {
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		data := datadogV1.SLOHistoryResponseData{}
+		if value, found := f.sloHistory[slo.GetId()]; found {
+			data.Overall = &datadogV1.SLOHistorySLIData{SliValue: *datadogapi.NewNullableFloat64(&value)}
+		}
+		writeJSON(w, http.StatusOK, datadogV1.SLOHistoryResponse{Data: &data})
+	case r.Method == http.MethodGet:
+		var data datadogV1.SLOResponseData
+		if err := convert(slo, &data); err != nil {
+			writeErrors(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, datadogV1.SLOResponse{Data: &data})
+	case r.Method == http.MethodPut:
+		if err := overlay(&slo, body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		slo.SetModifiedAt(time.Now().Unix())
+		f.slos[slo.GetId()] = slo
+		writeJSON(w, http.StatusOK, datadogV1.SLOListResponse{Data: []datadogV1.ServiceLevelObjective{slo}})
+	case r.Method == http.MethodDelete:
+		delete(f.slos, slo.GetId())
+		writeJSON(w, http.StatusOK, datadogV1.SLODeleteResponse{Data: []string{slo.GetId()}})
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *FakeDatadogAPI) serveDowntimes(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	if len(segments) == 0 {
+		if r.Method != http.MethodPost {
+			writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		var d datadogV1.Downtime
+		if err := json.Unmarshal(body, &d); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		d.SetId(f.newID())
+		d.SetActive(true)
+		f.downtimes[d.GetId()] = d
+		writeJSON(w, http.StatusOK, d)
+		return
+	}
+
+	id, err := strconv.ParseInt(segments[0], 10, 64)
+	d, found := f.downtimes[id]
+	if err != nil || !found || len(segments) > 1 {
+		writeErrors(w, http.StatusNotFound, "Downtime not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, d)
+	case http.MethodPut:
+		if err := overlay(&d, body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		f.downtimes[id] = d
+		writeJSON(w, http.StatusOK, d)
+	case http.MethodDelete:
+		delete(f.downtimes, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *FakeDatadogAPI) serveSeries(w http.ResponseWriter, r *http.Request, body []byte) {
+	var payload struct {
+		Series []zorkian.Metric `json:"series"`
+	}
+	if r.Method != http.MethodPost {
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	f.series = append(f.series, payload.Series...)
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "ok"})
+}
+
+func (f *FakeDatadogAPI) serveEvents(w http.ResponseWriter, r *http.Request, body []byte) {
+	var event zorkian.Event
+	if r.Method != http.MethodPost {
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	event.SetId(int(f.newID()))
+	f.events = append(f.events, event)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"event": event, "status": "ok"})
+}
+
+func (f *FakeDatadogAPI) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *FakeDatadogAPI) newSLOID() string {
+	return fmt.Sprintf("%032x", f.newID())
+}
+
+// overlay updates the fields of obj set in the JSON body, the required fields of obj can be missing from the body
+func overlay(obj interface{}, body []byte) error {
+	var fields, updated map[string]json.RawMessage
+	if err := convert(obj, &fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &updated); err != nil {
+		return err
+	}
+	for k, v := range updated {
+		fields[k] = v
+	}
+	return convert(fields, obj)
+}
+
+// convert copies in to out through their JSON representation
+func convert(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func containsAll(values, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, v := range values {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func writeErrors(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string][]string{"errors": {message}})
+}
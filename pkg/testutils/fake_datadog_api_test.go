@@ -0,0 +1,182 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package testutils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	zorkian "github.com/zorkian/go-datadog-api"
+)
+
+func TestFakeDatadogAPI_Monitors(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	client := datadogV1.NewMonitorsApi(f.APIClient())
+
+	monitor := datadogV1.NewMonitor("avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5", datadogV1.MONITORTYPE_METRIC_ALERT)
+	monitor.SetName("test monitor")
+	monitor.SetTags([]string{"generated:kubernetes"})
+	_, _, err := client.ValidateMonitor(f.Auth(), *monitor)
+	require.NoError(t, err)
+	created, _, err := client.CreateMonitor(f.Auth(), *monitor)
+	require.NoError(t, err)
+	assert.NotZero(t, created.GetId())
+	assert.Equal(t, FakeCreator, created.Creator.GetEmail())
+
+	update := datadogV1.MonitorUpdateRequest{Name: datadogapi.PtrString("updated monitor")}
+	_, _, err = client.UpdateMonitor(f.Auth(), created.GetId(), update)
+	require.NoError(t, err)
+	got, _, err := client.GetMonitor(f.Auth(), created.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "updated monitor", got.GetName())
+	assert.Equal(t, monitor.Query, got.Query)
+
+	other := f.SetMonitor(datadogV1.Monitor{Query: "avg(last_5m):avg:system.cpu.user{*} > 90", Type: datadogV1.MONITORTYPE_METRIC_ALERT})
+	listed, _, err := client.ListMonitors(f.Auth(), *datadogV1.NewListMonitorsOptionalParameters().WithMonitorTags("generated:kubernetes"))
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+	listed, _, err = client.ListMonitors(f.Auth(), *datadogV1.NewListMonitorsOptionalParameters().WithPage(1).WithPageSize(1))
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, other, listed[0].GetId())
+
+	_, _, err = client.DeleteMonitor(f.Auth(), created.GetId())
+	require.NoError(t, err)
+	_, _, err = client.GetMonitor(f.Auth(), created.GetId())
+	assert.EqualError(t, err, "404 Not Found")
+}
+
+func TestFakeDatadogAPI_SLOs(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	client := datadogV1.NewServiceLevelObjectivesApi(f.APIClient())
+
+	request := datadogV1.NewServiceLevelObjectiveRequest("test SLO", []datadogV1.SLOThreshold{{Target: 99, Timeframe: datadogV1.SLOTIMEFRAME_SEVEN_DAYS}}, datadogV1.SLOTYPE_MONITOR)
+	request.SetMonitorIds([]int64{12345})
+	created, _, err := client.CreateSLO(f.Auth(), *request)
+	require.NoError(t, err)
+	require.Len(t, created.Data, 1)
+	id := created.Data[0].GetId()
+
+	slo := created.Data[0]
+	slo.SetName("updated SLO")
+	_, _, err = client.UpdateSLO(f.Auth(), id, slo)
+	require.NoError(t, err)
+	got, _, err := client.GetSLO(f.Auth(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "updated SLO", got.Data.GetName())
+	assert.Equal(t, []int64{12345}, got.Data.GetMonitorIds())
+
+	f.SetSLOHistory(id, 99.5)
+	history, _, err := client.GetSLOHistory(f.Auth(), id, time.Now().Add(-time.Hour).Unix(), time.Now().Unix())
+	require.NoError(t, err)
+	assert.Equal(t, 99.5, history.Data.Overall.GetSliValue())
+
+	_, _, err = client.DeleteSLO(f.Auth(), id)
+	require.NoError(t, err)
+	_, found := f.SLO(id)
+	assert.False(t, found)
+}
+
+func TestFakeDatadogAPI_Downtimes(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	client := datadogV1.NewDowntimesApi(f.APIClient())
+
+	created, _, err := client.CreateDowntime(f.Auth(), datadogV1.Downtime{Scope: []string{"env:prod"}, MonitorId: *datadogapi.NewNullableInt64(datadogapi.PtrInt64(12345))})
+	require.NoError(t, err)
+	_, _, err = client.UpdateDowntime(f.Auth(), created.GetId(), datadogV1.Downtime{Message: *datadogapi.NewNullableString(datadogapi.PtrString("maintenance"))})
+	require.NoError(t, err)
+	got, _, err := client.GetDowntime(f.Auth(), created.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "maintenance", got.GetMessage())
+	assert.Equal(t, []string{"env:prod"}, got.GetScope())
+
+	_, err = client.CancelDowntime(f.Auth(), created.GetId())
+	require.NoError(t, err)
+	_, found := f.Downtime(created.GetId())
+	assert.False(t, found)
+}
+
+func TestFakeDatadogAPI_MetricsForwarder(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	f.SetInvalidAPIKey("invalid")
+
+	client := zorkian.NewClient(FakeAPIKey, "")
+	client.SetBaseUrl(f.URL())
+	valid, err := client.Validate()
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	invalidClient := zorkian.NewClient("invalid", "")
+	invalidClient.SetBaseUrl(f.URL())
+	valid, err = invalidClient.Validate()
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	require.NoError(t, client.PostMetrics([]zorkian.Metric{{Metric: zorkian.String("datadog.operator.reconcile.success"), Points: []zorkian.DataPoint{{zorkian.Float64(1), zorkian.Float64(1)}}}}))
+	_, err = client.PostEvent(&zorkian.Event{Title: zorkian.String("DatadogAgent updated")})
+	require.NoError(t, err)
+
+	require.Len(t, f.Series(), 1)
+	assert.Equal(t, "datadog.operator.reconcile.success", f.Series()[0].GetMetric())
+	require.Len(t, f.Events(), 1)
+	assert.Equal(t, "DatadogAgent updated", f.Events()[0].GetTitle())
+}
+
+func TestFakeDatadogAPI_Errors(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	client := datadogV1.NewMonitorsApi(f.APIClient())
+	id := f.SetMonitor(datadogV1.Monitor{Query: "avg(last_5m):avg:system.cpu.user{*} > 90", Type: datadogV1.MONITORTYPE_METRIC_ALERT})
+
+	f.InjectError(http.MethodGet, "/api/v1/monitor/*", http.StatusInternalServerError, 1)
+	_, _, err := client.GetMonitor(f.Auth(), id)
+	assert.EqualError(t, err, "500 Internal Server Error")
+	_, _, err = client.GetMonitor(f.Auth(), id)
+	assert.NoError(t, err)
+
+	f.InjectError(http.MethodGet, "/api/v1/monitor/*", http.StatusForbidden, 0)
+	for i := 0; i < 2; i++ {
+		_, _, err = client.GetMonitor(f.Auth(), id)
+		assert.EqualError(t, err, "403 Forbidden")
+	}
+	f.ClearErrors()
+	_, _, err = client.GetMonitor(f.Auth(), id)
+	assert.NoError(t, err)
+
+	requests := f.Requests()
+	require.Len(t, requests, 5)
+	assert.Equal(t, http.MethodGet, requests[0].Method)
+	assert.Equal(t, "/api/v1/monitor/1001", requests[0].Path)
+	assert.Equal(t, FakeAPIKey, requests[0].Header.Get("DD-API-KEY"))
+}
+
+func TestFakeDatadogAPI_RateLimit(t *testing.T) {
+	f := NewFakeDatadogAPI()
+	defer f.Close()
+	client := datadogV1.NewMonitorsApi(f.APIClient())
+	f.SetRateLimit(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, resp, err := client.ListMonitors(f.Auth())
+		require.NoError(t, err)
+		assert.Equal(t, "2", resp.Header.Get("X-RateLimit-Limit"))
+		assert.Equal(t, "60", resp.Header.Get("X-RateLimit-Period"))
+	}
+	_, resp, err := client.ListMonitors(f.Auth())
+	assert.EqualError(t, err, "429 Too Many Requests")
+	assert.Equal(t, "0", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", resp.Header.Get("X-RateLimit-Reset"))
+}